@@ -9,6 +9,8 @@ import (
 	"api/internal/services"
 	"log"
 	"net/http"
+	// Embedded zone database, so IANA zones resolve on hosts without tzdata.
+	_ "time/tzdata"
 
 	"github.com/gin-gonic/gin"
 )
@@ -25,25 +27,84 @@ func main() {
 		log.Fatal("Failed to migrate database:", err)
 	}
 
+	if cfg.Database.LegacyTimeZone != "" {
+		if err := db.MigrateTimesToUTC(cfg.Database.LegacyTimeZone); err != nil {
+			log.Fatal("Failed to convert stored times to UTC:", err)
+		}
+	}
+
 	userRepo := repositories.NewUserRepository(db.DB)
+	passwordResetRepo := repositories.NewPasswordResetRepository(db.DB)
+	apiTokenRepo := repositories.NewAPITokenRepository(db.DB)
+	rolePermissionRepo := repositories.NewRolePermissionRepository(db.DB)
+	groupRepo := repositories.NewGroupRepository(db.DB)
+	siteRepo := repositories.NewSiteRepository(db.DB)
+	buildingRepo := repositories.NewBuildingRepository(db.DB)
+	floorRepo := repositories.NewFloorRepository(db.DB)
 	roomRepo := repositories.NewRoomRepository(db.DB)
 	roomFeatureRepo := repositories.NewRoomFeatureRepository(db.DB)
+	bookingPolicyRepo := repositories.NewBookingPolicyRepository(db.DB)
+	blackoutRepo := repositories.NewBlackoutRepository(db.DB)
+	equipmentRepo := repositories.NewEquipmentRepository(db.DB)
+	deskBookingRepo := repositories.NewDeskBookingRepository(db.DB)
+	waitlistRepo := repositories.NewWaitlistRepository(db.DB)
+	bumpRepo := repositories.NewMeetingBumpRepository(db.DB)
+	delegateRepo := repositories.NewDelegateRepository(db.DB)
 	meetingRepo := repositories.NewMeetingRepository(db.DB)
 	dashboardRepo := repositories.NewDashboardRepository(db.DB)
 
-	authService := services.NewAuthService(userRepo, cfg)
-	userService := services.NewUserService(userRepo)
-	roomService := services.NewRoomService(roomRepo, roomFeatureRepo)
-	meetingService := services.NewMeetingService(meetingRepo, roomRepo, userRepo)
+	authzService := services.NewAuthorizationService(rolePermissionRepo)
+	if err := authzService.Load(); err != nil {
+		log.Fatal("Failed to load role permissions:", err)
+	}
+
+	locationService := services.NewLocationService(siteRepo, buildingRepo, floorRepo)
+	notifier := services.NewLogNotifier()
+	bookingPolicyService, err := services.NewBookingPolicyService(bookingPolicyRepo, roomRepo, meetingRepo, cfg.Booking)
+	if err != nil {
+		log.Fatal("Failed to initialize booking policies:", err)
+	}
+	roomService := services.NewRoomService(roomRepo, roomFeatureRepo, floorRepo, meetingRepo, userRepo, groupRepo, authzService, bookingPolicyService, notifier)
+	blackoutService := services.NewBlackoutService(blackoutRepo, roomRepo, buildingRepo, bookingPolicyService)
+	equipmentService := services.NewEquipmentService(equipmentRepo, siteRepo)
+	deskService := services.NewDeskService(deskBookingRepo, roomRepo, userRepo, groupRepo, authzService)
+	meetingService := services.NewMeetingService(meetingRepo, roomRepo, userRepo, groupRepo, equipmentRepo, waitlistRepo, delegateRepo, authzService, bookingPolicyService, notifier, cfg.Booking)
+	meetingService.StartApprovalSweeper(cfg.Booking.ApprovalSweepInterval)
+	meetingService.StartWaitlistSweeper(cfg.Booking.WaitlistSweepInterval)
+	meetingService.StartHoldSweeper(cfg.Booking.HoldSweepInterval)
+	apiTokenService := services.NewAPITokenService(apiTokenRepo, userRepo)
+	groupService := services.NewGroupService(groupRepo, userRepo, meetingRepo, meetingService)
+	authService, err := services.NewAuthService(userRepo, passwordResetRepo, apiTokenService, groupService, cfg)
+	if err != nil {
+		log.Fatal("Failed to initialize auth service:", err)
+	}
+	authService.StartKeyReloader(cfg.Auth.JWTKeysReloadInterval)
+	bumpService := services.NewBumpService(meetingService, roomService, meetingRepo, roomRepo, bumpRepo, userRepo, authzService, notifier)
+	userService := services.NewUserService(userRepo, floorRepo, meetingService)
+	delegateService := services.NewDelegateService(delegateRepo, userRepo)
 	dashboardService := services.NewDashboardService(dashboardRepo)
 
+	if err := authService.BootstrapAdmin(); err != nil {
+		log.Fatal("Failed to bootstrap local admin:", err)
+	}
+
 	authHandler := handlers.NewAuthHandler(authService)
-	userHandler := handlers.NewUserHandler(userService)
+	userHandler := handlers.NewUserHandler(userService, authzService)
+	apiTokenHandler := handlers.NewAPITokenHandler(apiTokenService, userService)
+	permissionHandler := handlers.NewPermissionHandler(authzService)
+	groupHandler := handlers.NewGroupHandler(groupService)
+	locationHandler := handlers.NewLocationHandler(locationService)
 	roomHandler := handlers.NewRoomHandler(roomService)
+	bookingPolicyHandler := handlers.NewBookingPolicyHandler(bookingPolicyService)
+	blackoutHandler := handlers.NewBlackoutHandler(blackoutService)
+	equipmentHandler := handlers.NewEquipmentHandler(equipmentService)
+	deskHandler := handlers.NewDeskHandler(deskService)
 	meetingHandler := handlers.NewMeetingHandler(meetingService)
+	bumpHandler := handlers.NewBumpHandler(bumpService)
+	delegateHandler := handlers.NewDelegateHandler(delegateService)
 	dashboardHandler := handlers.NewDashboardHandler(dashboardService)
 
-	r := setupRouter(cfg, authService, authHandler, userHandler, roomHandler, meetingHandler, dashboardHandler)
+	r := setupRouter(cfg, authService, authzService, userService, meetingService, delegateService, authHandler, userHandler, apiTokenHandler, permissionHandler, groupHandler, locationHandler, roomHandler, bookingPolicyHandler, blackoutHandler, equipmentHandler, deskHandler, meetingHandler, bumpHandler, delegateHandler, dashboardHandler)
 
 	log.Printf("Server starting on http://%s:%s", cfg.Server.Host, cfg.Server.Port)
 	if err := r.Run(cfg.Server.Host + ":" + cfg.Server.Port); err != nil {
@@ -54,10 +115,24 @@ func main() {
 func setupRouter(
 	cfg *config.Config,
 	authService *services.AuthService,
+	authzService *services.AuthorizationService,
+	userService *services.UserService,
+	meetingService *services.MeetingService,
+	delegateService *services.DelegateService,
 	authHandler *handlers.AuthHandler,
 	userHandler *handlers.UserHandler,
+	apiTokenHandler *handlers.APITokenHandler,
+	permissionHandler *handlers.PermissionHandler,
+	groupHandler *handlers.GroupHandler,
+	locationHandler *handlers.LocationHandler,
 	roomHandler *handlers.RoomHandler,
+	bookingPolicyHandler *handlers.BookingPolicyHandler,
+	blackoutHandler *handlers.BlackoutHandler,
+	equipmentHandler *handlers.EquipmentHandler,
+	deskHandler *handlers.DeskHandler,
 	meetingHandler *handlers.MeetingHandler,
+	bumpHandler *handlers.BumpHandler,
+	delegateHandler *handlers.DelegateHandler,
 	dashboardHandler *handlers.DashboardHandler,
 ) *gin.Engine {
 	r := gin.New()
@@ -75,6 +150,8 @@ func setupRouter(
 		})
 	})
 
+	r.GET("/.well-known/jwks.json", authHandler.JWKS)
+
 	api := r.Group("/api/v1")
 
 	auth := api.Group("/auth")
@@ -83,69 +160,218 @@ func setupRouter(
 		auth.GET("/callback", authHandler.Callback)
 		auth.POST("/refresh", authHandler.RefreshToken)
 		auth.POST("/logout", middleware.AuthMiddleware(authService), authHandler.Logout)
-		auth.GET("/me", middleware.AuthMiddleware(authService), authHandler.Me)
+		auth.GET("/me", middleware.AuthMiddleware(authService), authHandler.GetProfile)
+
+		if authService.LocalAuthEnabled() {
+			auth.POST("/local/login", authHandler.LocalLogin)
+			auth.POST("/local/password-reset", authHandler.RequestPasswordReset)
+			auth.POST("/local/password-reset/confirm", authHandler.ResetPassword)
+		}
+
+		if authService.DevLoginEnabled() {
+			auth.POST("/dev/login", authHandler.DevLogin)
+		}
 	}
 
 	users := api.Group("/users")
-	users.Use(middleware.AuthMiddleware(authService))
+	users.Use(middleware.AuthMiddleware(authService), middleware.RequireResourceScope("users"), middleware.RequirePermission(authzService, models.PermUserRead))
 	{
-		users.POST("", middleware.RequireRole(models.RoleAdmin), userHandler.CreateUser)
-		users.GET("", userHandler.GetAllUsers)
+		users.POST("", middleware.RequirePermission(authzService, models.PermUserAdmin), userHandler.CreateUser)
+		users.GET("", userHandler.GetUsers)
 		users.GET("/active", userHandler.GetActiveUsers)
 		users.GET("/search", userHandler.SearchUsers)
-		users.GET("/:id", userHandler.GetUser)
-		users.PUT("/:id", middleware.RequireOwnerOrRole(models.RoleManager), userHandler.UpdateUser)
-		users.DELETE("/:id", middleware.RequireRole(models.RoleAdmin), userHandler.DeleteUser)
-		users.PUT("/:id/role", middleware.RequireRole(models.RoleAdmin), userHandler.UpdateUserRole)
-		users.POST("/:id/activate", middleware.RequireRole(models.RoleAdmin), userHandler.ActivateUser)
-		users.POST("/:id/deactivate", middleware.RequireRole(models.RoleAdmin), userHandler.DeactivateUser)
+		users.GET("/:id", userHandler.GetUserByID)
+		users.PUT("/:id", middleware.RequireOwnership(authzService, models.ActionUserUpdate, middleware.UserResource("id")), userHandler.UpdateUser)
+		users.DELETE("/:id", middleware.RequirePermission(authzService, models.PermUserAdmin), userHandler.DeleteUser)
+		users.PUT("/:id/role", middleware.RequirePermission(authzService, models.PermUserAdmin), userHandler.UpdateUserRole)
+		users.POST("/:id/activate", middleware.RequirePermission(authzService, models.PermUserAdmin), userHandler.ActivateUser)
+		users.POST("/:id/deactivate", middleware.RequirePermission(authzService, models.PermUserAdmin), userHandler.DeactivateUser)
+		users.POST("/:id/password-reset", middleware.RequirePermission(authzService, models.PermUserAdmin), authHandler.IssuePasswordReset)
+	}
+
+	tokens := api.Group("/tokens")
+	tokens.Use(middleware.AuthMiddleware(authService), middleware.RequireInteractiveAuth())
+	{
+		tokens.POST("", apiTokenHandler.CreateToken)
+		tokens.GET("", apiTokenHandler.GetTokens)
+		tokens.DELETE("/:id", apiTokenHandler.RevokeToken)
+	}
+
+	serviceAccounts := api.Group("/service-accounts")
+	serviceAccounts.Use(middleware.AuthMiddleware(authService), middleware.RequireInteractiveAuth(), middleware.RequirePermission(authzService, models.PermUserAdmin))
+	{
+		serviceAccounts.POST("", apiTokenHandler.CreateServiceAccount)
+		serviceAccounts.GET("", apiTokenHandler.GetServiceAccounts)
+		serviceAccounts.POST("/:id/tokens", apiTokenHandler.CreateServiceAccountToken)
+		serviceAccounts.GET("/:id/tokens", apiTokenHandler.GetServiceAccountTokens)
+	}
+
+	permissions := api.Group("/permissions")
+	permissions.Use(middleware.AuthMiddleware(authService), middleware.RequireInteractiveAuth(), middleware.RequirePermission(authzService, models.PermUserAdmin))
+	{
+		permissions.GET("", permissionHandler.GetPermissions)
+		permissions.GET("/roles", permissionHandler.GetRolePermissions)
+		permissions.PUT("/roles/:role", permissionHandler.UpdateRolePermissions)
+	}
+
+	groups := api.Group("/groups")
+	groups.Use(middleware.AuthMiddleware(authService), middleware.RequireResourceScope("users"), middleware.RequirePermission(authzService, models.PermUserRead))
+	{
+		groups.POST("", middleware.RequirePermission(authzService, models.PermGroupManage), groupHandler.CreateGroup)
+		groups.GET("", groupHandler.GetAllGroups)
+		groups.GET("/:id", groupHandler.GetGroup)
+		groups.PUT("/:id", middleware.RequirePermission(authzService, models.PermGroupManage), groupHandler.UpdateGroup)
+		groups.DELETE("/:id", middleware.RequirePermission(authzService, models.PermGroupManage), groupHandler.DeleteGroup)
+		groups.POST("/:id/members", middleware.RequirePermission(authzService, models.PermGroupManage), groupHandler.AddMember)
+		groups.DELETE("/:id/members/:user_id", middleware.RequirePermission(authzService, models.PermGroupManage), groupHandler.RemoveMember)
+	}
+
+	sites := api.Group("/sites")
+	sites.Use(middleware.AuthMiddleware(authService), middleware.RequireResourceScope("rooms"), middleware.RequirePermission(authzService, models.PermRoomRead))
+	{
+		sites.POST("", middleware.RequirePermission(authzService, models.PermRoomManage), locationHandler.CreateSite)
+		sites.GET("", locationHandler.GetAllSites)
+		sites.GET("/:id", locationHandler.GetSite)
+		sites.PUT("/:id", middleware.RequirePermission(authzService, models.PermRoomManage), locationHandler.UpdateSite)
+		sites.DELETE("/:id", middleware.RequirePermission(authzService, models.PermRoomManage), locationHandler.DeleteSite)
+	}
+
+	buildings := api.Group("/buildings")
+	buildings.Use(middleware.AuthMiddleware(authService), middleware.RequireResourceScope("rooms"), middleware.RequirePermission(authzService, models.PermRoomRead))
+	{
+		buildings.POST("", middleware.RequirePermission(authzService, models.PermRoomManage), locationHandler.CreateBuilding)
+		buildings.GET("", locationHandler.GetAllBuildings)
+		buildings.GET("/:id", locationHandler.GetBuilding)
+		buildings.PUT("/:id", middleware.RequirePermission(authzService, models.PermRoomManage), locationHandler.UpdateBuilding)
+		buildings.DELETE("/:id", middleware.RequirePermission(authzService, models.PermRoomManage), locationHandler.DeleteBuilding)
+	}
+
+	floors := api.Group("/floors")
+	floors.Use(middleware.AuthMiddleware(authService), middleware.RequireResourceScope("rooms"), middleware.RequirePermission(authzService, models.PermRoomRead))
+	{
+		floors.POST("", middleware.RequirePermission(authzService, models.PermRoomManage), locationHandler.CreateFloor)
+		floors.GET("", locationHandler.GetAllFloors)
+		floors.GET("/:id", locationHandler.GetFloor)
+		floors.PUT("/:id", middleware.RequirePermission(authzService, models.PermRoomManage), locationHandler.UpdateFloor)
+		floors.DELETE("/:id", middleware.RequirePermission(authzService, models.PermRoomManage), locationHandler.DeleteFloor)
 	}
 
 	rooms := api.Group("/rooms")
-	rooms.Use(middleware.AuthMiddleware(authService))
+	rooms.Use(middleware.AuthMiddleware(authService), middleware.RequireResourceScope("rooms"), middleware.RequirePermission(authzService, models.PermRoomRead))
 	{
-		rooms.POST("", middleware.RequireRole(models.RoleManager), roomHandler.CreateRoom)
+		rooms.POST("", middleware.RequirePermission(authzService, models.PermRoomManage), roomHandler.CreateRoom)
 		rooms.GET("", roomHandler.GetAllRooms)
 		rooms.GET("/active", roomHandler.GetActiveRooms)
 		rooms.GET("/available", roomHandler.GetAvailableRooms)
 		rooms.GET("/search", roomHandler.SearchRooms)
+		rooms.GET("/recommendations", roomHandler.RecommendRooms)
+		rooms.GET("/booking-policy", bookingPolicyHandler.GetDefaultPolicy)
 		rooms.GET("/:id", roomHandler.GetRoom)
-		rooms.PUT("/:id", middleware.RequireRole(models.RoleManager), roomHandler.UpdateRoom)
-		rooms.DELETE("/:id", middleware.RequireRole(models.RoleManager), roomHandler.DeleteRoom)
+		rooms.PUT("/:id", middleware.RequirePermission(authzService, models.PermRoomManage), roomHandler.UpdateRoom)
+		rooms.DELETE("/:id", middleware.RequirePermission(authzService, models.PermRoomManage), roomHandler.DeleteRoom)
 		rooms.GET("/:id/availability", roomHandler.CheckRoomAvailability)
+		rooms.GET("/:id/relocation-plan", middleware.RequirePermission(authzService, models.PermRoomManage), roomHandler.GetRelocationPlan)
+		rooms.GET("/:id/booking-policy", bookingPolicyHandler.GetRoomPolicy)
+		rooms.PUT("/:id/booking-policy", middleware.RequirePermission(authzService, models.PermRoomManage), bookingPolicyHandler.UpdateRoomPolicy)
+		rooms.DELETE("/:id/booking-policy", middleware.RequirePermission(authzService, models.PermRoomManage), bookingPolicyHandler.DeleteRoomPolicy)
 	}
 
 	roomFeatures := api.Group("/room-features")
-	roomFeatures.Use(middleware.AuthMiddleware(authService))
+	roomFeatures.Use(middleware.AuthMiddleware(authService), middleware.RequireResourceScope("rooms"), middleware.RequirePermission(authzService, models.PermRoomRead))
 	{
-		roomFeatures.POST("", middleware.RequireRole(models.RoleManager), roomHandler.CreateFeature)
+		roomFeatures.POST("", middleware.RequirePermission(authzService, models.PermRoomManage), roomHandler.CreateFeature)
 		roomFeatures.GET("", roomHandler.GetAllFeatures)
 		roomFeatures.GET("/:id", roomHandler.GetFeature)
-		roomFeatures.PUT("/:id", middleware.RequireRole(models.RoleManager), roomHandler.UpdateFeature)
-		roomFeatures.DELETE("/:id", middleware.RequireRole(models.RoleManager), roomHandler.DeleteFeature)
+		roomFeatures.PUT("/:id", middleware.RequirePermission(authzService, models.PermRoomManage), roomHandler.UpdateFeature)
+		roomFeatures.DELETE("/:id", middleware.RequirePermission(authzService, models.PermRoomManage), roomHandler.DeleteFeature)
+	}
+
+	equipment := api.Group("/equipment")
+	equipment.Use(middleware.AuthMiddleware(authService), middleware.RequireResourceScope("rooms"), middleware.RequirePermission(authzService, models.PermRoomRead))
+	{
+		equipment.POST("", middleware.RequirePermission(authzService, models.PermRoomManage), equipmentHandler.CreateEquipment)
+		equipment.GET("", equipmentHandler.GetAllEquipment)
+		equipment.GET("/availability", equipmentHandler.GetEquipmentAvailability)
+		equipment.GET("/:id", equipmentHandler.GetEquipment)
+		equipment.PUT("/:id", middleware.RequirePermission(authzService, models.PermRoomManage), equipmentHandler.UpdateEquipment)
+		equipment.DELETE("/:id", middleware.RequirePermission(authzService, models.PermRoomManage), equipmentHandler.DeleteEquipment)
+	}
+
+	meetingResource := middleware.MeetingResource(meetingService, "id")
+	attendeeResource := middleware.AttendeeResource(meetingService, "id", "user_id")
+	delegatedMeetingResource := middleware.DelegatedMeetingResource(meetingService, delegateService, "id", models.DelegateScopeManage)
+
+	blackouts := api.Group("/blackouts")
+	blackouts.Use(middleware.AuthMiddleware(authService), middleware.RequireResourceScope("rooms"), middleware.RequirePermission(authzService, models.PermRoomRead))
+	{
+		blackouts.POST("", middleware.RequirePermission(authzService, models.PermRoomManage), blackoutHandler.CreateBlackout)
+		blackouts.GET("", blackoutHandler.GetAllBlackouts)
+		blackouts.GET("/:id", blackoutHandler.GetBlackout)
+		blackouts.GET("/:id/conflicts", middleware.RequirePermission(authzService, models.PermRoomManage), blackoutHandler.GetConflictingMeetings)
+		blackouts.PUT("/:id", middleware.RequirePermission(authzService, models.PermRoomManage), blackoutHandler.UpdateBlackout)
+		blackouts.DELETE("/:id", middleware.RequirePermission(authzService, models.PermRoomManage), blackoutHandler.DeleteBlackout)
+	}
+
+	desks := api.Group("/desks")
+	desks.Use(middleware.AuthMiddleware(authService), middleware.RequireResourceScope("rooms"), middleware.RequirePermission(authzService, models.PermRoomRead), middleware.ResolveTimeZone(userService))
+	{
+		desks.GET("", deskHandler.GetDesks)
+		desks.GET("/available", deskHandler.GetAvailableDesks)
+	}
+
+	deskBookings := api.Group("/desk-bookings")
+	deskBookings.Use(middleware.AuthMiddleware(authService), middleware.RequireResourceScope("meetings"), middleware.ResolveTimeZone(userService))
+	{
+		deskBookings.POST("", deskHandler.BookDesk)
+		deskBookings.GET("", deskHandler.GetDeskBookings)
+		deskBookings.GET("/:id", deskHandler.GetDeskBooking)
+		deskBookings.POST("/:id/cancel", deskHandler.CancelDeskBooking)
+		deskBookings.POST("/:id/check-in", deskHandler.CheckIn)
 	}
 
 	meetings := api.Group("/meetings")
-	meetings.Use(middleware.AuthMiddleware(authService))
+	meetings.Use(middleware.AuthMiddleware(authService), middleware.RequireResourceScope("meetings"), middleware.ResolveTimeZone(userService))
 	{
 		meetings.POST("", meetingHandler.CreateMeeting)
 		meetings.GET("", meetingHandler.GetAllMeetings)
 		meetings.GET("/filter", meetingHandler.GetMeetingsByFilter)
 		meetings.GET("/upcoming", meetingHandler.GetUpcomingMeetings)
 		meetings.GET("/date-range", meetingHandler.GetMeetingsByDateRange)
+		meetings.GET("/approvals", meetingHandler.GetApprovalQueue)
+		meetings.POST("/bump", bumpHandler.BumpMeeting)
+		meetings.POST("/transfer", meetingHandler.TransferUserMeetings)
+		meetings.GET("/bumps", bumpHandler.GetBumps)
+		meetings.POST("/waitlist", meetingHandler.JoinWaitlist)
+		meetings.GET("/waitlist", meetingHandler.GetWaitlist)
+		meetings.DELETE("/waitlist/:id", meetingHandler.LeaveWaitlist)
+		meetings.POST("/waitlist/:id/accept", meetingHandler.AcceptWaitlistOffer)
 		meetings.GET("/:id", meetingHandler.GetMeeting)
-		meetings.PUT("/:id", meetingHandler.UpdateMeeting)
-		meetings.DELETE("/:id", meetingHandler.DeleteMeeting)
-		meetings.POST("/:id/start", meetingHandler.StartMeeting)
-		meetings.POST("/:id/complete", meetingHandler.CompleteMeeting)
-		meetings.POST("/:id/cancel", meetingHandler.CancelMeeting)
+		meetings.GET("/:id/occurrences", meetingHandler.GetMeetingOccurrences)
+		meetings.GET("/:id/activity", meetingHandler.GetMeetingActivity)
+		meetings.PUT("/:id", middleware.RequireOwnership(authzService, models.ActionMeetingUpdate, delegatedMeetingResource), meetingHandler.UpdateMeeting)
+		meetings.DELETE("/:id", middleware.RequireOwnership(authzService, models.ActionMeetingDelete, meetingResource), meetingHandler.DeleteMeeting)
+		meetings.POST("/:id/start", middleware.RequireOwnership(authzService, models.ActionMeetingStatus, meetingResource), meetingHandler.StartMeeting)
+		meetings.POST("/:id/complete", middleware.RequireOwnership(authzService, models.ActionMeetingStatus, meetingResource), meetingHandler.CompleteMeeting)
+		meetings.POST("/:id/cancel", middleware.RequireOwnership(authzService, models.ActionMeetingStatus, delegatedMeetingResource), meetingHandler.CancelMeeting)
+		meetings.POST("/:id/transfer", middleware.RequireOwnership(authzService, models.ActionMeetingUpdate, meetingResource), meetingHandler.TransferMeeting)
+		meetings.POST("/:id/confirm", middleware.RequireOwnership(authzService, models.ActionMeetingStatus, meetingResource), meetingHandler.ConfirmMeeting)
+		meetings.POST("/:id/approve", meetingHandler.ApproveMeeting)
+		meetings.POST("/:id/reject", meetingHandler.RejectMeeting)
 		meetings.GET("/:id/attendees", meetingHandler.GetMeetingAttendees)
-		meetings.POST("/:id/attendees", meetingHandler.AddAttendee)
-		meetings.DELETE("/:id/attendees/:user_id", meetingHandler.RemoveAttendee)
+		meetings.POST("/:id/attendees", middleware.RequireOwnership(authzService, models.ActionMeetingAttendees, meetingResource), meetingHandler.AddAttendee)
+		meetings.DELETE("/:id/attendees/:user_id", middleware.RequireOwnership(authzService, models.ActionMeetingAttendees, attendeeResource), meetingHandler.RemoveAttendee)
+	}
+
+	delegates := api.Group("/delegates")
+	delegates.Use(middleware.AuthMiddleware(authService), middleware.RequireResourceScope("meetings"))
+	{
+		delegates.GET("", delegateHandler.GetDelegates)
+		delegates.POST("", middleware.RequireInteractiveAuth(), delegateHandler.GrantDelegate)
+		delegates.DELETE("/:id", middleware.RequireInteractiveAuth(), delegateHandler.RevokeDelegate)
 	}
 
 	dashboard := api.Group("/dashboard")
-	dashboard.Use(middleware.AuthMiddleware(authService))
+	dashboard.Use(middleware.AuthMiddleware(authService), middleware.RequireResourceScope("dashboard"), middleware.RequirePermission(authzService, models.PermDashboardRead), middleware.ResolveTimeZone(userService))
 	{
 		dashboard.GET("/stats", dashboardHandler.GetDashboardStats)
 		dashboard.GET("/room-utilization", dashboardHandler.GetRoomUtilization)
@@ -162,8 +388,27 @@ func setupRouter(
 func migrateDatabase(db *config.Database) error {
 	return db.DB.AutoMigrate(
 		&models.User{},
+		&models.PasswordResetToken{},
+		&models.APIToken{},
+		&models.RolePermission{},
+		&models.AppliedDefaultPermission{},
+		&models.UserGroup{},
+		&models.Site{},
+		&models.Building{},
+		&models.Floor{},
 		&models.Room{},
+		&models.RoomAllowedRole{},
+		&models.RoomBookingPolicy{},
 		&models.RoomFeature{},
+		&models.RoomBlackout{},
 		&models.Meeting{},
+		&models.MeetingGroupAttendee{},
+		&models.Equipment{},
+		&models.EquipmentBooking{},
+		&models.DeskBooking{},
+		&models.WaitlistEntry{},
+		&models.MeetingBump{},
+		&models.DelegateGrant{},
+		&models.MeetingActivity{},
 	)
 }
\ No newline at end of file
@@ -3,12 +3,17 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
-	Database DatabaseConfig
-	Server   ServerConfig
-	Auth     AuthConfig
+	Environment string
+	Database    DatabaseConfig
+	Server      ServerConfig
+	Auth        AuthConfig
+	Booking     BookingConfig
 }
 
 type DatabaseConfig struct {
@@ -17,6 +22,10 @@ type DatabaseConfig struct {
 	User     string
 	Password string
 	Name     string
+	// LegacyTimeZone is the zone the server ran in when times were still
+	// stored in local time; it is only read to convert them to UTC once, and
+	// nothing is converted when it is empty.
+	LegacyTimeZone string
 }
 
 type ServerConfig struct {
@@ -28,28 +37,166 @@ type AuthConfig struct {
 	MicrosoftClientID     string
 	MicrosoftClientSecret string
 	MicrosoftRedirectURL  string
+	// MicrosoftSyncGroups mirrors the directory groups of users signing in
+	// with Microsoft as user groups.
+	MicrosoftSyncGroups   bool
 	JWTSecret             string
+	JWTKeysFile           string
+	JWTKeysReloadInterval time.Duration
+	LocalAuth             LocalAuthConfig
+	DevLoginEnabled       bool
 }
 
+type LocalAuthConfig struct {
+	Enabled           bool
+	PasswordHashAlgo  string
+	AdminEmail        string
+	AdminPassword     string
+	PasswordResetTTL  time.Duration
+	MinPasswordLength int
+}
+
+type BookingConfig struct {
+	ApprovalTTL           time.Duration
+	ApprovalSweepInterval time.Duration
+	// WaitlistOfferTTL is how long a user has to accept a freed slot offered
+	// from the waitlist before it goes to the next in line.
+	WaitlistOfferTTL      time.Duration
+	WaitlistSweepInterval time.Duration
+	// HoldTTL is how long a tentative booking holds its room when no expiry
+	// is given.
+	HoldTTL           time.Duration
+	HoldSweepInterval time.Duration
+	// RolePriorities is the booking priority each role books with. Only
+	// bookings of a lower priority can be bumped; unlisted roles get 0.
+	RolePriorities map[string]int
+	// DefaultTimeZone applies to rooms that are not attached to a site.
+	DefaultTimeZone string
+	Policy          BookingPolicyConfig
+}
+
+// BookingPolicyConfig is the global booking policy; rooms may override any
+// of it. Zero values disable the corresponding limit.
+type BookingPolicyConfig struct {
+	MinDurationMinutes  int
+	MaxDurationMinutes  int
+	MaxAdvanceDays      int
+	MinLeadTimeMinutes  int
+	EarliestStart       string
+	LatestEnd           string
+	AllowedDays         []string
+	MaxActiveBookings   int
+	CapacityEnforcement string
+}
+
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
+)
+
+const DefaultJWTSecret = "your-secret-key"
+
 func LoadConfig() *Config {
-	return &Config{
+	cfg := &Config{
+		Environment: getEnv("APP_ENV", EnvDevelopment),
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "3306"),
-			User:     getEnv("DB_USER", "root"),
-			Password: getEnv("DB_PASSWORD", ""),
-			Name:     getEnv("DB_NAME", "meeting_salt_db"),
+			Host:           getEnv("DB_HOST", "localhost"),
+			Port:           getEnv("DB_PORT", "3306"),
+			User:           getEnv("DB_USER", "root"),
+			Password:       getEnv("DB_PASSWORD", ""),
+			Name:           getEnv("DB_NAME", "meeting_salt_db"),
+			LegacyTimeZone: getOptionalEnv("DB_LEGACY_TIME_ZONE"),
 		},
 		Server: ServerConfig{
 			Port: getEnv("SERVER_PORT", "8080"),
 			Host: getEnv("SERVER_HOST", "localhost"),
 		},
 		Auth: AuthConfig{
-			MicrosoftClientID:     getEnv("MICROSOFT_CLIENT_ID", ""),
-			MicrosoftClientSecret: getEnv("MICROSOFT_CLIENT_SECRET", ""),
+			MicrosoftClientID:     getOptionalEnv("MICROSOFT_CLIENT_ID"),
+			MicrosoftClientSecret: getOptionalEnv("MICROSOFT_CLIENT_SECRET"),
 			MicrosoftRedirectURL:  getEnv("MICROSOFT_REDIRECT_URL", "http://localhost:8080/auth/callback"),
-			JWTSecret:             getEnv("JWT_SECRET", "your-secret-key"),
+			MicrosoftSyncGroups:   getEnvBool("MICROSOFT_SYNC_GROUPS", false),
+			JWTSecret:             getEnv("JWT_SECRET", DefaultJWTSecret),
+			JWTKeysFile:           getOptionalEnv("JWT_KEYS_FILE"),
+			JWTKeysReloadInterval: getEnvDuration("JWT_KEYS_RELOAD_INTERVAL", 0),
+			LocalAuth: LocalAuthConfig{
+				Enabled:           getEnvBool("LOCAL_AUTH_ENABLED", false),
+				PasswordHashAlgo:  getEnv("LOCAL_AUTH_HASH_ALGO", "bcrypt"),
+				AdminEmail:        getOptionalEnv("LOCAL_ADMIN_EMAIL"),
+				AdminPassword:     getOptionalEnv("LOCAL_ADMIN_PASSWORD"),
+				PasswordResetTTL:  getEnvDuration("PASSWORD_RESET_TTL", time.Hour),
+				MinPasswordLength: getEnvInt("LOCAL_AUTH_MIN_PASSWORD_LENGTH", 12),
+			},
+			DevLoginEnabled: getEnvBool("DEV_LOGIN_ENABLED", false),
 		},
+		Booking: BookingConfig{
+			ApprovalTTL:           getEnvDuration("BOOKING_APPROVAL_TTL", 48*time.Hour),
+			ApprovalSweepInterval: getEnvDuration("BOOKING_APPROVAL_SWEEP_INTERVAL", 5*time.Minute),
+			WaitlistOfferTTL:      getEnvDuration("BOOKING_WAITLIST_OFFER_TTL", time.Hour),
+			WaitlistSweepInterval: getEnvDuration("BOOKING_WAITLIST_SWEEP_INTERVAL", 5*time.Minute),
+			HoldTTL:               getEnvDuration("BOOKING_HOLD_TTL", 24*time.Hour),
+			HoldSweepInterval:     getEnvDuration("BOOKING_HOLD_SWEEP_INTERVAL", 5*time.Minute),
+			RolePriorities:        getEnvIntMap("BOOKING_ROLE_PRIORITIES", "employee=0,manager=1,admin=2"),
+			DefaultTimeZone:       getEnv("BOOKING_DEFAULT_TIME_ZONE", "UTC"),
+			Policy: BookingPolicyConfig{
+				MinDurationMinutes:  getEnvInt("BOOKING_MIN_DURATION_MINUTES", 0),
+				MaxDurationMinutes:  getEnvInt("BOOKING_MAX_DURATION_MINUTES", 0),
+				MaxAdvanceDays:      getEnvInt("BOOKING_MAX_ADVANCE_DAYS", 0),
+				MinLeadTimeMinutes:  getEnvInt("BOOKING_MIN_LEAD_TIME_MINUTES", 0),
+				EarliestStart:       getOptionalEnv("BOOKING_EARLIEST_START"),
+				LatestEnd:           getOptionalEnv("BOOKING_LATEST_END"),
+				AllowedDays:         getEnvList("BOOKING_ALLOWED_DAYS"),
+				MaxActiveBookings:   getEnvInt("BOOKING_MAX_ACTIVE_PER_USER", 0),
+				CapacityEnforcement: getEnv("BOOKING_CAPACITY_ENFORCEMENT", "hard"),
+			},
+		},
+	}
+
+	cfg.validate()
+	return cfg
+}
+
+func (c *Config) IsDevelopment() bool {
+	return c.Environment == EnvDevelopment
+}
+
+func (c *Config) IsProduction() bool {
+	return c.Environment == EnvProduction
+}
+
+func (c *Config) MicrosoftAuthEnabled() bool {
+	return c.Auth.MicrosoftClientID != "" && c.Auth.MicrosoftClientSecret != ""
+}
+
+// JWTFallbackSecret is the HS256 secret that still verifies tokens once
+// JWT_KEYS_FILE is set, so tokens issued before the switch stay valid. The
+// built-in default secret is never accepted that way.
+func (c *Config) JWTFallbackSecret() string {
+	if c.Auth.JWTSecret == DefaultJWTSecret {
+		return ""
+	}
+	return c.Auth.JWTSecret
+}
+
+func (c *Config) validate() {
+	if !c.MicrosoftAuthEnabled() && !c.Auth.LocalAuth.Enabled && !c.Auth.DevLoginEnabled {
+		log.Fatal("No authentication provider configured: set MICROSOFT_CLIENT_ID and MICROSOFT_CLIENT_SECRET, LOCAL_AUTH_ENABLED or DEV_LOGIN_ENABLED")
+	}
+
+	if c.Auth.JWTKeysFile == "" && c.Auth.JWTSecret == DefaultJWTSecret && !c.IsDevelopment() {
+		log.Fatal("Refusing to start with the default JWT_SECRET outside development: set JWT_KEYS_FILE or a strong JWT_SECRET")
+	}
+
+	if c.Auth.DevLoginEnabled && c.IsProduction() {
+		log.Fatal("DEV_LOGIN_ENABLED must not be set when APP_ENV is production")
+	}
+
+	if c.Auth.LocalAuth.PasswordHashAlgo != "bcrypt" && c.Auth.LocalAuth.PasswordHashAlgo != "argon2id" {
+		log.Fatalf("LOCAL_AUTH_HASH_ALGO must be bcrypt or argon2id, got %q", c.Auth.LocalAuth.PasswordHashAlgo)
+	}
+
+	if (c.Auth.LocalAuth.AdminEmail == "") != (c.Auth.LocalAuth.AdminPassword == "") {
+		log.Fatal("LOCAL_ADMIN_EMAIL and LOCAL_ADMIN_PASSWORD must be set together")
 	}
 }
 
@@ -61,4 +208,76 @@ func getEnv(key, defaultValue string) string {
 		log.Fatalf("Environment variable %s is required", key)
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
+
+func getOptionalEnv(key string) string {
+	return os.Getenv(key)
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Fatalf("Environment variable %s must be a boolean, got %q", key, value)
+	}
+	return parsed
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatalf("Environment variable %s must be an integer, got %q", key, value)
+	}
+	return parsed
+}
+
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvIntMap parses a comma-separated list of key=integer pairs.
+func getEnvIntMap(key, defaultValue string) map[string]int {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		value = defaultValue
+	}
+
+	values := make(map[string]int)
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		name, number, found := strings.Cut(pair, "=")
+		parsed, err := strconv.Atoi(strings.TrimSpace(number))
+		if !found || err != nil {
+			log.Fatalf("Environment variable %s must be a list of name=integer pairs, got %q", key, value)
+		}
+		values[strings.TrimSpace(name)] = parsed
+	}
+	return values
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return defaultValue
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("Environment variable %s must be a duration, got %q", key, value)
+	}
+	return parsed
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// utcTimesMigration names the one-off conversion of times written with the
+// server's local zone to UTC.
+const utcTimesMigration = "utc_times"
+
+type schemaMigration struct {
+	Name      string `gorm:"primaryKey;size:100"`
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// MigrateTimesToUTC converts the DATETIME columns of a database written
+// before times were stored in UTC from legacyTimeZone, the zone the API
+// server ran in, to UTC. It runs once; a database without users is new and
+// only records that it needs no conversion.
+func (d *Database) MigrateTimesToUTC(legacyTimeZone string) error {
+	if err := d.DB.AutoMigrate(&schemaMigration{}); err != nil {
+		return err
+	}
+
+	var applied int64
+	if err := d.DB.Model(&schemaMigration{}).Where("name = ?", utcTimesMigration).Count(&applied).Error; err != nil {
+		return err
+	}
+	if applied > 0 {
+		return nil
+	}
+
+	var users int64
+	if err := d.DB.Table("users").Count(&users).Error; err != nil {
+		return err
+	}
+	if users == 0 {
+		return d.DB.Create(&schemaMigration{Name: utcTimesMigration, AppliedAt: time.Now().UTC()}).Error
+	}
+
+	if _, err := time.LoadLocation(legacyTimeZone); err != nil {
+		return fmt.Errorf("invalid DB_LEGACY_TIME_ZONE %q: %v", legacyTimeZone, err)
+	}
+
+	return d.DB.Transaction(func(tx *gorm.DB) error {
+		if legacyTimeZone != "UTC" {
+			var probe struct {
+				Converted *time.Time
+			}
+			if err := tx.Raw("SELECT CONVERT_TZ('2000-01-01 00:00:00', ?, '+00:00') AS converted", legacyTimeZone).Scan(&probe).Error; err != nil {
+				return err
+			}
+			if probe.Converted == nil {
+				return fmt.Errorf("MySQL does not know the time zone %q; load its time zone tables with mysql_tzinfo_to_sql", legacyTimeZone)
+			}
+
+			var columns []struct {
+				TableName  string
+				ColumnName string
+			}
+			if err := tx.Raw(`SELECT TABLE_NAME AS table_name, COLUMN_NAME AS column_name
+				FROM information_schema.COLUMNS
+				WHERE TABLE_SCHEMA = DATABASE() AND DATA_TYPE = 'datetime' AND TABLE_NAME <> ?`,
+				schemaMigration{}.TableName()).Scan(&columns).Error; err != nil {
+				return err
+			}
+
+			for _, column := range columns {
+				result := tx.Exec(fmt.Sprintf("UPDATE `%s` SET `%s` = CONVERT_TZ(`%s`, ?, '+00:00') WHERE `%s` IS NOT NULL",
+					column.TableName, column.ColumnName, column.ColumnName, column.ColumnName), legacyTimeZone)
+				if result.Error != nil {
+					return result.Error
+				}
+				log.Printf("Converted %d values of %s.%s from %s to UTC", result.RowsAffected, column.TableName, column.ColumnName, legacyTimeZone)
+			}
+		}
+
+		return tx.Create(&schemaMigration{Name: utcTimesMigration, AppliedAt: time.Now().UTC()}).Error
+	})
+}
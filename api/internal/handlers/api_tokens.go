@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"api/internal/models"
+	"api/internal/services"
+	"api/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APITokenHandler struct {
+	apiTokenService *services.APITokenService
+	userService     *services.UserService
+}
+
+func NewAPITokenHandler(apiTokenService *services.APITokenService, userService *services.UserService) *APITokenHandler {
+	return &APITokenHandler{
+		apiTokenService: apiTokenService,
+		userService:     userService,
+	}
+}
+
+func (h *APITokenHandler) CreateToken(c *gin.Context) {
+	currentUserID, ok := currentUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreateAPITokenRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	token, err := h.apiTokenService.CreateToken(currentUserID, currentUserID, &req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, "API token created successfully", token)
+}
+
+func (h *APITokenHandler) GetTokens(c *gin.Context) {
+	currentUserID, ok := currentUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.apiTokenService.GetTokensByUser(currentUserID)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to retrieve API tokens")
+		return
+	}
+
+	utils.SuccessResponse(c, "API tokens retrieved successfully", tokens)
+}
+
+func (h *APITokenHandler) RevokeToken(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid token ID")
+		return
+	}
+
+	currentUserID, ok := currentUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.apiTokenService.RevokeToken(uint(id), currentUserID); err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "API token revoked successfully", nil)
+}
+
+func (h *APITokenHandler) CreateServiceAccount(c *gin.Context) {
+	var req models.CreateServiceAccountRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	user, err := h.userService.CreateServiceAccount(&req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, "Service account created successfully", user)
+}
+
+func (h *APITokenHandler) GetServiceAccounts(c *gin.Context) {
+	users, err := h.userService.GetServiceAccounts()
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to retrieve service accounts")
+		return
+	}
+
+	utils.SuccessResponse(c, "Service accounts retrieved successfully", users)
+}
+
+func (h *APITokenHandler) CreateServiceAccountToken(c *gin.Context) {
+	serviceAccount, ok := h.serviceAccountFromParam(c)
+	if !ok {
+		return
+	}
+
+	currentUserID, ok := currentUserIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var req models.CreateAPITokenRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	token, err := h.apiTokenService.CreateToken(serviceAccount.ID, currentUserID, &req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, "API token created successfully", token)
+}
+
+func (h *APITokenHandler) GetServiceAccountTokens(c *gin.Context) {
+	serviceAccount, ok := h.serviceAccountFromParam(c)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.apiTokenService.GetTokensByUser(serviceAccount.ID)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to retrieve API tokens")
+		return
+	}
+
+	utils.SuccessResponse(c, "API tokens retrieved successfully", tokens)
+}
+
+func (h *APITokenHandler) serviceAccountFromParam(c *gin.Context) (*models.User, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid service account ID")
+		return nil, false
+	}
+
+	user, err := h.userService.GetUserByID(uint(id))
+	if err != nil || !user.IsServiceAccount {
+		utils.NotFoundResponse(c, "Service account not found")
+		return nil, false
+	}
+
+	return user, true
+}
+
+func currentUserIDFromContext(c *gin.Context) (uint, bool) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return 0, false
+	}
+
+	currentUserID, ok := userID.(uint)
+	if !ok {
+		utils.UnauthorizedResponse(c, "Invalid user ID")
+		return 0, false
+	}
+
+	return currentUserID, true
+}
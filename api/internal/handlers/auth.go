@@ -2,10 +2,14 @@ package handlers
 
 import (
 	"api/internal/middleware"
+	"api/internal/models"
 	"api/internal/services"
 	"api/internal/utils"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,17 +24,6 @@ func NewAuthHandler(authService *services.AuthService) *AuthHandler {
 	}
 }
 
-func (h *AuthHandler) RegisterRoutes(router *gin.RouterGroup) {
-	auth := router.Group("/auth")
-	{
-		auth.GET("/login", h.Login)
-		auth.GET("/callback", h.Callback)
-		auth.POST("/refresh", h.RefreshToken)
-		auth.POST("/logout", h.Logout)
-		auth.GET("/me", middleware.AuthMiddleware(nil), h.GetProfile)
-	}
-}
-
 func (h *AuthHandler) Login(c *gin.Context) {
 	state, err := generateState()
 	if err != nil {
@@ -38,7 +31,11 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	authURL := h.authService.GetAuthURL(state)
+	authURL, err := h.authService.GetAuthURL(state)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
 
 	utils.SuccessResponse(c, "Authentication URL generated", gin.H{
 		"auth_url": authURL,
@@ -69,6 +66,122 @@ func (h *AuthHandler) Callback(c *gin.Context) {
 	utils.SuccessResponse(c, "Login successful", loginResponse)
 }
 
+func (h *AuthHandler) LocalLogin(c *gin.Context) {
+	var req models.LocalLoginRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	loginResponse, err := h.authService.LocalLogin(&req)
+	if err != nil {
+		if errors.Is(err, services.ErrLocalAuthDisabled) {
+			utils.NotFoundResponse(c, err.Error())
+			return
+		}
+		utils.UnauthorizedResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Login successful", loginResponse)
+}
+
+func (h *AuthHandler) DevLogin(c *gin.Context) {
+	var req models.DevLoginRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	loginResponse, err := h.authService.DevLogin(&req)
+	if err != nil {
+		if errors.Is(err, services.ErrDevLoginDisabled) {
+			utils.NotFoundResponse(c, err.Error())
+			return
+		}
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Dev login successful", loginResponse)
+}
+
+func (h *AuthHandler) RequestPasswordReset(c *gin.Context) {
+	var req models.PasswordResetRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	if err := h.authService.RequestPasswordReset(&req); err != nil {
+		if errors.Is(err, services.ErrLocalAuthDisabled) {
+			utils.NotFoundResponse(c, err.Error())
+			return
+		}
+		utils.InternalServerErrorResponse(c, "Failed to request password reset")
+		return
+	}
+
+	utils.SuccessResponse(c, "If the account exists, a password reset has been issued", nil)
+}
+
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req models.PasswordResetConfirmRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	if err := h.authService.ResetPassword(&req); err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Password has been reset", nil)
+}
+
+func (h *AuthHandler) IssuePasswordReset(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid user ID")
+		return
+	}
+
+	token, err := h.authService.IssuePasswordReset(uint(id))
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, "Password reset token issued", gin.H{
+		"token": token,
+	})
+}
+
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var request struct {
 		Token string `json:"token" binding:"required"`
@@ -90,6 +203,13 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	})
 }
 
+// JWKS serves the raw JWK Set document rather than the usual response
+// envelope, since it is consumed by standard JWT libraries.
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.authService.JWKS())
+}
+
 func (h *AuthHandler) Logout(c *gin.Context) {
 	utils.SuccessResponse(c, "Logged out successfully", nil)
 }
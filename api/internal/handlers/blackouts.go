@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/services"
+	"api/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BlackoutHandler struct {
+	blackoutService *services.BlackoutService
+}
+
+func NewBlackoutHandler(blackoutService *services.BlackoutService) *BlackoutHandler {
+	return &BlackoutHandler{
+		blackoutService: blackoutService,
+	}
+}
+
+func (h *BlackoutHandler) CreateBlackout(c *gin.Context) {
+	var req models.CreateRoomBlackoutRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	report, err := h.blackoutService.CreateBlackout(middleware.GetUserIDFromContext(c), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, "Blackout created successfully", report)
+}
+
+func (h *BlackoutHandler) GetBlackout(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid blackout ID")
+		return
+	}
+
+	blackout, err := h.blackoutService.GetBlackoutByID(uint(id))
+	if err != nil {
+		utils.NotFoundResponse(c, "Blackout not found")
+		return
+	}
+
+	utils.SuccessResponse(c, "Blackout retrieved successfully", blackout)
+}
+
+func (h *BlackoutHandler) GetAllBlackouts(c *gin.Context) {
+	var filter models.RoomBlackoutFilter
+	var ok bool
+
+	if filter.RoomID, ok = parseOptionalID(c, "room_id"); !ok {
+		utils.BadRequestResponse(c, "Invalid room_id")
+		return
+	}
+	if filter.BuildingID, ok = parseOptionalID(c, "building_id"); !ok {
+		utils.BadRequestResponse(c, "Invalid building_id")
+		return
+	}
+
+	pagination := utils.GetPaginationParams(c)
+
+	blackouts, meta, err := h.blackoutService.GetAllBlackouts(filter, pagination.Page, pagination.Limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to retrieve blackouts")
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Blackouts retrieved successfully", blackouts, meta)
+}
+
+func (h *BlackoutHandler) UpdateBlackout(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid blackout ID")
+		return
+	}
+
+	var req models.UpdateRoomBlackoutRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	report, err := h.blackoutService.UpdateBlackout(uint(id), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Blackout updated successfully", report)
+}
+
+func (h *BlackoutHandler) DeleteBlackout(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid blackout ID")
+		return
+	}
+
+	if err := h.blackoutService.DeleteBlackout(uint(id)); err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Blackout deleted successfully", nil)
+}
+
+func (h *BlackoutHandler) GetConflictingMeetings(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid blackout ID")
+		return
+	}
+
+	meetings, err := h.blackoutService.GetConflictingMeetings(uint(id))
+	if err != nil {
+		utils.NotFoundResponse(c, "Blackout not found")
+		return
+	}
+
+	utils.SuccessResponse(c, "Conflicting meetings retrieved successfully", meetings)
+}
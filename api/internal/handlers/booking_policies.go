@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"api/internal/models"
+	"api/internal/services"
+	"api/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookingPolicyHandler struct {
+	policyService *services.BookingPolicyService
+}
+
+func NewBookingPolicyHandler(policyService *services.BookingPolicyService) *BookingPolicyHandler {
+	return &BookingPolicyHandler{
+		policyService: policyService,
+	}
+}
+
+func (h *BookingPolicyHandler) GetDefaultPolicy(c *gin.Context) {
+	utils.SuccessResponse(c, "Booking policy retrieved successfully", h.policyService.GetDefaultPolicy())
+}
+
+func (h *BookingPolicyHandler) GetRoomPolicy(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid room ID")
+		return
+	}
+
+	policy, err := h.policyService.GetEffectivePolicy(uint(id))
+	if err != nil {
+		utils.NotFoundResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Booking policy retrieved successfully", policy)
+}
+
+func (h *BookingPolicyHandler) UpdateRoomPolicy(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid room ID")
+		return
+	}
+
+	var req models.UpdateRoomBookingPolicyRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	policy, err := h.policyService.UpdateRoomPolicy(uint(id), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Booking policy updated successfully", policy)
+}
+
+func (h *BookingPolicyHandler) DeleteRoomPolicy(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid room ID")
+		return
+	}
+
+	if err := h.policyService.DeleteRoomPolicy(uint(id)); err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Booking policy overrides removed successfully", nil)
+}
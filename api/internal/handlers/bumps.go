@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/services"
+	"api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BumpHandler struct {
+	bumpService *services.BumpService
+}
+
+func NewBumpHandler(bumpService *services.BumpService) *BumpHandler {
+	return &BumpHandler{
+		bumpService: bumpService,
+	}
+}
+
+func (h *BumpHandler) BumpMeeting(c *gin.Context) {
+	var req models.BumpMeetingRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	result, err := h.bumpService.BumpMeeting(middleware.GetUserIDFromContext(c), &req)
+	if err != nil {
+		serviceErrorResponse(c, err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Meeting booked by bumping lower-priority bookings", result)
+}
+
+// GetBumps lists the bump audit trail, optionally of one meeting or user.
+func (h *BumpHandler) GetBumps(c *gin.Context) {
+	var filter models.MeetingBumpFilter
+	var ok bool
+
+	if filter.MeetingID, ok = parseOptionalID(c, "meeting_id"); !ok {
+		utils.BadRequestResponse(c, "Invalid meeting_id")
+		return
+	}
+	if filter.UserID, ok = parseOptionalID(c, "user_id"); !ok {
+		utils.BadRequestResponse(c, "Invalid user_id")
+		return
+	}
+
+	pagination := utils.GetPaginationParams(c)
+
+	bumps, meta, err := h.bumpService.GetBumps(middleware.GetUserIDFromContext(c), filter, pagination.Page, pagination.Limit)
+	if err != nil {
+		serviceErrorResponse(c, err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Meeting bumps retrieved successfully", bumps, meta)
+}
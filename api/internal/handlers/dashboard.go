@@ -1,11 +1,11 @@
 package handlers
 
 import (
+	"api/internal/middleware"
 	"api/internal/models"
 	"api/internal/services"
 	"api/internal/utils"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -87,13 +87,15 @@ func (h *DashboardHandler) GetTopActiveUsers(c *gin.Context) {
 }
 
 func (h *DashboardHandler) GetRecentMeetings(c *gin.Context) {
+	filter := h.parseFilter(c)
+	
 	limitStr := c.DefaultQuery("limit", "10")
 	limit, err := strconv.Atoi(limitStr)
 	if err != nil || limit <= 0 {
 		limit = 10
 	}
 	
-	meetings, err := h.dashboardService.GetRecentMeetings(limit)
+	meetings, err := h.dashboardService.GetRecentMeetings(filter, limit)
 	if err != nil {
 		utils.InternalServerErrorResponse(c, "Failed to retrieve recent meetings")
 		return
@@ -103,7 +105,9 @@ func (h *DashboardHandler) GetRecentMeetings(c *gin.Context) {
 }
 
 func (h *DashboardHandler) GetUpcomingMeetings7d(c *gin.Context) {
-	meetings, err := h.dashboardService.GetUpcomingMeetings7d()
+	filter := h.parseFilter(c)
+	
+	meetings, err := h.dashboardService.GetUpcomingMeetings7d(filter)
 	if err != nil {
 		utils.InternalServerErrorResponse(c, "Failed to retrieve upcoming meetings")
 		return
@@ -116,13 +120,13 @@ func (h *DashboardHandler) parseFilter(c *gin.Context) models.DashboardFilter {
 	var filter models.DashboardFilter
 	
 	if startDateStr := c.Query("start_date"); startDateStr != "" {
-		if startDate, err := time.Parse("2006-01-02", startDateStr); err == nil {
+		if startDate, err := parseStartDate(c, startDateStr); err == nil {
 			filter.StartDate = &startDate
 		}
 	}
 	
 	if endDateStr := c.Query("end_date"); endDateStr != "" {
-		if endDate, err := time.Parse("2006-01-02", endDateStr); err == nil {
+		if endDate, err := parseEndDate(c, endDateStr); err == nil {
 			filter.EndDate = &endDate
 		}
 	}
@@ -134,6 +138,9 @@ func (h *DashboardHandler) parseFilter(c *gin.Context) models.DashboardFilter {
 		}
 	}
 	
+	filter.LocationFilter, _ = parseLocationFilter(c)
+	filter.TimeZone = middleware.GetTimeZoneFromContext(c)
+	
 	if userIDStr := c.Query("user_id"); userIDStr != "" {
 		if userID, err := strconv.ParseUint(userIDStr, 10, 32); err == nil {
 			userIDUint := uint(userID)
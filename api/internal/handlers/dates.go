@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"api/internal/middleware"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const dateLayout = "2006-01-02"
+
+// parseStartDate reads a YYYY-MM-DD date as midnight in the caller's time
+// zone.
+func parseStartDate(c *gin.Context, value string) (time.Time, error) {
+	return time.ParseInLocation(dateLayout, value, middleware.GetTimeZoneFromContext(c))
+}
+
+// parseEndDate reads a YYYY-MM-DD date as the end of that day in the caller's
+// time zone, so a range ending on a date includes the whole day. AddDate
+// keeps this right on days that are 23 or 25 hours long.
+func parseEndDate(c *gin.Context, value string) (time.Time, error) {
+	date, err := parseStartDate(c, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return date.AddDate(0, 0, 1), nil
+}
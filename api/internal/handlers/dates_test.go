@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseDates(t *testing.T) {
+	tests := []struct {
+		name      string
+		zone      string
+		date      string
+		wantStart string
+		wantHours float64
+	}{
+		{"UTC", "UTC", "2025-06-10", "2025-06-10T00:00:00Z", 24},
+		{"caller zone", "America/New_York", "2025-06-10", "2025-06-10T04:00:00Z", 24},
+		{"DST starts", "Europe/Berlin", "2025-03-30", "2025-03-29T23:00:00Z", 23},
+		{"DST ends", "Europe/Berlin", "2025-10-26", "2025-10-25T22:00:00Z", 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := time.LoadLocation(tt.zone)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Set("time_zone", loc)
+
+			start, err := parseStartDate(c, tt.date)
+			if err != nil {
+				t.Fatalf("parseStartDate() error = %v", err)
+			}
+			end, err := parseEndDate(c, tt.date)
+			if err != nil {
+				t.Fatalf("parseEndDate() error = %v", err)
+			}
+
+			if got := start.UTC().Format(time.RFC3339); got != tt.wantStart {
+				t.Errorf("start = %s, want %s", got, tt.wantStart)
+			}
+			if got := end.Sub(start).Hours(); got != tt.wantHours {
+				t.Errorf("range lasts %v hours, want %v", got, tt.wantHours)
+			}
+		})
+	}
+}
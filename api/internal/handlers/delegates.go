@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/services"
+	"api/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DelegateHandler struct {
+	delegateService *services.DelegateService
+}
+
+func NewDelegateHandler(delegateService *services.DelegateService) *DelegateHandler {
+	return &DelegateHandler{
+		delegateService: delegateService,
+	}
+}
+
+// GrantDelegate lets another user act for the caller.
+func (h *DelegateHandler) GrantDelegate(c *gin.Context) {
+	var req models.GrantDelegateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	grant, err := h.delegateService.GrantDelegate(middleware.GetUserIDFromContext(c), &req)
+	if err != nil {
+		serviceErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Delegate granted successfully", grant)
+}
+
+// GetDelegates lists the grants the caller gave and received.
+func (h *DelegateHandler) GetDelegates(c *gin.Context) {
+	grants, err := h.delegateService.GetDelegates(middleware.GetUserIDFromContext(c))
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to retrieve delegates")
+		return
+	}
+
+	utils.SuccessResponse(c, "Delegates retrieved successfully", grants)
+}
+
+func (h *DelegateHandler) RevokeDelegate(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid delegate grant ID")
+		return
+	}
+
+	if err := h.delegateService.RevokeDelegate(uint(id), middleware.GetUserIDFromContext(c)); err != nil {
+		serviceErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Delegate revoked successfully", nil)
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/services"
+	"api/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeskHandler struct {
+	deskService *services.DeskService
+}
+
+func NewDeskHandler(deskService *services.DeskService) *DeskHandler {
+	return &DeskHandler{
+		deskService: deskService,
+	}
+}
+
+func (h *DeskHandler) GetDesks(c *gin.Context) {
+	filter, err := parseRoomFilter(c)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	pagination := utils.GetPaginationParams(c)
+
+	desks, meta, err := h.deskService.GetDesks(filter, pagination.Page, pagination.Limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to retrieve desks")
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Desks retrieved successfully", desks, meta)
+}
+
+// GetAvailableDesks lists the desks that are free for the given date and
+// period, read in the caller's time zone.
+func (h *DeskHandler) GetAvailableDesks(c *gin.Context) {
+	dateStr := c.Query("date")
+	if dateStr == "" {
+		utils.BadRequestResponse(c, "date is required")
+		return
+	}
+
+	day, err := parseStartDate(c, dateStr)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid date format. Use YYYY-MM-DD")
+		return
+	}
+
+	filter, err := parseRoomFilter(c)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	subject, ok := middleware.GetSubjectFromContext(c)
+	if !ok {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+
+	period := models.DeskPeriod(c.DefaultQuery("period", string(models.DeskPeriodFullDay)))
+	desks, err := h.deskService.GetAvailableDesks(subject, filter, day, period)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Available desks retrieved successfully", desks)
+}
+
+func (h *DeskHandler) BookDesk(c *gin.Context) {
+	var req models.CreateDeskBookingRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	booking, err := h.deskService.BookDesk(middleware.GetUserIDFromContext(c), &req, middleware.GetTimeZoneFromContext(c))
+	if err != nil {
+		serviceErrorResponse(c, err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Desk booked successfully", booking)
+}
+
+// GetDeskBookings lists the caller's desk bookings, or with desk_id the
+// bookings of that desk, between the optional start_date and end_date.
+func (h *DeskHandler) GetDeskBookings(c *gin.Context) {
+	var filter models.DeskBookingFilter
+	var ok bool
+
+	if filter.DeskID, ok = parseOptionalID(c, "desk_id"); !ok {
+		utils.BadRequestResponse(c, "Invalid desk_id")
+		return
+	}
+	userID := middleware.GetUserIDFromContext(c)
+	if filter.DeskID == nil {
+		filter.UserID = &userID
+	}
+
+	if value := c.Query("start_date"); value != "" {
+		startDate, err := parseStartDate(c, value)
+		if err != nil {
+			utils.BadRequestResponse(c, "Invalid start_date format. Use YYYY-MM-DD")
+			return
+		}
+		filter.StartDate = &startDate
+	}
+	if value := c.Query("end_date"); value != "" {
+		endDate, err := parseEndDate(c, value)
+		if err != nil {
+			utils.BadRequestResponse(c, "Invalid end_date format. Use YYYY-MM-DD")
+			return
+		}
+		filter.EndDate = &endDate
+	}
+
+	pagination := utils.GetPaginationParams(c)
+
+	bookings, meta, err := h.deskService.GetDeskBookings(userID, filter, pagination.Page, pagination.Limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to retrieve desk bookings")
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Desk bookings retrieved successfully", bookings, meta)
+}
+
+func (h *DeskHandler) GetDeskBooking(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid desk booking ID")
+		return
+	}
+
+	booking, err := h.deskService.GetDeskBookingByID(uint(id), middleware.GetUserIDFromContext(c))
+	if err != nil {
+		serviceErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Desk booking retrieved successfully", booking)
+}
+
+func (h *DeskHandler) CancelDeskBooking(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid desk booking ID")
+		return
+	}
+
+	if err := h.deskService.CancelDeskBooking(uint(id), middleware.GetUserIDFromContext(c)); err != nil {
+		serviceErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Desk booking cancelled successfully", nil)
+}
+
+func (h *DeskHandler) CheckIn(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid desk booking ID")
+		return
+	}
+
+	booking, err := h.deskService.CheckIn(uint(id), middleware.GetUserIDFromContext(c))
+	if err != nil {
+		serviceErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Checked in successfully", booking)
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"api/internal/models"
+	"api/internal/services"
+	"api/internal/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EquipmentHandler struct {
+	equipmentService *services.EquipmentService
+}
+
+func NewEquipmentHandler(equipmentService *services.EquipmentService) *EquipmentHandler {
+	return &EquipmentHandler{
+		equipmentService: equipmentService,
+	}
+}
+
+func (h *EquipmentHandler) CreateEquipment(c *gin.Context) {
+	var req models.CreateEquipmentRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	equipment, err := h.equipmentService.CreateEquipment(&req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, "Equipment created successfully", equipment)
+}
+
+func (h *EquipmentHandler) GetEquipment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid equipment ID")
+		return
+	}
+
+	equipment, err := h.equipmentService.GetEquipmentByID(uint(id))
+	if err != nil {
+		utils.NotFoundResponse(c, "Equipment not found")
+		return
+	}
+
+	utils.SuccessResponse(c, "Equipment retrieved successfully", equipment)
+}
+
+func (h *EquipmentHandler) GetAllEquipment(c *gin.Context) {
+	filter, ok := parseEquipmentFilter(c)
+	if !ok {
+		utils.BadRequestResponse(c, "Invalid site_id")
+		return
+	}
+
+	pagination := utils.GetPaginationParams(c)
+
+	equipment, meta, err := h.equipmentService.GetAllEquipment(filter, pagination.Page, pagination.Limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to retrieve equipment")
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Equipment retrieved successfully", equipment, meta)
+}
+
+// GetEquipmentAvailability reports the free units of every active piece of
+// equipment between start_time and end_time.
+func (h *EquipmentHandler) GetEquipmentAvailability(c *gin.Context) {
+	startTimeStr := c.Query("start_time")
+	endTimeStr := c.Query("end_time")
+
+	if startTimeStr == "" || endTimeStr == "" {
+		utils.BadRequestResponse(c, "start_time and end_time are required")
+		return
+	}
+
+	startTime, err := time.Parse(time.RFC3339, startTimeStr)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid start_time format. Use RFC3339 format")
+		return
+	}
+
+	endTime, err := time.Parse(time.RFC3339, endTimeStr)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid end_time format. Use RFC3339 format")
+		return
+	}
+
+	filter, ok := parseEquipmentFilter(c)
+	if !ok {
+		utils.BadRequestResponse(c, "Invalid site_id")
+		return
+	}
+
+	availability, err := h.equipmentService.GetAvailability(filter, startTime, endTime)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Equipment availability retrieved successfully", availability)
+}
+
+func (h *EquipmentHandler) UpdateEquipment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid equipment ID")
+		return
+	}
+
+	var req models.UpdateEquipmentRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	equipment, err := h.equipmentService.UpdateEquipment(uint(id), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Equipment updated successfully", equipment)
+}
+
+func (h *EquipmentHandler) DeleteEquipment(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid equipment ID")
+		return
+	}
+
+	if err := h.equipmentService.DeleteEquipment(uint(id)); err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Equipment deleted successfully", nil)
+}
+
+// parseEquipmentFilter reads the category and site_id query parameters.
+func parseEquipmentFilter(c *gin.Context) (filter models.EquipmentFilter, ok bool) {
+	if category := c.Query("category"); category != "" {
+		filter.Category = &category
+	}
+	filter.SiteID, ok = parseOptionalID(c, "site_id")
+	return filter, ok
+}
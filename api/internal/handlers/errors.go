@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"api/internal/services"
+	"api/internal/utils"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serviceErrorResponse maps authorization failures to 403, booking policy
+// violations to a validation error response and everything else to the 400
+// the handlers have always returned for service errors.
+func serviceErrorResponse(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrPermissionDenied) {
+		utils.ForbiddenResponse(c, err.Error())
+		return
+	}
+
+	var policyErr *services.BookingPolicyError
+	if errors.As(err, &policyErr) {
+		utils.ValidationErrorResponse(c, policyErr.Violations)
+		return
+	}
+
+	utils.BadRequestResponse(c, err.Error())
+}
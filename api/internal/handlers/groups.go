@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"api/internal/models"
+	"api/internal/services"
+	"api/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GroupHandler struct {
+	groupService *services.GroupService
+}
+
+func NewGroupHandler(groupService *services.GroupService) *GroupHandler {
+	return &GroupHandler{
+		groupService: groupService,
+	}
+}
+
+func (h *GroupHandler) CreateGroup(c *gin.Context) {
+	var req models.CreateUserGroupRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	group, err := h.groupService.CreateGroup(&req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, "Group created successfully", group)
+}
+
+func (h *GroupHandler) GetGroup(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid group ID")
+		return
+	}
+
+	group, err := h.groupService.GetGroupByID(uint(id))
+	if err != nil {
+		utils.NotFoundResponse(c, "Group not found")
+		return
+	}
+
+	utils.SuccessResponse(c, "Group retrieved successfully", group)
+}
+
+func (h *GroupHandler) GetAllGroups(c *gin.Context) {
+	pagination := utils.GetPaginationParams(c)
+
+	groups, meta, err := h.groupService.GetAllGroups(pagination.Page, pagination.Limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to retrieve groups")
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Groups retrieved successfully", groups, meta)
+}
+
+func (h *GroupHandler) UpdateGroup(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid group ID")
+		return
+	}
+
+	var req models.UpdateUserGroupRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	group, err := h.groupService.UpdateGroup(uint(id), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Group updated successfully", group)
+}
+
+func (h *GroupHandler) DeleteGroup(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid group ID")
+		return
+	}
+
+	if err := h.groupService.DeleteGroup(uint(id)); err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Group deleted successfully", nil)
+}
+
+func (h *GroupHandler) AddMember(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid group ID")
+		return
+	}
+
+	var req struct {
+		UserID uint `json:"user_id" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if err := h.groupService.AddMember(uint(id), req.UserID); err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Member added successfully", nil)
+}
+
+func (h *GroupHandler) RemoveMember(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid group ID")
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid user ID")
+		return
+	}
+
+	if err := h.groupService.RemoveMember(uint(id), uint(userID)); err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Member removed successfully", nil)
+}
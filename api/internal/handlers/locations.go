@@ -0,0 +1,332 @@
+package handlers
+
+import (
+	"api/internal/models"
+	"api/internal/services"
+	"api/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LocationHandler struct {
+	locationService *services.LocationService
+}
+
+func NewLocationHandler(locationService *services.LocationService) *LocationHandler {
+	return &LocationHandler{
+		locationService: locationService,
+	}
+}
+
+// parseOptionalID reads an optional numeric query parameter. ok is false
+// when the parameter is present but not a valid ID.
+func parseOptionalID(c *gin.Context, name string) (id *uint, ok bool) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, true
+	}
+
+	parsed, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return nil, false
+	}
+
+	result := uint(parsed)
+	return &result, true
+}
+
+// parseLocationFilter reads the site_id, building_id and floor_id query
+// parameters shared by room and dashboard endpoints.
+func parseLocationFilter(c *gin.Context) (models.LocationFilter, bool) {
+	var filter models.LocationFilter
+	var ok bool
+
+	if filter.SiteID, ok = parseOptionalID(c, "site_id"); !ok {
+		return filter, false
+	}
+	if filter.BuildingID, ok = parseOptionalID(c, "building_id"); !ok {
+		return filter, false
+	}
+	if filter.FloorID, ok = parseOptionalID(c, "floor_id"); !ok {
+		return filter, false
+	}
+
+	return filter, true
+}
+
+func (h *LocationHandler) CreateSite(c *gin.Context) {
+	var req models.CreateSiteRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	site, err := h.locationService.CreateSite(&req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, "Site created successfully", site)
+}
+
+func (h *LocationHandler) GetSite(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid site ID")
+		return
+	}
+
+	site, err := h.locationService.GetSiteByID(uint(id))
+	if err != nil {
+		utils.NotFoundResponse(c, "Site not found")
+		return
+	}
+
+	utils.SuccessResponse(c, "Site retrieved successfully", site)
+}
+
+func (h *LocationHandler) GetAllSites(c *gin.Context) {
+	pagination := utils.GetPaginationParams(c)
+
+	sites, meta, err := h.locationService.GetAllSites(pagination.Page, pagination.Limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to retrieve sites")
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Sites retrieved successfully", sites, meta)
+}
+
+func (h *LocationHandler) UpdateSite(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid site ID")
+		return
+	}
+
+	var req models.UpdateSiteRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	site, err := h.locationService.UpdateSite(uint(id), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Site updated successfully", site)
+}
+
+func (h *LocationHandler) DeleteSite(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid site ID")
+		return
+	}
+
+	if err := h.locationService.DeleteSite(uint(id)); err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Site deleted successfully", nil)
+}
+
+func (h *LocationHandler) CreateBuilding(c *gin.Context) {
+	var req models.CreateBuildingRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	building, err := h.locationService.CreateBuilding(&req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, "Building created successfully", building)
+}
+
+func (h *LocationHandler) GetBuilding(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid building ID")
+		return
+	}
+
+	building, err := h.locationService.GetBuildingByID(uint(id))
+	if err != nil {
+		utils.NotFoundResponse(c, "Building not found")
+		return
+	}
+
+	utils.SuccessResponse(c, "Building retrieved successfully", building)
+}
+
+func (h *LocationHandler) GetAllBuildings(c *gin.Context) {
+	siteID, ok := parseOptionalID(c, "site_id")
+	if !ok {
+		utils.BadRequestResponse(c, "Invalid site ID")
+		return
+	}
+
+	pagination := utils.GetPaginationParams(c)
+
+	buildings, meta, err := h.locationService.GetAllBuildings(siteID, pagination.Page, pagination.Limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to retrieve buildings")
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Buildings retrieved successfully", buildings, meta)
+}
+
+func (h *LocationHandler) UpdateBuilding(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid building ID")
+		return
+	}
+
+	var req models.UpdateBuildingRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	building, err := h.locationService.UpdateBuilding(uint(id), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Building updated successfully", building)
+}
+
+func (h *LocationHandler) DeleteBuilding(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid building ID")
+		return
+	}
+
+	if err := h.locationService.DeleteBuilding(uint(id)); err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Building deleted successfully", nil)
+}
+
+func (h *LocationHandler) CreateFloor(c *gin.Context) {
+	var req models.CreateFloorRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	floor, err := h.locationService.CreateFloor(&req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, "Floor created successfully", floor)
+}
+
+func (h *LocationHandler) GetFloor(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid floor ID")
+		return
+	}
+
+	floor, err := h.locationService.GetFloorByID(uint(id))
+	if err != nil {
+		utils.NotFoundResponse(c, "Floor not found")
+		return
+	}
+
+	utils.SuccessResponse(c, "Floor retrieved successfully", floor)
+}
+
+func (h *LocationHandler) GetAllFloors(c *gin.Context) {
+	buildingID, ok := parseOptionalID(c, "building_id")
+	if !ok {
+		utils.BadRequestResponse(c, "Invalid building ID")
+		return
+	}
+
+	pagination := utils.GetPaginationParams(c)
+
+	floors, meta, err := h.locationService.GetAllFloors(buildingID, pagination.Page, pagination.Limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to retrieve floors")
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Floors retrieved successfully", floors, meta)
+}
+
+func (h *LocationHandler) UpdateFloor(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid floor ID")
+		return
+	}
+
+	var req models.UpdateFloorRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	floor, err := h.locationService.UpdateFloor(uint(id), &req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Floor updated successfully", floor)
+}
+
+func (h *LocationHandler) DeleteFloor(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid floor ID")
+		return
+	}
+
+	if err := h.locationService.DeleteFloor(uint(id)); err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Floor deleted successfully", nil)
+}
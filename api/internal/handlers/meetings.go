@@ -1,11 +1,11 @@
 package handlers
 
 import (
+	"api/internal/middleware"
 	"api/internal/models"
 	"api/internal/services"
 	"api/internal/utils"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -47,7 +47,7 @@ func (h *MeetingHandler) CreateMeeting(c *gin.Context) {
 	
 	meeting, err := h.meetingService.CreateMeeting(organizerID, &req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		serviceErrorResponse(c, err)
 		return
 	}
 	
@@ -71,6 +71,43 @@ func (h *MeetingHandler) GetMeeting(c *gin.Context) {
 	utils.SuccessResponse(c, "Meeting retrieved successfully", meeting)
 }
 
+func (h *MeetingHandler) GetMeetingOccurrences(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid meeting ID")
+		return
+	}
+	
+	startDateStr := c.Query("start_date")
+	endDateStr := c.Query("end_date")
+	
+	if startDateStr == "" || endDateStr == "" {
+		utils.BadRequestResponse(c, "start_date and end_date are required")
+		return
+	}
+	
+	startDate, err := parseStartDate(c, startDateStr)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid start_date format. Use YYYY-MM-DD")
+		return
+	}
+	
+	endDate, err := parseEndDate(c, endDateStr)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid end_date format. Use YYYY-MM-DD")
+		return
+	}
+	
+	occurrences, err := h.meetingService.GetMeetingOccurrences(uint(id), startDate, endDate)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+	
+	utils.SuccessResponse(c, "Meeting occurrences retrieved successfully", occurrences)
+}
+
 func (h *MeetingHandler) GetAllMeetings(c *gin.Context) {
 	pagination := utils.GetPaginationParams(c)
 	
@@ -112,7 +149,7 @@ func (h *MeetingHandler) UpdateMeeting(c *gin.Context) {
 	
 	meeting, err := h.meetingService.UpdateMeeting(uint(id), currentUserID, &req)
 	if err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		serviceErrorResponse(c, err)
 		return
 	}
 	
@@ -140,7 +177,7 @@ func (h *MeetingHandler) DeleteMeeting(c *gin.Context) {
 	}
 	
 	if err := h.meetingService.DeleteMeeting(uint(id), currentUserID); err != nil {
-		utils.BadRequestResponse(c, err.Error())
+		serviceErrorResponse(c, err)
 		return
 	}
 	
@@ -176,7 +213,7 @@ func (h *MeetingHandler) GetMeetingsByFilter(c *gin.Context) {
 	}
 	
 	if startDateStr := c.Query("start_date"); startDateStr != "" {
-		startDate, err := time.Parse("2006-01-02", startDateStr)
+		startDate, err := parseStartDate(c, startDateStr)
 		if err != nil {
 			utils.BadRequestResponse(c, "Invalid start_date format. Use YYYY-MM-DD")
 			return
@@ -185,7 +222,7 @@ func (h *MeetingHandler) GetMeetingsByFilter(c *gin.Context) {
 	}
 	
 	if endDateStr := c.Query("end_date"); endDateStr != "" {
-		endDate, err := time.Parse("2006-01-02", endDateStr)
+		endDate, err := parseEndDate(c, endDateStr)
 		if err != nil {
 			utils.BadRequestResponse(c, "Invalid end_date format. Use YYYY-MM-DD")
 			return
@@ -253,13 +290,13 @@ func (h *MeetingHandler) GetMeetingsByDateRange(c *gin.Context) {
 		return
 	}
 	
-	startDate, err := time.Parse("2006-01-02", startDateStr)
+	startDate, err := parseStartDate(c, startDateStr)
 	if err != nil {
 		utils.BadRequestResponse(c, "Invalid start_date format. Use YYYY-MM-DD")
 		return
 	}
 	
-	endDate, err := time.Parse("2006-01-02", endDateStr)
+	endDate, err := parseEndDate(c, endDateStr)
 	if err != nil {
 		utils.BadRequestResponse(c, "Invalid end_date format. Use YYYY-MM-DD")
 		return
@@ -293,8 +330,8 @@ func (h *MeetingHandler) StartMeeting(c *gin.Context) {
 		return
 	}
 	
-	if err := h.meetingService.StartMeeting(uint(id)); err != nil {
-		utils.BadRequestResponse(c, err.Error())
+	if err := h.meetingService.StartMeeting(uint(id), middleware.GetUserIDFromContext(c)); err != nil {
+		serviceErrorResponse(c, err)
 		return
 	}
 	
@@ -309,8 +346,8 @@ func (h *MeetingHandler) CompleteMeeting(c *gin.Context) {
 		return
 	}
 	
-	if err := h.meetingService.CompleteMeeting(uint(id)); err != nil {
-		utils.BadRequestResponse(c, err.Error())
+	if err := h.meetingService.CompleteMeeting(uint(id), middleware.GetUserIDFromContext(c)); err != nil {
+		serviceErrorResponse(c, err)
 		return
 	}
 	
@@ -337,14 +374,112 @@ func (h *MeetingHandler) CancelMeeting(c *gin.Context) {
 		return
 	}
 	
-	if err := h.meetingService.CancelMeeting(uint(id), currentUserID); err != nil {
-		utils.BadRequestResponse(c, err.Error())
+	var req models.CancelMeetingRequest
+	
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.BadRequestResponse(c, "Invalid request format")
+			return
+		}
+	}
+	
+	if err := h.meetingService.CancelMeeting(uint(id), currentUserID, req.OnBehalfOf); err != nil {
+		serviceErrorResponse(c, err)
 		return
 	}
 	
 	utils.SuccessResponse(c, "Meeting cancelled successfully", nil)
 }
 
+// GetMeetingActivity lists who created, changed and cancelled the meeting,
+// including the users delegates acted for.
+func (h *MeetingHandler) GetMeetingActivity(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid meeting ID")
+		return
+	}
+	
+	activity, err := h.meetingService.GetMeetingActivity(uint(id))
+	if err != nil {
+		utils.NotFoundResponse(c, "Meeting not found")
+		return
+	}
+	
+	utils.SuccessResponse(c, "Meeting activity retrieved successfully", activity)
+}
+
+func (h *MeetingHandler) TransferMeeting(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid meeting ID")
+		return
+	}
+	
+	var req models.TransferMeetingRequest
+	
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+	
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+	
+	meeting, err := h.meetingService.TransferMeeting(uint(id), middleware.GetUserIDFromContext(c), &req)
+	if err != nil {
+		serviceErrorResponse(c, err)
+		return
+	}
+	
+	utils.SuccessResponse(c, "Meeting transferred successfully", meeting)
+}
+
+// TransferUserMeetings hands all upcoming meetings of a user to a new
+// organizer.
+func (h *MeetingHandler) TransferUserMeetings(c *gin.Context) {
+	var req models.TransferMeetingsRequest
+	
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+	
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+	
+	report, err := h.meetingService.TransferUserMeetings(middleware.GetUserIDFromContext(c), &req)
+	if err != nil {
+		serviceErrorResponse(c, err)
+		return
+	}
+	
+	utils.SuccessResponse(c, "Meetings transferred successfully", report)
+}
+
+func (h *MeetingHandler) ConfirmMeeting(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid meeting ID")
+		return
+	}
+	
+	meeting, err := h.meetingService.ConfirmMeeting(uint(id), middleware.GetUserIDFromContext(c))
+	if err != nil {
+		serviceErrorResponse(c, err)
+		return
+	}
+	
+	utils.SuccessResponse(c, "Meeting confirmed successfully", meeting)
+}
+
 func (h *MeetingHandler) AddAttendee(c *gin.Context) {
 	idParam := c.Param("id")
 	meetingID, err := strconv.ParseUint(idParam, 10, 32)
@@ -362,12 +497,15 @@ func (h *MeetingHandler) AddAttendee(c *gin.Context) {
 		return
 	}
 	
-	if err := h.meetingService.AddAttendee(uint(meetingID), req.UserID); err != nil {
-		utils.BadRequestResponse(c, err.Error())
+	capacityWarning, err := h.meetingService.AddAttendee(uint(meetingID), req.UserID, middleware.GetUserIDFromContext(c))
+	if err != nil {
+		serviceErrorResponse(c, err)
 		return
 	}
 	
-	utils.SuccessResponse(c, "Attendee added successfully", nil)
+	utils.SuccessResponse(c, "Attendee added successfully", gin.H{
+		"capacity_warning": capacityWarning,
+	})
 }
 
 func (h *MeetingHandler) RemoveAttendee(c *gin.Context) {
@@ -385,8 +523,8 @@ func (h *MeetingHandler) RemoveAttendee(c *gin.Context) {
 		return
 	}
 	
-	if err := h.meetingService.RemoveAttendee(uint(meetingID), uint(userID)); err != nil {
-		utils.BadRequestResponse(c, err.Error())
+	if err := h.meetingService.RemoveAttendee(uint(meetingID), uint(userID), middleware.GetUserIDFromContext(c)); err != nil {
+		serviceErrorResponse(c, err)
 		return
 	}
 	
@@ -408,4 +546,61 @@ func (h *MeetingHandler) GetMeetingAttendees(c *gin.Context) {
 	}
 	
 	utils.SuccessResponse(c, "Meeting attendees retrieved successfully", attendees)
+}
+
+func (h *MeetingHandler) GetApprovalQueue(c *gin.Context) {
+	pagination := utils.GetPaginationParams(c)
+	
+	meetings, meta, err := h.meetingService.GetApprovalQueue(middleware.GetUserIDFromContext(c), pagination.Page, pagination.Limit)
+	if err != nil {
+		serviceErrorResponse(c, err)
+		return
+	}
+	
+	utils.PaginatedSuccessResponse(c, "Approval queue retrieved successfully", meetings, meta)
+}
+
+func (h *MeetingHandler) ApproveMeeting(c *gin.Context) {
+	h.decideApproval(c, true)
+}
+
+func (h *MeetingHandler) RejectMeeting(c *gin.Context) {
+	h.decideApproval(c, false)
+}
+
+func (h *MeetingHandler) decideApproval(c *gin.Context, approve bool) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid meeting ID")
+		return
+	}
+	
+	var req models.ApprovalDecisionRequest
+	
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.BadRequestResponse(c, "Invalid request format")
+			return
+		}
+	}
+	
+	userID := middleware.GetUserIDFromContext(c)
+	
+	if approve {
+		meeting, err := h.meetingService.ApproveMeeting(uint(id), userID, req.Note)
+		if err != nil {
+			serviceErrorResponse(c, err)
+			return
+		}
+		utils.SuccessResponse(c, "Meeting approved successfully", meeting)
+		return
+	}
+	
+	meeting, err := h.meetingService.RejectMeeting(uint(id), userID, req.Note)
+	if err != nil {
+		serviceErrorResponse(c, err)
+		return
+	}
+	utils.SuccessResponse(c, "Meeting rejected successfully", meeting)
 }
\ No newline at end of file
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"api/internal/models"
+	"api/internal/services"
+	"api/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PermissionHandler struct {
+	authzService *services.AuthorizationService
+}
+
+func NewPermissionHandler(authzService *services.AuthorizationService) *PermissionHandler {
+	return &PermissionHandler{
+		authzService: authzService,
+	}
+}
+
+func (h *PermissionHandler) GetPermissions(c *gin.Context) {
+	utils.SuccessResponse(c, "Permissions retrieved successfully", models.AllPermissions)
+}
+
+func (h *PermissionHandler) GetRolePermissions(c *gin.Context) {
+	utils.SuccessResponse(c, "Role permissions retrieved successfully", h.authzService.GetRolePermissions())
+}
+
+func (h *PermissionHandler) UpdateRolePermissions(c *gin.Context) {
+	role := models.UserRole(c.Param("role"))
+
+	var req models.UpdateRolePermissionsRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	if err := h.authzService.UpdateRolePermissions(role, req.Permissions); err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, "Role permissions updated successfully", h.authzService.GetRolePermissions()[role])
+}
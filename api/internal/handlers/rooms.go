@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"api/internal/middleware"
 	"api/internal/models"
 	"api/internal/services"
 	"api/internal/utils"
+	"errors"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -62,7 +65,13 @@ func (h *RoomHandler) GetRoom(c *gin.Context) {
 func (h *RoomHandler) GetAllRooms(c *gin.Context) {
 	pagination := utils.GetPaginationParams(c)
 	
-	rooms, meta, err := h.roomService.GetAllRooms(pagination.Page, pagination.Limit)
+	location, ok := parseLocationFilter(c)
+	if !ok {
+		utils.BadRequestResponse(c, "Invalid site_id, building_id or floor_id")
+		return
+	}
+	
+	rooms, meta, err := h.roomService.GetAllRooms(location, pagination.Page, pagination.Limit)
 	if err != nil {
 		utils.InternalServerErrorResponse(c, "Failed to retrieve rooms")
 		return
@@ -103,12 +112,32 @@ func (h *RoomHandler) DeleteRoom(c *gin.Context) {
 		return
 	}
 	
-	if err := h.roomService.DeleteRoom(uint(id)); err != nil {
+	relocate := c.Query("relocate") == "true"
+	
+	report, err := h.roomService.DeleteRoom(uint(id), relocate)
+	if err != nil {
 		utils.BadRequestResponse(c, err.Error())
 		return
 	}
 	
-	utils.SuccessResponse(c, "Room deactivated successfully", nil)
+	utils.SuccessResponse(c, "Room deactivated successfully", report)
+}
+
+func (h *RoomHandler) GetRelocationPlan(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.ParseUint(idParam, 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid room ID")
+		return
+	}
+	
+	report, err := h.roomService.GetRelocationPlan(uint(id))
+	if err != nil {
+		utils.NotFoundResponse(c, err.Error())
+		return
+	}
+	
+	utils.SuccessResponse(c, "Relocation plan retrieved successfully", report)
 }
 
 func (h *RoomHandler) GetActiveRooms(c *gin.Context) {
@@ -122,17 +151,24 @@ func (h *RoomHandler) GetActiveRooms(c *gin.Context) {
 }
 
 func (h *RoomHandler) SearchRooms(c *gin.Context) {
+	filter, err := parseRoomFilter(c)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+	
 	query := c.Query("q")
-	if query == "" {
-		utils.BadRequestResponse(c, "Search query is required")
+	if query == "" && !filter.HasFeatures() && filter.Capacity == nil && filter.Location.IsEmpty() &&
+		!filter.WheelchairAccessible && !filter.HearingLoop {
+		utils.BadRequestResponse(c, "Search query or filter is required")
 		return
 	}
 	
 	pagination := utils.GetPaginationParams(c)
 	
-	rooms, meta, err := h.roomService.SearchRooms(query, pagination.Page, pagination.Limit)
+	rooms, meta, err := h.roomService.SearchRooms(query, filter, pagination.Page, pagination.Limit)
 	if err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to search rooms")
+		utils.BadRequestResponse(c, err.Error())
 		return
 	}
 	
@@ -142,7 +178,6 @@ func (h *RoomHandler) SearchRooms(c *gin.Context) {
 func (h *RoomHandler) GetAvailableRooms(c *gin.Context) {
 	startTimeStr := c.Query("start_time")
 	endTimeStr := c.Query("end_time")
-	capacityStr := c.Query("capacity")
 	
 	if startTimeStr == "" || endTimeStr == "" {
 		utils.BadRequestResponse(c, "start_time and end_time are required")
@@ -161,18 +196,98 @@ func (h *RoomHandler) GetAvailableRooms(c *gin.Context) {
 		return
 	}
 	
+	filter, err := parseRoomFilter(c)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+	
 	req := &models.RoomAvailabilityQuery{
-		StartTime: startTime,
-		EndTime:   endTime,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		RoomFilter: filter,
+	}
+	
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+	
+	subject, ok := middleware.GetSubjectFromContext(c)
+	if !ok {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+	
+	booker, err := h.roomService.BookerFor(subject)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to resolve room access")
+		return
+	}
+	req.Booker = booker
+	
+	rooms, err := h.roomService.GetAvailableRooms(req)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+	
+	utils.SuccessResponse(c, "Available rooms retrieved successfully", rooms)
+}
+
+func (h *RoomHandler) RecommendRooms(c *gin.Context) {
+	startTime, err := time.Parse(time.RFC3339, c.Query("start_time"))
+	if err != nil {
+		utils.BadRequestResponse(c, "start_time is required in RFC3339 format")
+		return
+	}
+	
+	endTime, err := time.Parse(time.RFC3339, c.Query("end_time"))
+	if err != nil {
+		utils.BadRequestResponse(c, "end_time is required in RFC3339 format")
+		return
+	}
+	
+	filter, err := parseRoomFilter(c)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+	
+	req := &models.RoomRecommendationQuery{
+		StartTime:   startTime,
+		EndTime:     endTime,
+		RoomFilter:  filter,
+		OrganizerID: middleware.GetUserIDFromContext(c),
 	}
 	
-	if capacityStr != "" {
-		capacity, err := strconv.Atoi(capacityStr)
+	for _, value := range splitQueryList(c.Query("attendee_ids")) {
+		id, err := strconv.ParseUint(value, 10, 32)
 		if err != nil {
-			utils.BadRequestResponse(c, "Invalid capacity value")
+			utils.BadRequestResponse(c, "Invalid attendee_ids value")
 			return
 		}
-		req.Capacity = &capacity
+		req.AttendeeIDs = append(req.AttendeeIDs, uint(id))
+	}
+	
+	if value := c.Query("attendee_count"); value != "" {
+		if req.AttendeeCount, err = strconv.Atoi(value); err != nil {
+			utils.BadRequestResponse(c, "Invalid attendee_count value")
+			return
+		}
+	}
+	
+	if value := c.Query("limit"); value != "" {
+		if req.Limit, err = strconv.Atoi(value); err != nil {
+			utils.BadRequestResponse(c, "Invalid limit value")
+			return
+		}
+	}
+	
+	var ok bool
+	if req.PreferredBuildingID, ok = parseOptionalID(c, "preferred_building_id"); !ok {
+		utils.BadRequestResponse(c, "Invalid preferred_building_id")
+		return
 	}
 	
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
@@ -180,13 +295,80 @@ func (h *RoomHandler) GetAvailableRooms(c *gin.Context) {
 		return
 	}
 	
-	rooms, err := h.roomService.GetAvailableRooms(req)
+	subject, ok := middleware.GetSubjectFromContext(c)
+	if !ok {
+		utils.UnauthorizedResponse(c, "User not authenticated")
+		return
+	}
+	
+	booker, err := h.roomService.BookerFor(subject)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to resolve room access")
+		return
+	}
+	req.Booker = booker
+	
+	recommendations, err := h.roomService.RecommendRooms(req)
 	if err != nil {
 		utils.BadRequestResponse(c, err.Error())
 		return
 	}
 	
-	utils.SuccessResponse(c, "Available rooms retrieved successfully", rooms)
+	utils.SuccessResponse(c, "Room recommendations retrieved successfully", recommendations)
+}
+
+// parseRoomFilter reads the capacity, location, feature and accessibility
+// query parameters shared by room search and availability. feature_ids and
+// features are comma-separated; feature_match is all (default) or any.
+func parseRoomFilter(c *gin.Context) (models.RoomFilter, error) {
+	var filter models.RoomFilter
+	
+	if value := c.Query("capacity"); value != "" {
+		capacity, err := strconv.Atoi(value)
+		if err != nil {
+			return filter, errors.New("invalid capacity value")
+		}
+		filter.Capacity = &capacity
+	}
+	
+	location, ok := parseLocationFilter(c)
+	if !ok {
+		return filter, errors.New("invalid site_id, building_id or floor_id")
+	}
+	filter.Location = location
+	filter.Zone = strings.TrimSpace(c.Query("zone"))
+	
+	for _, value := range splitQueryList(c.Query("feature_ids")) {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return filter, errors.New("invalid feature_ids value")
+		}
+		filter.FeatureIDs = append(filter.FeatureIDs, uint(id))
+	}
+	filter.FeatureNames = splitQueryList(c.Query("features"))
+	
+	switch c.DefaultQuery("feature_match", "all") {
+	case "all":
+	case "any":
+		filter.AnyFeature = true
+	default:
+		return filter, errors.New("feature_match must be all or any")
+	}
+	
+	filter.WheelchairAccessible = c.Query("wheelchair_accessible") == "true"
+	filter.HearingLoop = c.Query("hearing_loop") == "true"
+	
+	return filter, nil
+}
+
+func splitQueryList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
 
 func (h *RoomHandler) CheckRoomAvailability(c *gin.Context) {
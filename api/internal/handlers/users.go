@@ -11,29 +11,36 @@ import (
 )
 
 type UserHandler struct {
-	userService *services.UserService
+	userService  *services.UserService
+	authzService *services.AuthorizationService
 }
 
-func NewUserHandler(userService *services.UserService) *UserHandler {
+func NewUserHandler(userService *services.UserService, authzService *services.AuthorizationService) *UserHandler {
 	return &UserHandler{
-		userService: userService,
+		userService:  userService,
+		authzService: authzService,
 	}
 }
 
-func (h *UserHandler) RegisterRoutes(router *gin.RouterGroup, jwtManager *utils.JWTManager) {
-	users := router.Group("/users")
-	users.Use(middleware.AuthMiddleware(jwtManager))
-	{
-		users.GET("", h.GetUsers)
-		users.GET("/search", h.SearchUsers)
-		users.GET("/active", h.GetActiveUsers)
-		users.GET("/:id", h.GetUserByID)
-		users.PUT("/:id", h.UpdateUser)
-		users.DELETE("/:id", middleware.RequireAdmin(), h.DeleteUser)
-		users.PUT("/:id/role", middleware.RequireAdmin(), h.UpdateUserRole)
-		users.PUT("/:id/activate", middleware.RequireAdmin(), h.ActivateUser)
-		users.PUT("/:id/deactivate", middleware.RequireAdmin(), h.DeactivateUser)
+func (h *UserHandler) CreateUser(c *gin.Context) {
+	var request models.CreateUserRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		utils.BadRequestResponse(c, "Invalid request body")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&request); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
 	}
+
+	user, err := h.userService.CreateUser(&request)
+	if err != nil {
+		utils.BadRequestResponse(c, err.Error())
+		return
+	}
+
+	utils.CreatedResponse(c, "User created successfully", user)
 }
 
 func (h *UserHandler) GetUsers(c *gin.Context) {
@@ -99,13 +106,7 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	currentUserID := middleware.GetUserIDFromContext(c)
-	currentUserRole := middleware.GetUserRoleFromContext(c)
-
-	if uint(id) != currentUserID && currentUserRole != "admin" && currentUserRole != "manager" {
-		utils.ForbiddenResponse(c, "You can only update your own profile")
-		return
-	}
+	subject, _ := middleware.GetSubjectFromContext(c)
 
 	var request models.UpdateUserRequest
 	if err := c.ShouldBindJSON(&request); err != nil {
@@ -113,7 +114,7 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	if request.Role != nil && currentUserRole != "admin" {
+	if request.Role != nil && h.authzService.Authorize(subject, models.ActionUserAdmin, nil) != nil {
 		utils.ForbiddenResponse(c, "Only administrators can update user roles")
 		return
 	}
@@ -134,12 +135,19 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 		return
 	}
 
-	if err := h.userService.DeleteUser(uint(id)); err != nil {
+	transferTo, ok := parseOptionalID(c, "transfer_to")
+	if !ok {
+		utils.BadRequestResponse(c, "Invalid transfer_to")
+		return
+	}
+
+	report, err := h.userService.DeactivateUser(uint(id), middleware.GetUserIDFromContext(c), transferTo)
+	if err != nil {
 		utils.BadRequestResponse(c, err.Error())
 		return
 	}
 
-	utils.SuccessResponse(c, "User deleted successfully", nil)
+	utils.SuccessResponse(c, "User deleted successfully", report)
 }
 
 func (h *UserHandler) UpdateUserRole(c *gin.Context) {
@@ -188,10 +196,17 @@ func (h *UserHandler) DeactivateUser(c *gin.Context) {
 		return
 	}
 
-	if err := h.userService.DeactivateUser(uint(id)); err != nil {
+	transferTo, ok := parseOptionalID(c, "transfer_to")
+	if !ok {
+		utils.BadRequestResponse(c, "Invalid transfer_to")
+		return
+	}
+
+	report, err := h.userService.DeactivateUser(uint(id), middleware.GetUserIDFromContext(c), transferTo)
+	if err != nil {
 		utils.BadRequestResponse(c, err.Error())
 		return
 	}
 
-	utils.SuccessResponse(c, "User deactivated successfully", nil)
+	utils.SuccessResponse(c, "User deactivated successfully", report)
 }
\ No newline at end of file
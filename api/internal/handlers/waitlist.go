@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *MeetingHandler) JoinWaitlist(c *gin.Context) {
+	var req models.JoinWaitlistRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request format")
+		return
+	}
+
+	if validationErrors := utils.ValidateStruct(&req); len(validationErrors) > 0 {
+		utils.ValidationErrorResponse(c, validationErrors)
+		return
+	}
+
+	entry, err := h.meetingService.JoinWaitlist(middleware.GetUserIDFromContext(c), &req)
+	if err != nil {
+		serviceErrorResponse(c, err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Added to the waitlist successfully", entry)
+}
+
+func (h *MeetingHandler) GetWaitlist(c *gin.Context) {
+	pagination := utils.GetPaginationParams(c)
+
+	entries, meta, err := h.meetingService.GetWaitlist(middleware.GetUserIDFromContext(c), pagination.Page, pagination.Limit)
+	if err != nil {
+		utils.InternalServerErrorResponse(c, "Failed to retrieve waitlist")
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Waitlist retrieved successfully", entries, meta)
+}
+
+func (h *MeetingHandler) LeaveWaitlist(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid waitlist entry ID")
+		return
+	}
+
+	if err := h.meetingService.LeaveWaitlist(uint(id), middleware.GetUserIDFromContext(c)); err != nil {
+		serviceErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Removed from the waitlist successfully", nil)
+}
+
+func (h *MeetingHandler) AcceptWaitlistOffer(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid waitlist entry ID")
+		return
+	}
+
+	meeting, err := h.meetingService.AcceptWaitlistOffer(uint(id), middleware.GetUserIDFromContext(c))
+	if err != nil {
+		serviceErrorResponse(c, err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Meeting booked from the waitlist successfully", meeting)
+}
@@ -4,12 +4,17 @@ import (
 	"api/internal/models"
 	"api/internal/services"
 	"api/internal/utils"
-	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	AuthTypeJWT      = "jwt"
+	AuthTypeAPIToken = "api_token"
+)
+
 func AuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
@@ -26,18 +31,88 @@ func AuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
 			return
 		}
 
-		claims, err := authService.ValidateToken(bearerToken[1])
-		if err != nil {
+		if err := authenticate(c, authService, bearerToken[1]); err != nil {
 			utils.UnauthorizedResponse(c, "Invalid token")
 			c.Abort()
 			return
 		}
 
-		c.Set("user_id", claims.UserID)
-		c.Set("user_email", claims.Email)
-		c.Set("user_role", claims.Role)
-		c.Set("microsoft_id", claims.MicrosoftID)
-		c.Set("claims", claims)
+		c.Next()
+	}
+}
+
+// authenticate accepts either a JWT or a personal access token and stores the
+// resolved identity in the request context.
+func authenticate(c *gin.Context, authService *services.AuthService, token string) error {
+	if strings.HasPrefix(token, models.APITokenPrefix) {
+		apiToken, err := authService.AuthenticateAPIToken(token, c.ClientIP())
+		if err != nil {
+			return err
+		}
+
+		c.Set("user_id", apiToken.User.ID)
+		c.Set("user_email", apiToken.User.Email)
+		c.Set("user_role", string(apiToken.User.Role))
+		c.Set("microsoft_id", apiToken.User.GetMicrosoftID())
+		c.Set("auth_type", AuthTypeAPIToken)
+		c.Set("api_token", apiToken)
+		return nil
+	}
+
+	claims, err := authService.ValidateToken(token)
+	if err != nil {
+		return err
+	}
+
+	c.Set("user_id", claims.UserID)
+	c.Set("user_email", claims.Email)
+	c.Set("user_role", claims.Role)
+	c.Set("microsoft_id", claims.MicrosoftID)
+	c.Set("auth_type", AuthTypeJWT)
+	c.Set("claims", claims)
+	return nil
+}
+
+// RequireScope restricts requests authenticated with a personal access token
+// to tokens holding scope. JWT sessions act with the user's full rights and
+// are not scope-limited.
+func RequireScope(scope models.TokenScope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if value, exists := c.Get("api_token"); exists {
+			apiToken, ok := value.(*models.APIToken)
+			if !ok || !apiToken.HasScope(scope) {
+				utils.ForbiddenResponse(c, "API token is missing required scope "+string(scope))
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// RequireResourceScope picks the read or write scope of resource based on the
+// HTTP method, so a whole route group can be guarded at once.
+func RequireResourceScope(resource string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		access := "write"
+		if c.Request.Method == "GET" || c.Request.Method == "HEAD" {
+			access = "read"
+		}
+
+		RequireScope(models.TokenScope(resource + ":" + access))(c)
+	}
+}
+
+// RequireInteractiveAuth rejects personal access tokens, e.g. so a token
+// cannot be used to mint further tokens.
+func RequireInteractiveAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, exists := c.Get("api_token"); exists {
+			utils.ForbiddenResponse(c, "This endpoint cannot be used with an API token")
+			c.Abort()
+			return
+		}
 
 		c.Next()
 	}
@@ -81,6 +156,56 @@ func RequireRole(role models.UserRole) gin.HandlerFunc {
 	}
 }
 
+// RequirePermission allows the request when the caller's role holds
+// permission. Ownership-dependent checks are left to the services, which can
+// load the resource.
+func RequirePermission(authzService *services.AuthorizationService, permission models.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subject, ok := GetSubjectFromContext(c)
+		if !ok {
+			utils.ForbiddenResponse(c, "User not authenticated")
+			c.Abort()
+			return
+		}
+
+		if !authzService.HasPermission(subject.Role, permission) {
+			utils.ForbiddenResponse(c, "Permission "+string(permission)+" required")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func GetSubjectFromContext(c *gin.Context) (models.Subject, bool) {
+	userID, ok := c.Get("user_id")
+	if !ok {
+		return models.Subject{}, false
+	}
+	id, ok := userID.(uint)
+	if !ok {
+		return models.Subject{}, false
+	}
+
+	return models.Subject{
+		UserID: id,
+		Role:   models.UserRole(GetUserRoleFromContext(c)),
+	}, true
+}
+
+func GetUserIDFromContext(c *gin.Context) uint {
+	return c.GetUint("user_id")
+}
+
+func GetUserEmailFromContext(c *gin.Context) string {
+	return c.GetString("user_email")
+}
+
+func GetUserRoleFromContext(c *gin.Context) string {
+	return c.GetString("user_role")
+}
+
 func RequireOwnerOrRole(role models.UserRole) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID, exists := c.Get("user_id")
@@ -103,12 +228,10 @@ func RequireOwnerOrRole(role models.UserRole) gin.HandlerFunc {
 		}
 
 		if targetUserIDStr != "" {
-			var targetUserID uint
-			if _, err := utils.GetValidator().Var(&targetUserID, "required,numeric"); err == nil {
-				if currentUserID == targetUserID {
-					c.Next()
-					return
-				}
+			targetUserID, err := strconv.ParseUint(targetUserIDStr, 10, 32)
+			if err == nil && uint(targetUserID) == currentUserID {
+				c.Next()
+				return
 			}
 		}
 
@@ -161,17 +284,7 @@ func OptionalAuth(authService *services.AuthService) gin.HandlerFunc {
 			return
 		}
 
-		claims, err := authService.ValidateToken(bearerToken[1])
-		if err != nil {
-			c.Next()
-			return
-		}
-
-		c.Set("user_id", claims.UserID)
-		c.Set("user_email", claims.Email)
-		c.Set("user_role", claims.Role)
-		c.Set("microsoft_id", claims.MicrosoftID)
-		c.Set("claims", claims)
+		authenticate(c, authService, bearerToken[1])
 
 		c.Next()
 	}
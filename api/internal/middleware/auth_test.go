@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"api/internal/models"
+	"api/internal/services"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type fakeRolePermissionRepository struct {
+	rows    []*models.RolePermission
+	applied []*models.AppliedDefaultPermission
+}
+
+func (r *fakeRolePermissionRepository) GetAll() ([]*models.RolePermission, error) {
+	return r.rows, nil
+}
+
+func (r *fakeRolePermissionRepository) ReplaceForRole(role models.UserRole, permissions []models.Permission) error {
+	kept := r.rows[:0]
+	for _, row := range r.rows {
+		if row.Role != role {
+			kept = append(kept, row)
+		}
+	}
+	for _, permission := range permissions {
+		kept = append(kept, &models.RolePermission{Role: role, Permission: permission})
+	}
+	r.rows = kept
+	return nil
+}
+
+func (r *fakeRolePermissionRepository) GetAppliedDefaults() ([]*models.AppliedDefaultPermission, error) {
+	return r.applied, nil
+}
+
+func (r *fakeRolePermissionRepository) ApplyDefaults(role models.UserRole, permissions []models.Permission) error {
+	for _, permission := range permissions {
+		r.rows = append(r.rows, &models.RolePermission{Role: role, Permission: permission})
+		r.applied = append(r.applied, &models.AppliedDefaultPermission{Role: role, Permission: permission})
+	}
+	return nil
+}
+
+type fakeMeetingLookup map[uint]*models.Meeting
+
+func (f fakeMeetingLookup) GetMeetingByID(id uint) (*models.Meeting, error) {
+	meeting, ok := f[id]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	return meeting, nil
+}
+
+const (
+	ownerID    uint = 1
+	attendeeID uint = 2
+	managerID  uint = 3
+	adminID    uint = 4
+	strangerID uint = 5
+)
+
+type caller struct {
+	id   uint
+	role models.UserRole
+}
+
+var (
+	owner    = caller{ownerID, models.RoleEmployee}
+	attendee = caller{attendeeID, models.RoleEmployee}
+	manager  = caller{managerID, models.RoleManager}
+	admin    = caller{adminID, models.RoleAdmin}
+	stranger = caller{strangerID, models.RoleEmployee}
+)
+
+func newTestAuthorizationService(t *testing.T) *services.AuthorizationService {
+	t.Helper()
+	authzService := services.NewAuthorizationService(&fakeRolePermissionRepository{})
+	if err := authzService.Load(); err != nil {
+		t.Fatalf("failed to load permissions: %v", err)
+	}
+	return authzService
+}
+
+func performRequest(route, path string, who caller, guard gin.HandlerFunc) int {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", who.id)
+		c.Set("user_role", string(who.role))
+		c.Next()
+	})
+	r.Handle(http.MethodPut, route, guard, func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, path, nil)
+	r.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestRequireOwnerOrRole(t *testing.T) {
+	tests := []struct {
+		name   string
+		route  string
+		path   string
+		caller caller
+		want   int
+	}{
+		{"owner via id", "/users/:id", "/users/1", owner, http.StatusNoContent},
+		{"owner via user_id", "/meetings/:meeting_id/attendees/:user_id", "/meetings/9/attendees/1", owner, http.StatusNoContent},
+		{"manager", "/users/:id", "/users/1", manager, http.StatusNoContent},
+		{"admin", "/users/:id", "/users/1", admin, http.StatusNoContent},
+		{"stranger", "/users/:id", "/users/1", stranger, http.StatusForbidden},
+		{"stranger with malformed id", "/users/:id", "/users/abc", stranger, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := performRequest(tt.route, tt.path, tt.caller, RequireOwnerOrRole(models.RoleManager))
+			if got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireOwnership(t *testing.T) {
+	authzService := newTestAuthorizationService(t)
+	meetings := fakeMeetingLookup{
+		10: {ID: 10, OrganizerID: ownerID},
+	}
+
+	userGuard := RequireOwnership(authzService, models.ActionUserUpdate, UserResource("id"))
+	meetingGuard := RequireOwnership(authzService, models.ActionMeetingUpdate, MeetingResource(meetings, "id"))
+	attendeeGuard := RequireOwnership(authzService, models.ActionMeetingAttendees, AttendeeResource(meetings, "id", "user_id"))
+
+	tests := []struct {
+		name   string
+		route  string
+		path   string
+		guard  gin.HandlerFunc
+		caller caller
+		want   int
+	}{
+		{"user: owner", "/users/:id", "/users/1", userGuard, owner, http.StatusNoContent},
+		{"user: manager", "/users/:id", "/users/1", userGuard, manager, http.StatusNoContent},
+		{"user: admin", "/users/:id", "/users/1", userGuard, admin, http.StatusNoContent},
+		{"user: stranger", "/users/:id", "/users/1", userGuard, stranger, http.StatusForbidden},
+		{"user: invalid id", "/users/:id", "/users/abc", userGuard, owner, http.StatusBadRequest},
+
+		{"meeting: organizer", "/meetings/:id", "/meetings/10", meetingGuard, owner, http.StatusNoContent},
+		{"meeting: manager", "/meetings/:id", "/meetings/10", meetingGuard, manager, http.StatusNoContent},
+		{"meeting: admin", "/meetings/:id", "/meetings/10", meetingGuard, admin, http.StatusNoContent},
+		{"meeting: stranger", "/meetings/:id", "/meetings/10", meetingGuard, stranger, http.StatusForbidden},
+		{"meeting: attendee is not owner", "/meetings/:id", "/meetings/10", meetingGuard, attendee, http.StatusForbidden},
+		{"meeting: not found", "/meetings/:id", "/meetings/99", meetingGuard, owner, http.StatusNotFound},
+
+		{"attendee: organizer", "/meetings/:id/attendees/:user_id", "/meetings/10/attendees/2", attendeeGuard, owner, http.StatusNoContent},
+		{"attendee: self", "/meetings/:id/attendees/:user_id", "/meetings/10/attendees/2", attendeeGuard, attendee, http.StatusNoContent},
+		{"attendee: manager", "/meetings/:id/attendees/:user_id", "/meetings/10/attendees/2", attendeeGuard, manager, http.StatusNoContent},
+		{"attendee: admin", "/meetings/:id/attendees/:user_id", "/meetings/10/attendees/2", attendeeGuard, admin, http.StatusNoContent},
+		{"attendee: stranger", "/meetings/:id/attendees/:user_id", "/meetings/10/attendees/2", attendeeGuard, stranger, http.StatusForbidden},
+		{"attendee: meeting not found", "/meetings/:id/attendees/:user_id", "/meetings/99/attendees/2", attendeeGuard, attendee, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := performRequest(tt.route, tt.path, tt.caller, tt.guard)
+			if got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"api/internal/models"
+	"api/internal/services"
+	"api/internal/utils"
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errResourceNotFound = errors.New("resource not found")
+
+// ResourceResolver loads the resource a route operates on so its ownership
+// can be checked before the handler runs.
+type ResourceResolver func(c *gin.Context) (models.Resource, error)
+
+// MeetingLookup is the part of the meeting service the resolvers need.
+type MeetingLookup interface {
+	GetMeetingByID(id uint) (*models.Meeting, error)
+}
+
+// RequireOwnership authorizes action against the resource returned by
+// resolve, so ".own" permissions are granted to the resource's owner and
+// ".any" permissions to everybody holding them.
+func RequireOwnership(authzService *services.AuthorizationService, action models.Action, resolve ResourceResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subject, ok := GetSubjectFromContext(c)
+		if !ok {
+			utils.ForbiddenResponse(c, "User not authenticated")
+			c.Abort()
+			return
+		}
+
+		resource, err := resolve(c)
+		if err != nil {
+			if errors.Is(err, errResourceNotFound) {
+				utils.NotFoundResponse(c, "Resource not found")
+			} else {
+				utils.BadRequestResponse(c, err.Error())
+			}
+			c.Abort()
+			return
+		}
+
+		if err := authzService.Authorize(subject, action, resource); err != nil {
+			utils.ForbiddenResponse(c, err.Error())
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// UserResource resolves the user addressed by the route parameter param. The
+// user is not loaded: ownership only depends on the ID.
+func UserResource(param string) ResourceResolver {
+	return func(c *gin.Context) (models.Resource, error) {
+		id, err := parseIDParam(c, param)
+		if err != nil {
+			return nil, err
+		}
+		return &models.User{ID: id}, nil
+	}
+}
+
+// MeetingResource resolves the meeting addressed by param; it is owned by its
+// organizer.
+func MeetingResource(meetings MeetingLookup, param string) ResourceResolver {
+	return func(c *gin.Context) (models.Resource, error) {
+		id, err := parseIDParam(c, param)
+		if err != nil {
+			return nil, err
+		}
+
+		meeting, err := meetings.GetMeetingByID(id)
+		if err != nil {
+			return nil, errResourceNotFound
+		}
+		return meeting, nil
+	}
+}
+
+// DelegateLookup is the part of the delegate service the resolvers need.
+type DelegateLookup interface {
+	IsDelegate(principalID, delegateID uint, scope models.DelegateScope) (bool, error)
+}
+
+// DelegatedMeetingResource resolves the meeting addressed by param like
+// MeetingResource, except that a caller the organizer made a delegate
+// within scope shares its ownership. The handler still has to act on behalf
+// of the organizer.
+func DelegatedMeetingResource(meetings MeetingLookup, delegates DelegateLookup, param string, scope models.DelegateScope) ResourceResolver {
+	resolveMeeting := MeetingResource(meetings, param)
+
+	return func(c *gin.Context) (models.Resource, error) {
+		resource, err := resolveMeeting(c)
+		if err != nil {
+			return nil, err
+		}
+
+		meeting := resource.(*models.Meeting)
+		subject, ok := GetSubjectFromContext(c)
+		if !ok || meeting.IsOwnedBy(subject.UserID) {
+			return meeting, nil
+		}
+
+		delegated, err := delegates.IsDelegate(meeting.OrganizerID, subject.UserID, scope)
+		if err != nil {
+			return nil, err
+		}
+		if delegated {
+			return &models.DelegatedMeeting{Meeting: meeting, DelegateID: subject.UserID}, nil
+		}
+		return meeting, nil
+	}
+}
+
+// AttendeeResource resolves the attendee record identified by a meeting and a
+// user parameter; it is owned by the attendee and by the meeting organizer.
+func AttendeeResource(meetings MeetingLookup, meetingParam, userParam string) ResourceResolver {
+	return func(c *gin.Context) (models.Resource, error) {
+		meetingID, err := parseIDParam(c, meetingParam)
+		if err != nil {
+			return nil, err
+		}
+
+		userID, err := parseIDParam(c, userParam)
+		if err != nil {
+			return nil, err
+		}
+
+		meeting, err := meetings.GetMeetingByID(meetingID)
+		if err != nil {
+			return nil, errResourceNotFound
+		}
+
+		return &models.MeetingAttendee{
+			MeetingID:   meeting.ID,
+			UserID:      userID,
+			OrganizerID: meeting.OrganizerID,
+		}, nil
+	}
+}
+
+func parseIDParam(c *gin.Context, param string) (uint, error) {
+	id, err := strconv.ParseUint(c.Param(param), 10, 32)
+	if err != nil {
+		return 0, errors.New("invalid " + param)
+	}
+	return uint(id), nil
+}
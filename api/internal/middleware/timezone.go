@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"api/internal/models"
+	"api/internal/services"
+	"api/internal/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResolveTimeZone decides the time zone dates in the request are read in: the
+// "tz" query parameter or X-Time-Zone header when given, otherwise the
+// authenticated user's zone, otherwise UTC. It must run after AuthMiddleware.
+func ResolveTimeZone(userService *services.UserService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Query("tz")
+		if name == "" {
+			name = c.GetHeader("X-Time-Zone")
+		}
+
+		if name != "" {
+			loc, err := models.LoadTimeZone(name)
+			if err != nil {
+				utils.BadRequestResponse(c, err.Error())
+				c.Abort()
+				return
+			}
+			c.Set("time_zone", loc)
+			c.Next()
+			return
+		}
+
+		loc := time.UTC
+		if userID := GetUserIDFromContext(c); userID != 0 {
+			if user, err := userService.GetUserByID(userID); err == nil {
+				loc = user.Location()
+			}
+		}
+		c.Set("time_zone", loc)
+
+		c.Next()
+	}
+}
+
+func GetTimeZoneFromContext(c *gin.Context) *time.Location {
+	if value, exists := c.Get("time_zone"); exists {
+		if loc, ok := value.(*time.Location); ok {
+			return loc
+		}
+	}
+	return time.UTC
+}
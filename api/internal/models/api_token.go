@@ -0,0 +1,102 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APITokenPrefix marks bearer credentials that are personal access tokens
+// rather than JWTs.
+const APITokenPrefix = "msa_"
+
+type TokenScope string
+
+const (
+	ScopeMeetingsRead  TokenScope = "meetings:read"
+	ScopeMeetingsWrite TokenScope = "meetings:write"
+	ScopeRoomsRead     TokenScope = "rooms:read"
+	ScopeRoomsWrite    TokenScope = "rooms:write"
+	ScopeUsersRead     TokenScope = "users:read"
+	ScopeUsersWrite    TokenScope = "users:write"
+	ScopeDashboardRead TokenScope = "dashboard:read"
+)
+
+var AllTokenScopes = []TokenScope{
+	ScopeMeetingsRead,
+	ScopeMeetingsWrite,
+	ScopeRoomsRead,
+	ScopeRoomsWrite,
+	ScopeUsersRead,
+	ScopeUsersWrite,
+	ScopeDashboardRead,
+}
+
+func IsValidTokenScope(scope TokenScope) bool {
+	for _, s := range AllTokenScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// APIToken is a long-lived credential for scripts and integrations. Only the
+// SHA-256 hash of the token is stored; Prefix is kept so users can tell their
+// tokens apart in listings.
+type APIToken struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	UserID      uint           `json:"user_id" gorm:"not null;index"`
+	Name        string         `json:"name" gorm:"not null"`
+	Prefix      string         `json:"prefix" gorm:"size:16;not null"`
+	TokenHash   string         `json:"-" gorm:"size:64;uniqueIndex;not null"`
+	Scopes      []TokenScope   `json:"scopes" gorm:"serializer:json"`
+	ExpiresAt   *time.Time     `json:"expires_at"`
+	LastUsedAt  *time.Time     `json:"last_used_at"`
+	LastUsedIP  string         `json:"last_used_ip"`
+	RevokedAt   *time.Time     `json:"revoked_at"`
+	CreatedByID uint           `json:"created_by_id"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+
+	User User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+type CreateAPITokenRequest struct {
+	Name      string       `json:"name" validate:"required,max=100"`
+	Scopes    []TokenScope `json:"scopes" validate:"required,min=1"`
+	ExpiresAt *time.Time   `json:"expires_at"`
+}
+
+type CreateAPITokenResponse struct {
+	Token    string    `json:"token"`
+	APIToken *APIToken `json:"api_token"`
+}
+
+type CreateServiceAccountRequest struct {
+	Name  string   `json:"name" validate:"required,max=100"`
+	Email string   `json:"email" validate:"omitempty,email"`
+	Role  UserRole `json:"role" validate:"omitempty,oneof=admin manager employee"`
+}
+
+func (t *APIToken) IsExpired() bool {
+	return t.ExpiresAt != nil && time.Now().After(*t.ExpiresAt)
+}
+
+func (t *APIToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+func (t *APIToken) IsUsable() bool {
+	return !t.IsExpired() && !t.IsRevoked()
+}
+
+func (t *APIToken) HasScope(scope TokenScope) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RoomBlackout blocks a single room, or every room of a building, for
+// maintenance, cleaning or events. Recurring blackouts repeat like recurring
+// meetings until RecurrenceUntil.
+type RoomBlackout struct {
+	ID                uint           `json:"id" gorm:"primaryKey"`
+	RoomID            *uint          `json:"room_id" gorm:"index"`
+	BuildingID        *uint          `json:"building_id" gorm:"index"`
+	Reason            string         `json:"reason" gorm:"not null"`
+	StartTime         time.Time      `json:"start_time" gorm:"not null;index"`
+	EndTime           time.Time      `json:"end_time" gorm:"not null"`
+	RecurrencePattern string         `json:"recurrence_pattern" gorm:"size:16"`
+	RecurrenceUntil   *time.Time     `json:"recurrence_until"`
+	TimeZone          string         `json:"time_zone" gorm:"size:64;not null;default:'UTC'"`
+	CreatedByID       uint           `json:"created_by_id"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Room     *Room     `json:"room,omitempty" gorm:"foreignKey:RoomID"`
+	Building *Building `json:"building,omitempty" gorm:"foreignKey:BuildingID"`
+}
+
+type CreateRoomBlackoutRequest struct {
+	RoomID            *uint      `json:"room_id"`
+	BuildingID        *uint      `json:"building_id"`
+	Reason            string     `json:"reason" validate:"required"`
+	StartTime         time.Time  `json:"start_time" validate:"required"`
+	EndTime           time.Time  `json:"end_time" validate:"required"`
+	RecurrencePattern string     `json:"recurrence_pattern"`
+	RecurrenceUntil   *time.Time `json:"recurrence_until"`
+	TimeZone          string     `json:"time_zone"`
+}
+
+type UpdateRoomBlackoutRequest struct {
+	Reason            *string    `json:"reason"`
+	StartTime         *time.Time `json:"start_time"`
+	EndTime           *time.Time `json:"end_time"`
+	RecurrencePattern *string    `json:"recurrence_pattern"`
+	RecurrenceUntil   *time.Time `json:"recurrence_until"`
+	TimeZone          *string    `json:"time_zone"`
+}
+
+type RoomBlackoutFilter struct {
+	RoomID     *uint `json:"room_id"`
+	BuildingID *uint `json:"building_id"`
+}
+
+// RoomBlackoutReport is returned when a blackout is created or changed. It
+// lists the bookings the blackout collides with so they can be relocated.
+type RoomBlackoutReport struct {
+	Blackout            *RoomBlackout `json:"blackout"`
+	ConflictingMeetings []*Meeting    `json:"conflicting_meetings"`
+}
+
+func (b *RoomBlackout) Recurrence() Recurrence {
+	return Recurrence{
+		StartTime: b.StartTime,
+		EndTime:   b.EndTime,
+		Pattern:   b.RecurrencePattern,
+		TimeZone:  b.TimeZone,
+		Until:     b.RecurrenceUntil,
+	}
+}
+
+// AppliesTo reports whether the blackout covers room, that is whether its
+// room shares space with room: blackouts of a component also cover the
+// combined rooms it is part of, and blackouts of a combined room its
+// components. Room blackouts need the Components of both rooms to be loaded,
+// building-wide blackouts the room's Floor.
+func (b *RoomBlackout) AppliesTo(room *Room) bool {
+	if b.RoomID != nil {
+		blackedOut := b.Room
+		if blackedOut == nil {
+			blackedOut = &Room{ID: *b.RoomID}
+		}
+		return room.SharesSpaceWith(blackedOut)
+	}
+	return b.BuildingID != nil && room.Floor != nil && room.Floor.BuildingID == *b.BuildingID
+}
+
+// Blocks reports whether any instance of the blackout overlaps [startTime,
+// endTime).
+func (b *RoomBlackout) Blocks(startTime, endTime time.Time) bool {
+	return b.Recurrence().Overlaps(startTime, endTime)
+}
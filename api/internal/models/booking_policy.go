@@ -0,0 +1,174 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Capacity enforcement modes. Hard rejects bookings with more people than the
+// room seats, soft accepts them with a warning.
+const (
+	CapacityEnforcementOff  = "off"
+	CapacityEnforcementSoft = "soft"
+	CapacityEnforcementHard = "hard"
+)
+
+// BookingPolicy limits when and how rooms may be booked. Zero values mean
+// "no limit"; an empty AllowedDays allows every day.
+type BookingPolicy struct {
+	MinDurationMinutes  int      `json:"min_duration_minutes"`
+	MaxDurationMinutes  int      `json:"max_duration_minutes"`
+	MaxAdvanceDays      int      `json:"max_advance_days"`
+	MinLeadTimeMinutes  int      `json:"min_lead_time_minutes"`
+	EarliestStart       string   `json:"earliest_start"`
+	LatestEnd           string   `json:"latest_end"`
+	AllowedDays         []string `json:"allowed_days"`
+	MaxActiveBookings   int      `json:"max_active_bookings"`
+	CapacityEnforcement string   `json:"capacity_enforcement"`
+}
+
+// RoomBookingPolicy overrides parts of the global policy for one room. Nil
+// fields inherit the global value.
+type RoomBookingPolicy struct {
+	RoomID              uint      `json:"room_id" gorm:"primaryKey"`
+	MinDurationMinutes  *int      `json:"min_duration_minutes"`
+	MaxDurationMinutes  *int      `json:"max_duration_minutes"`
+	MaxAdvanceDays      *int      `json:"max_advance_days"`
+	MinLeadTimeMinutes  *int      `json:"min_lead_time_minutes"`
+	EarliestStart       *string   `json:"earliest_start" gorm:"size:5"`
+	LatestEnd           *string   `json:"latest_end" gorm:"size:5"`
+	AllowedDays         []string  `json:"allowed_days" gorm:"serializer:json"`
+	MaxActiveBookings   *int      `json:"max_active_bookings"`
+	CapacityEnforcement *string   `json:"capacity_enforcement" gorm:"size:8"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+type UpdateRoomBookingPolicyRequest struct {
+	MinDurationMinutes  *int     `json:"min_duration_minutes" validate:"omitempty,min=0"`
+	MaxDurationMinutes  *int     `json:"max_duration_minutes" validate:"omitempty,min=0"`
+	MaxAdvanceDays      *int     `json:"max_advance_days" validate:"omitempty,min=0"`
+	MinLeadTimeMinutes  *int     `json:"min_lead_time_minutes" validate:"omitempty,min=0"`
+	EarliestStart       *string  `json:"earliest_start"`
+	LatestEnd           *string  `json:"latest_end"`
+	AllowedDays         []string `json:"allowed_days"`
+	MaxActiveBookings   *int     `json:"max_active_bookings" validate:"omitempty,min=0"`
+	CapacityEnforcement *string  `json:"capacity_enforcement"`
+}
+
+// EffectiveBookingPolicy is the policy a room is booked under together with
+// the room's own overrides, if any.
+type EffectiveBookingPolicy struct {
+	RoomID    uint               `json:"room_id"`
+	Policy    BookingPolicy      `json:"policy"`
+	Overrides *RoomBookingPolicy `json:"overrides"`
+}
+
+// WithOverrides returns p with every field set in override replaced.
+func (p BookingPolicy) WithOverrides(override *RoomBookingPolicy) BookingPolicy {
+	if override == nil {
+		return p
+	}
+
+	if override.MinDurationMinutes != nil {
+		p.MinDurationMinutes = *override.MinDurationMinutes
+	}
+	if override.MaxDurationMinutes != nil {
+		p.MaxDurationMinutes = *override.MaxDurationMinutes
+	}
+	if override.MaxAdvanceDays != nil {
+		p.MaxAdvanceDays = *override.MaxAdvanceDays
+	}
+	if override.MinLeadTimeMinutes != nil {
+		p.MinLeadTimeMinutes = *override.MinLeadTimeMinutes
+	}
+	if override.EarliestStart != nil {
+		p.EarliestStart = *override.EarliestStart
+	}
+	if override.LatestEnd != nil {
+		p.LatestEnd = *override.LatestEnd
+	}
+	if len(override.AllowedDays) > 0 {
+		p.AllowedDays = override.AllowedDays
+	}
+	if override.MaxActiveBookings != nil {
+		p.MaxActiveBookings = *override.MaxActiveBookings
+	}
+	if override.CapacityEnforcement != nil {
+		p.CapacityEnforcement = *override.CapacityEnforcement
+	}
+	return p
+}
+
+// Validate checks that the policy is self-consistent.
+func (p BookingPolicy) Validate() error {
+	if p.MinDurationMinutes < 0 || p.MaxDurationMinutes < 0 || p.MaxAdvanceDays < 0 ||
+		p.MinLeadTimeMinutes < 0 || p.MaxActiveBookings < 0 {
+		return fmt.Errorf("booking policy limits must not be negative")
+	}
+
+	if p.MaxDurationMinutes > 0 && p.MinDurationMinutes > p.MaxDurationMinutes {
+		return fmt.Errorf("minimum duration exceeds maximum duration")
+	}
+
+	earliest, err := ParseTimeOfDay(p.EarliestStart)
+	if err != nil {
+		return fmt.Errorf("earliest_start: %w", err)
+	}
+	latest, err := ParseTimeOfDay(p.LatestEnd)
+	if err != nil {
+		return fmt.Errorf("latest_end: %w", err)
+	}
+	if p.EarliestStart != "" && p.LatestEnd != "" && latest <= earliest {
+		return fmt.Errorf("latest_end must be after earliest_start")
+	}
+
+	if _, err := ParseWeekdays(p.AllowedDays); err != nil {
+		return err
+	}
+
+	switch p.CapacityEnforcement {
+	case "", CapacityEnforcementOff, CapacityEnforcementSoft, CapacityEnforcementHard:
+	default:
+		return fmt.Errorf("capacity_enforcement must be off, soft or hard")
+	}
+	return nil
+}
+
+// ParseTimeOfDay parses "HH:MM" into the offset from midnight. An empty
+// value yields zero.
+func ParseTimeOfDay(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("time of day must be HH:MM, got %q", value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ParseWeekdays parses three-letter day names such as "mon" or "Fri".
+func ParseWeekdays(names []string) ([]time.Weekday, error) {
+	days := make([]time.Weekday, 0, len(names))
+	for _, name := range names {
+		day, ok := weekdayNames[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", name)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// MeetingBump records a booking being moved to another room to make way for
+// a booking of higher priority.
+type MeetingBump struct {
+	ID                uint      `json:"id" gorm:"primaryKey"`
+	MeetingID         uint      `json:"meeting_id" gorm:"not null;index"`
+	BumpedByMeetingID uint      `json:"bumped_by_meeting_id" gorm:"not null;index"`
+	BumpedByUserID    uint      `json:"bumped_by_user_id" gorm:"not null;index"`
+	FromRoomID        uint      `json:"from_room_id" gorm:"not null"`
+	ToRoomID          uint      `json:"to_room_id" gorm:"not null"`
+	MeetingPriority   int       `json:"meeting_priority"`
+	BumpPriority      int       `json:"bump_priority"`
+	Reason            string    `json:"reason" gorm:"size:500"`
+	CreatedAt         time.Time `json:"created_at"`
+
+	Meeting  *Meeting `json:"meeting,omitempty" gorm:"foreignKey:MeetingID"`
+	BumpedBy *User    `json:"bumped_by,omitempty" gorm:"foreignKey:BumpedByUserID"`
+	FromRoom *Room    `json:"from_room,omitempty" gorm:"foreignKey:FromRoomID"`
+	ToRoom   *Room    `json:"to_room,omitempty" gorm:"foreignKey:ToRoomID"`
+}
+
+// BumpMeetingRequest books a room that is taken, moving the lower-priority
+// bookings in the way to equivalent rooms.
+type BumpMeetingRequest struct {
+	CreateMeetingRequest
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+type BumpResult struct {
+	Meeting *Meeting       `json:"meeting"`
+	Bumps   []*MeetingBump `json:"bumps"`
+}
+
+// MeetingBumpFilter narrows the bump audit trail. MeetingID matches both the
+// bumped and the bumping meeting.
+type MeetingBumpFilter struct {
+	MeetingID *uint `json:"meeting_id"`
+	UserID    *uint `json:"user_id"`
+}
@@ -51,4 +51,7 @@ type DashboardFilter struct {
 	EndDate   *time.Time `json:"end_date"`
 	RoomID    *uint      `json:"room_id"`
 	UserID    *uint      `json:"user_id"`
+	LocationFilter
+	// TimeZone is the zone months are bucketed in; nil means UTC.
+	TimeZone *time.Location `json:"-"`
 }
\ No newline at end of file
@@ -0,0 +1,87 @@
+package models
+
+import "time"
+
+// DelegateScope is what a delegate may do for the user who granted it.
+type DelegateScope string
+
+const (
+	// DelegateScopeBook allows booking meetings organized by the principal.
+	DelegateScopeBook DelegateScope = "book"
+	// DelegateScopeManage also allows updating and cancelling them.
+	DelegateScopeManage DelegateScope = "manage"
+)
+
+// DelegateGrant lets Delegate act on behalf of Principal within Scope.
+type DelegateGrant struct {
+	ID          uint          `json:"id" gorm:"primaryKey"`
+	PrincipalID uint          `json:"principal_id" gorm:"not null;uniqueIndex:idx_delegate_grants_pair"`
+	DelegateID  uint          `json:"delegate_id" gorm:"not null;uniqueIndex:idx_delegate_grants_pair;index"`
+	Scope       DelegateScope `json:"scope" gorm:"size:16;not null"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+
+	Principal *User `json:"principal,omitempty" gorm:"foreignKey:PrincipalID"`
+	Delegate  *User `json:"delegate,omitempty" gorm:"foreignKey:DelegateID"`
+}
+
+type GrantDelegateRequest struct {
+	DelegateID uint          `json:"delegate_id" validate:"required"`
+	Scope      DelegateScope `json:"scope" validate:"required,oneof=book manage"`
+}
+
+// Covers reports whether a grant of scope s allows what required does.
+func (s DelegateScope) Covers(required DelegateScope) bool {
+	return s == required || s == DelegateScopeManage
+}
+
+// IsOwnedBy lets both sides of a grant revoke it.
+func (g *DelegateGrant) IsOwnedBy(userID uint) bool {
+	return g.PrincipalID == userID || g.DelegateID == userID
+}
+
+// DelegatedMeeting is a meeting as seen by a delegate of its organizer, who
+// shares the organizer's ownership of it.
+type DelegatedMeeting struct {
+	*Meeting
+	DelegateID uint
+}
+
+func (m *DelegatedMeeting) IsOwnedBy(userID uint) bool {
+	return m.Meeting.IsOwnedBy(userID) || m.DelegateID == userID
+}
+
+type MeetingActivityAction string
+
+const (
+	MeetingActivityCreated   MeetingActivityAction = "created"
+	MeetingActivityUpdated   MeetingActivityAction = "updated"
+	MeetingActivityCancelled MeetingActivityAction = "cancelled"
+	MeetingActivityDeleted   MeetingActivityAction = "deleted"
+	// MeetingActivityTransferred is a change of organizer.
+	MeetingActivityTransferred MeetingActivityAction = "transferred"
+)
+
+// MeetingActivity records who performed an action on a meeting, and for
+// whom when they acted as a delegate. Transfers also record the previous and
+// the new organizer.
+type MeetingActivity struct {
+	ID                  uint                  `json:"id" gorm:"primaryKey"`
+	MeetingID           uint                  `json:"meeting_id" gorm:"not null;index"`
+	Action              MeetingActivityAction `json:"action" gorm:"size:16;not null"`
+	ActorID             uint                  `json:"actor_id" gorm:"not null"`
+	OnBehalfOfID        *uint                 `json:"on_behalf_of_id,omitempty"`
+	PreviousOrganizerID *uint                 `json:"previous_organizer_id,omitempty"`
+	NewOrganizerID      *uint                 `json:"new_organizer_id,omitempty"`
+	CreatedAt           time.Time             `json:"created_at"`
+
+	Actor             *User `json:"actor,omitempty" gorm:"foreignKey:ActorID"`
+	OnBehalfOf        *User `json:"on_behalf_of,omitempty" gorm:"foreignKey:OnBehalfOfID"`
+	PreviousOrganizer *User `json:"previous_organizer,omitempty" gorm:"foreignKey:PreviousOrganizerID"`
+	NewOrganizer      *User `json:"new_organizer,omitempty" gorm:"foreignKey:NewOrganizerID"`
+}
+
+// CancelMeetingRequest is the optional body of a cancellation.
+type CancelMeetingRequest struct {
+	OnBehalfOf *uint `json:"on_behalf_of"`
+}
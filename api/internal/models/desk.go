@@ -0,0 +1,98 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DeskPeriod is the part of a day a desk is reserved for. Half days split at
+// noon in the booking's time zone.
+type DeskPeriod string
+
+const (
+	DeskPeriodFullDay   DeskPeriod = "full_day"
+	DeskPeriodMorning   DeskPeriod = "morning"
+	DeskPeriodAfternoon DeskPeriod = "afternoon"
+)
+
+type DeskBookingStatus string
+
+const (
+	DeskBookingBooked    DeskBookingStatus = "booked"
+	DeskBookingCheckedIn DeskBookingStatus = "checked_in"
+	DeskBookingCancelled DeskBookingStatus = "cancelled"
+)
+
+// DeskBookingConflict is why a desk booking could not be made.
+type DeskBookingConflict string
+
+const (
+	DeskConflictNone       DeskBookingConflict = ""
+	DeskConflictUserBooked DeskBookingConflict = "user_booked"
+	DeskConflictDeskTaken  DeskBookingConflict = "desk_taken"
+)
+
+// DeskHoldingStatuses are the statuses in which a desk booking occupies its
+// desk.
+var DeskHoldingStatuses = []DeskBookingStatus{DeskBookingBooked, DeskBookingCheckedIn}
+
+// DeskBooking reserves a desk for one user for a day or half a day. Date is
+// the day in TimeZone; StartTime and EndTime are the period it covers.
+type DeskBooking struct {
+	ID          uint              `json:"id" gorm:"primaryKey"`
+	DeskID      uint              `json:"desk_id" gorm:"not null;index"`
+	UserID      uint              `json:"user_id" gorm:"not null;index:idx_desk_bookings_user_date"`
+	Date        string            `json:"date" gorm:"size:10;not null;index:idx_desk_bookings_user_date"`
+	Period      DeskPeriod        `json:"period" gorm:"size:16;not null"`
+	StartTime   time.Time         `json:"start_time" gorm:"not null;index"`
+	EndTime     time.Time         `json:"end_time" gorm:"not null"`
+	TimeZone    string            `json:"time_zone" gorm:"size:64;not null;default:'UTC'"`
+	Status      DeskBookingStatus `json:"status" gorm:"size:16;not null;default:'booked'"`
+	CheckedInAt *time.Time        `json:"checked_in_at,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt    `json:"-" gorm:"index"`
+
+	Desk *Room `json:"desk,omitempty" gorm:"foreignKey:DeskID"`
+	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+type CreateDeskBookingRequest struct {
+	DeskID uint       `json:"desk_id" validate:"required"`
+	Date   string     `json:"date" validate:"required"`
+	Period DeskPeriod `json:"period" validate:"omitempty,oneof=full_day morning afternoon"`
+}
+
+type DeskBookingFilter struct {
+	UserID    *uint      `json:"user_id"`
+	DeskID    *uint      `json:"desk_id"`
+	StartDate *time.Time `json:"start_date"`
+	EndDate   *time.Time `json:"end_date"`
+}
+
+func (b *DeskBooking) IsOwnedBy(userID uint) bool {
+	return b.UserID == userID
+}
+
+func IsValidDeskPeriod(period DeskPeriod) bool {
+	switch period {
+	case DeskPeriodFullDay, DeskPeriodMorning, DeskPeriodAfternoon:
+		return true
+	}
+	return false
+}
+
+// Interval returns the time period covers on day, which must be midnight in
+// the booking's time zone.
+func (p DeskPeriod) Interval(day time.Time) (time.Time, time.Time) {
+	noon := time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, day.Location())
+	switch p {
+	case DeskPeriodMorning:
+		return day, noon
+	case DeskPeriodAfternoon:
+		return noon, day.AddDate(0, 0, 1)
+	default:
+		return day, day.AddDate(0, 0, 1)
+	}
+}
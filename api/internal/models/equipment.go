@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Equipment is a movable resource such as a projector, a laptop or a parking
+// spot. Unlike room features it is booked per meeting, and Quantity units of
+// it can be in use at the same time. Equipment tied to a site can only be
+// booked for rooms there.
+type Equipment struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	Name        string         `json:"name" gorm:"uniqueIndex;size:191;not null"`
+	Description string         `json:"description"`
+	Category    string         `json:"category" gorm:"size:64;index"`
+	Quantity    int            `json:"quantity" gorm:"not null;default:1"`
+	SiteID      *uint          `json:"site_id" gorm:"index"`
+	IsActive    bool           `json:"is_active" gorm:"default:true"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Site *Site `json:"site,omitempty" gorm:"foreignKey:SiteID"`
+}
+
+// EquipmentBooking is the number of units of a piece of equipment a meeting
+// holds. The units are in use while the meeting holds its slot.
+type EquipmentBooking struct {
+	MeetingID   uint `json:"meeting_id" gorm:"primaryKey"`
+	EquipmentID uint `json:"equipment_id" gorm:"primaryKey;index"`
+	Quantity    int  `json:"quantity" gorm:"not null"`
+
+	Equipment *Equipment `json:"equipment,omitempty" gorm:"foreignKey:EquipmentID"`
+}
+
+type CreateEquipmentRequest struct {
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description"`
+	Category    string `json:"category"`
+	Quantity    int    `json:"quantity" validate:"required,min=1"`
+	SiteID      *uint  `json:"site_id"`
+}
+
+type UpdateEquipmentRequest struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	Category    *string `json:"category"`
+	Quantity    *int    `json:"quantity" validate:"omitempty,min=1"`
+	SiteID      *uint   `json:"site_id"`
+	IsActive    *bool   `json:"is_active"`
+}
+
+type EquipmentFilter struct {
+	Category *string `json:"category"`
+	SiteID   *uint   `json:"site_id"`
+}
+
+// EquipmentRequest asks for Quantity units of a piece of equipment for a
+// meeting.
+type EquipmentRequest struct {
+	EquipmentID uint `json:"equipment_id" validate:"required"`
+	Quantity    int  `json:"quantity" validate:"required,min=1"`
+}
+
+// EquipmentAvailability is how many units of a piece of equipment are free
+// throughout a period. Reserved is the most units in use at any moment of it.
+type EquipmentAvailability struct {
+	Equipment *Equipment `json:"equipment"`
+	Reserved  int        `json:"reserved"`
+	Available int        `json:"available"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GroupSource is where a group's membership is managed.
+type GroupSource string
+
+const (
+	GroupSourceManual GroupSource = "manual"
+	// GroupSourceDirectory groups mirror a directory group and have their
+	// members synced from it when they sign in.
+	GroupSourceDirectory GroupSource = "directory"
+)
+
+type UserGroup struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	Name        string         `json:"name" gorm:"uniqueIndex;size:191;not null"`
+	Description string         `json:"description"`
+	Source      GroupSource    `json:"source" gorm:"size:16;not null;default:'manual'"`
+	ExternalID  *string        `json:"external_id,omitempty" gorm:"uniqueIndex;size:191"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Members []User `json:"members,omitempty" gorm:"many2many:user_group_members;"`
+}
+
+type CreateUserGroupRequest struct {
+	Name        string `json:"name" validate:"required,max=191"`
+	Description string `json:"description"`
+	MemberIDs   []uint `json:"member_ids"`
+}
+
+type UpdateUserGroupRequest struct {
+	Name        *string `json:"name" validate:"omitempty,max=191"`
+	Description *string `json:"description"`
+}
+
+// DirectoryGroup is a group a user belongs to in the directory.
+type DirectoryGroup struct {
+	ExternalID string
+	Name       string
+}
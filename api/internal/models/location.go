@@ -0,0 +1,98 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Site is an office location. Its time zone is the default for the rooms and
+// people working there.
+type Site struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	Name      string         `json:"name" gorm:"uniqueIndex;size:191;not null"`
+	TimeZone  string         `json:"time_zone" gorm:"size:64;not null;default:'UTC'"`
+	Address   string         `json:"address"`
+	City      string         `json:"city"`
+	Country   string         `json:"country"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Buildings []Building `json:"buildings,omitempty" gorm:"foreignKey:SiteID"`
+}
+
+type Building struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	SiteID    uint           `json:"site_id" gorm:"not null;index"`
+	Name      string         `json:"name" gorm:"not null"`
+	Address   string         `json:"address"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Site   *Site   `json:"site,omitempty" gorm:"foreignKey:SiteID"`
+	Floors []Floor `json:"floors,omitempty" gorm:"foreignKey:BuildingID"`
+}
+
+type Floor struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	BuildingID uint           `json:"building_id" gorm:"not null;index"`
+	Name       string         `json:"name" gorm:"not null"`
+	Level      int            `json:"level"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Building *Building `json:"building,omitempty" gorm:"foreignKey:BuildingID"`
+}
+
+type CreateSiteRequest struct {
+	Name     string `json:"name" validate:"required,max=191"`
+	TimeZone string `json:"time_zone" validate:"required"`
+	Address  string `json:"address"`
+	City     string `json:"city"`
+	Country  string `json:"country"`
+}
+
+type UpdateSiteRequest struct {
+	Name     *string `json:"name" validate:"omitempty,max=191"`
+	TimeZone *string `json:"time_zone"`
+	Address  *string `json:"address"`
+	City     *string `json:"city"`
+	Country  *string `json:"country"`
+}
+
+type CreateBuildingRequest struct {
+	SiteID  uint   `json:"site_id" validate:"required"`
+	Name    string `json:"name" validate:"required"`
+	Address string `json:"address"`
+}
+
+type UpdateBuildingRequest struct {
+	Name    *string `json:"name"`
+	Address *string `json:"address"`
+}
+
+type CreateFloorRequest struct {
+	BuildingID uint   `json:"building_id" validate:"required"`
+	Name       string `json:"name" validate:"required"`
+	Level      int    `json:"level"`
+}
+
+type UpdateFloorRequest struct {
+	Name  *string `json:"name"`
+	Level *int    `json:"level"`
+}
+
+// LocationFilter narrows room and meeting queries to a site, building or
+// floor. The most specific filter set wins.
+type LocationFilter struct {
+	SiteID     *uint `json:"site_id"`
+	BuildingID *uint `json:"building_id"`
+	FloorID    *uint `json:"floor_id"`
+}
+
+func (f LocationFilter) IsEmpty() bool {
+	return f.SiteID == nil && f.BuildingID == nil && f.FloorID == nil
+}
@@ -15,15 +15,36 @@ type Meeting struct {
 	Status            MeetingStatus  `json:"status" gorm:"default:'scheduled'"`
 	IsRecurring       bool           `json:"is_recurring" gorm:"default:false"`
 	RecurrencePattern string         `json:"recurrence_pattern"`
+	TimeZone          string         `json:"time_zone" gorm:"size:64;not null;default:'UTC'"`
 	OrganizerID       uint           `json:"organizer_id" gorm:"not null"`
 	RoomID            uint           `json:"room_id" gorm:"not null"`
+	ApprovalExpiresAt *time.Time     `json:"approval_expires_at,omitempty" gorm:"index"`
+	ApprovalDecidedBy *uint          `json:"approval_decided_by,omitempty"`
+	ApprovalDecidedAt *time.Time     `json:"approval_decided_at,omitempty"`
+	ApprovalNote      string         `json:"approval_note,omitempty"`
+	HoldExpiresAt     *time.Time     `json:"hold_expires_at,omitempty" gorm:"index"`
+	Priority          int            `json:"priority" gorm:"not null;default:0"`
 	CreatedAt         time.Time      `json:"created_at"`
 	UpdatedAt         time.Time      `json:"updated_at"`
 	DeletedAt         gorm.DeletedAt `json:"-" gorm:"index"`
 
+	BookingBuffers
+
 	Organizer User   `json:"organizer" gorm:"foreignKey:OrganizerID"`
 	Room      Room   `json:"room" gorm:"foreignKey:RoomID"`
 	Attendees []User `json:"attendees" gorm:"many2many:meeting_attendees;"`
+	// AttendeeGroups are the groups invited as a whole. Their members are
+	// listed among Attendees and follow later membership changes.
+	AttendeeGroups []UserGroup `json:"attendee_groups,omitempty" gorm:"many2many:meeting_attendee_groups;"`
+
+	Equipment []EquipmentBooking `json:"equipment,omitempty" gorm:"foreignKey:MeetingID"`
+
+	// CapacityWarning is set on create and update responses when the meeting
+	// has more people than its room seats.
+	CapacityWarning *CapacityWarning `json:"capacity_warning,omitempty" gorm:"-"`
+	// AttendeeConflicts is set on create and update responses when attendees
+	// are booked into another meeting at the same time.
+	AttendeeConflicts []AttendeeConflict `json:"attendee_conflicts,omitempty" gorm:"-"`
 }
 
 type MeetingStatus string
@@ -33,8 +54,19 @@ const (
 	StatusInProgress MeetingStatus = "in_progress"
 	StatusCompleted  MeetingStatus = "completed"
 	StatusCancelled  MeetingStatus = "cancelled"
+
+	StatusPendingApproval MeetingStatus = "pending_approval"
+	StatusRejected        MeetingStatus = "rejected"
+
+	// StatusHeld is a tentative booking that is released at HoldExpiresAt
+	// unless its organizer confirms it.
+	StatusHeld MeetingStatus = "held"
 )
 
+// SlotHoldingStatuses are the statuses in which a meeting occupies its room.
+// A booking waiting for approval or held tentatively already holds the slot.
+var SlotHoldingStatuses = []MeetingStatus{StatusScheduled, StatusInProgress, StatusPendingApproval, StatusHeld}
+
 type CreateMeetingRequest struct {
 	Title             string    `json:"title" validate:"required"`
 	Description       string    `json:"description"`
@@ -42,8 +74,21 @@ type CreateMeetingRequest struct {
 	EndTime           time.Time `json:"end_time" validate:"required"`
 	RoomID            uint      `json:"room_id" validate:"required"`
 	AttendeeIDs       []uint    `json:"attendee_ids"`
+	AttendeeGroupIDs  []uint    `json:"attendee_group_ids"`
 	IsRecurring       bool      `json:"is_recurring"`
 	RecurrencePattern string    `json:"recurrence_pattern"`
+	TimeZone          string    `json:"time_zone"`
+	BookingBuffers
+	Equipment []EquipmentRequest `json:"equipment" validate:"dive"`
+	// Hold books the room tentatively until HoldUntil, by default for the
+	// configured hold TTL, and never past the meeting start.
+	Hold      bool       `json:"hold"`
+	HoldUntil *time.Time `json:"hold_until"`
+	// Priority defaults to the organizer's role priority and may only be
+	// lower.
+	Priority *int `json:"priority" validate:"omitempty,min=0"`
+	// OnBehalfOf books the meeting for a user who made the caller a delegate.
+	OnBehalfOf *uint `json:"on_behalf_of"`
 }
 
 type UpdateMeetingRequest struct {
@@ -53,9 +98,52 @@ type UpdateMeetingRequest struct {
 	EndTime           *time.Time     `json:"end_time"`
 	RoomID            *uint          `json:"room_id"`
 	AttendeeIDs       []uint         `json:"attendee_ids"`
+	AttendeeGroupIDs  []uint         `json:"attendee_group_ids"`
 	Status            *MeetingStatus `json:"status"`
 	IsRecurring       *bool          `json:"is_recurring"`
 	RecurrencePattern *string        `json:"recurrence_pattern"`
+	TimeZone          *string        `json:"time_zone"`
+	BookingBuffers
+	// Equipment replaces the meeting's equipment bookings; nil keeps them.
+	Equipment  []EquipmentRequest `json:"equipment" validate:"dive"`
+	OnBehalfOf *uint              `json:"on_behalf_of"`
+}
+
+// BookingBuffers are the minutes reserved before and after a booking for
+// setup and cleanup. Nil values fall back to the room's defaults.
+type BookingBuffers struct {
+	SetupMinutes   *int `json:"setup_minutes" validate:"omitempty,min=0,max=1440"`
+	CleanupMinutes *int `json:"cleanup_minutes" validate:"omitempty,min=0,max=1440"`
+}
+
+// Resolve returns the buffers, using room's defaults where none are set.
+func (b BookingBuffers) Resolve(room *Room) (setup, cleanup time.Duration) {
+	setupMinutes, cleanupMinutes := room.SetupMinutes, room.CleanupMinutes
+	if b.SetupMinutes != nil {
+		setupMinutes = *b.SetupMinutes
+	}
+	if b.CleanupMinutes != nil {
+		cleanupMinutes = *b.CleanupMinutes
+	}
+	return time.Duration(setupMinutes) * time.Minute, time.Duration(cleanupMinutes) * time.Minute
+}
+
+// CapacityWarning tells the organizer that a meeting has more people than its
+// room seats. SuggestedRoom is a larger room that is free at the same time.
+type CapacityWarning struct {
+	Capacity      int    `json:"capacity"`
+	Headcount     int    `json:"headcount"`
+	Message       string `json:"message"`
+	SuggestedRoom *Room  `json:"suggested_room,omitempty"`
+}
+
+// AttendeeConflict names an attendee who organizes or attends another
+// meeting that overlaps the one being booked.
+type AttendeeConflict struct {
+	UserID    uint      `json:"user_id"`
+	MeetingID uint      `json:"meeting_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
 }
 
 type MeetingFilter struct {
@@ -71,10 +159,79 @@ func (m *Meeting) IsOverlapping(startTime, endTime time.Time) bool {
 	return m.StartTime.Before(endTime) && m.EndTime.After(startTime)
 }
 
+// BlockedInterval is the time the meeting keeps room occupied, including
+// setup and cleanup. StartTime and EndTime stay the displayed times.
+func (m *Meeting) BlockedInterval(room *Room) (time.Time, time.Time) {
+	setup, cleanup := m.BookingBuffers.Resolve(room)
+	return m.StartTime.Add(-setup), m.EndTime.Add(cleanup)
+}
+
 func (m *Meeting) Duration() time.Duration {
 	return m.EndTime.Sub(m.StartTime)
 }
 
+func (m *Meeting) IsOwnedBy(userID uint) bool {
+	return m.OrganizerID == userID
+}
+
+// MeetingAttendee identifies one attendee record of a meeting. It is owned
+// both by the attendee and by the meeting's organizer.
+type MeetingAttendee struct {
+	MeetingID   uint `json:"meeting_id"`
+	UserID      uint `json:"user_id"`
+	OrganizerID uint `json:"organizer_id"`
+}
+
+func (a *MeetingAttendee) IsOwnedBy(userID uint) bool {
+	return a.UserID == userID || a.OrganizerID == userID
+}
+
+// MeetingGroupAttendee records that UserID attends MeetingID as a member of
+// GroupID. Attendees without such a record were invited individually.
+type MeetingGroupAttendee struct {
+	MeetingID uint `json:"meeting_id" gorm:"primaryKey"`
+	UserID    uint `json:"user_id" gorm:"primaryKey"`
+	GroupID   uint `json:"group_id" gorm:"primaryKey;index"`
+}
+
 func (m *Meeting) IsActive() bool {
-	return m.Status == StatusScheduled || m.Status == StatusInProgress
+	return m.Status == StatusScheduled || m.Status == StatusInProgress || m.Status == StatusPendingApproval || m.Status == StatusHeld
+}
+
+type ApprovalDecisionRequest struct {
+	Note string `json:"note"`
+}
+
+// MeetingApproval is the resource approval decisions are made on. It is owned
+// by the approvers of the booked room.
+type MeetingApproval struct {
+	ApproverIDs []uint
+}
+
+func NewMeetingApproval(room *Room) *MeetingApproval {
+	approval := &MeetingApproval{}
+	for _, approver := range room.Approvers {
+		approval.ApproverIDs = append(approval.ApproverIDs, approver.ID)
+	}
+	return approval
+}
+
+func (a *MeetingApproval) IsOwnedBy(userID uint) bool {
+	for _, approverID := range a.ApproverIDs {
+		if approverID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Occurrences lists up to limit instances of the meeting that overlap
+// [from, to). Recurring meetings repeat at the same wall-clock time in the
+// meeting's time zone.
+func (m *Meeting) Occurrences(from, to time.Time, limit int) []Occurrence {
+	recurrence := Recurrence{StartTime: m.StartTime, EndTime: m.EndTime, TimeZone: m.TimeZone}
+	if m.IsRecurring {
+		recurrence.Pattern = m.RecurrencePattern
+	}
+	return recurrence.Occurrences(from, to, limit)
 }
\ No newline at end of file
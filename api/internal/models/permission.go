@@ -0,0 +1,183 @@
+package models
+
+import "time"
+
+// Permission names an action a role may perform. Actions that depend on who
+// owns the resource come in ".own" and ".any" variants.
+type Permission string
+
+const (
+	PermMeetingCreate       Permission = "meeting.create"
+	PermMeetingUpdateOwn    Permission = "meeting.update.own"
+	PermMeetingUpdateAny    Permission = "meeting.update.any"
+	PermMeetingDeleteOwn    Permission = "meeting.delete.own"
+	PermMeetingDeleteAny    Permission = "meeting.delete.any"
+	PermMeetingStatusOwn    Permission = "meeting.status.own"
+	PermMeetingStatusAny    Permission = "meeting.status.any"
+	PermMeetingAttendeesOwn Permission = "meeting.attendees.own"
+	PermMeetingAttendeesAny Permission = "meeting.attendees.any"
+	PermMeetingApproveOwn   Permission = "meeting.approve.own"
+	PermMeetingApproveAny   Permission = "meeting.approve.any"
+	PermMeetingBump         Permission = "meeting.bump"
+	PermRoomRead            Permission = "room.read"
+	PermRoomManage          Permission = "room.manage"
+	PermRoomBookRestricted  Permission = "room.book.restricted"
+	PermGroupManage         Permission = "group.manage"
+	PermGroupInvite         Permission = "group.invite"
+	PermUserRead            Permission = "user.read"
+	PermUserUpdateOwn       Permission = "user.update.own"
+	PermUserUpdateAny       Permission = "user.update.any"
+	PermUserAdmin           Permission = "user.admin"
+	PermDashboardRead       Permission = "dashboard.read"
+	PermDeskBook            Permission = "desk.book"
+	PermDeskBookingOwn      Permission = "desk.booking.own"
+	PermDeskBookingAny      Permission = "desk.booking.any"
+)
+
+// Action is a permission without its ownership suffix, e.g. "meeting.update".
+type Action string
+
+const (
+	ActionMeetingCreate    Action = "meeting.create"
+	ActionMeetingUpdate    Action = "meeting.update"
+	ActionMeetingDelete    Action = "meeting.delete"
+	ActionMeetingStatus    Action = "meeting.status"
+	ActionMeetingAttendees Action = "meeting.attendees"
+	ActionMeetingApprove   Action = "meeting.approve"
+	ActionMeetingBump      Action = "meeting.bump"
+	ActionRoomRead         Action = "room.read"
+	ActionRoomManage       Action = "room.manage"
+	ActionGroupManage      Action = "group.manage"
+	ActionGroupInvite      Action = "group.invite"
+	ActionUserRead         Action = "user.read"
+	ActionUserUpdate       Action = "user.update"
+	ActionUserAdmin        Action = "user.admin"
+	ActionDashboardRead    Action = "dashboard.read"
+	ActionDeskBook         Action = "desk.book"
+	ActionDeskBooking      Action = "desk.booking"
+)
+
+var AllPermissions = []Permission{
+	PermMeetingCreate,
+	PermMeetingUpdateOwn,
+	PermMeetingUpdateAny,
+	PermMeetingDeleteOwn,
+	PermMeetingDeleteAny,
+	PermMeetingStatusOwn,
+	PermMeetingStatusAny,
+	PermMeetingAttendeesOwn,
+	PermMeetingAttendeesAny,
+	PermMeetingApproveOwn,
+	PermMeetingApproveAny,
+	PermMeetingBump,
+	PermRoomRead,
+	PermRoomManage,
+	PermRoomBookRestricted,
+	PermGroupManage,
+	PermGroupInvite,
+	PermUserRead,
+	PermUserUpdateOwn,
+	PermUserUpdateAny,
+	PermUserAdmin,
+	PermDashboardRead,
+	PermDeskBook,
+	PermDeskBookingOwn,
+	PermDeskBookingAny,
+}
+
+var employeePermissions = []Permission{
+	PermMeetingCreate,
+	PermMeetingUpdateOwn,
+	PermMeetingDeleteOwn,
+	PermMeetingStatusOwn,
+	PermMeetingAttendeesOwn,
+	PermMeetingApproveOwn,
+	PermRoomRead,
+	PermUserRead,
+	PermUserUpdateOwn,
+	PermDashboardRead,
+	PermDeskBook,
+	PermDeskBookingOwn,
+}
+
+var managerPermissions = append(append([]Permission{}, employeePermissions...),
+	PermMeetingUpdateAny,
+	PermMeetingDeleteAny,
+	PermMeetingStatusAny,
+	PermMeetingAttendeesAny,
+	PermMeetingBump,
+	PermRoomManage,
+	PermGroupManage,
+	PermGroupInvite,
+	PermUserUpdateAny,
+	PermDeskBookingAny,
+)
+
+var adminPermissions = append(append([]Permission{}, managerPermissions...),
+	PermUserAdmin,
+	PermRoomBookRestricted,
+	PermMeetingApproveAny,
+)
+
+// DefaultRolePermissions reproduces the behaviour of the former hard-coded
+// role checks. Defaults a role has not been given yet are granted on start,
+// so permissions introduced later reach existing installations too.
+var DefaultRolePermissions = map[UserRole][]Permission{
+	RoleEmployee: employeePermissions,
+	RoleManager:  managerPermissions,
+	RoleAdmin:    adminPermissions,
+}
+
+var AllRoles = []UserRole{RoleAdmin, RoleManager, RoleEmployee}
+
+type RolePermission struct {
+	Role       UserRole   `json:"role" gorm:"primaryKey;size:32"`
+	Permission Permission `json:"permission" gorm:"primaryKey;size:64"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// AppliedDefaultPermission records that a default permission was granted to
+// a role once, whether or not the role still has it.
+type AppliedDefaultPermission struct {
+	Role       UserRole   `json:"role" gorm:"primaryKey;size:32"`
+	Permission Permission `json:"permission" gorm:"primaryKey;size:64"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type UpdateRolePermissionsRequest struct {
+	Permissions []Permission `json:"permissions" validate:"required"`
+}
+
+// Subject is the identity an authorization decision is made for.
+type Subject struct {
+	UserID uint     `json:"user_id"`
+	Role   UserRole `json:"role"`
+}
+
+// Resource is anything whose ownership decides between ".own" and ".any"
+// permissions.
+type Resource interface {
+	IsOwnedBy(userID uint) bool
+}
+
+func IsValidPermission(permission Permission) bool {
+	for _, p := range AllPermissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func IsValidRole(role UserRole) bool {
+	for _, r := range AllRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func SubjectFromUser(user *User) Subject {
+	return Subject{UserID: user.ID, Role: user.Role}
+}
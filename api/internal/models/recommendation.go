@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// RoomRecommendationQuery asks which free rooms suit a new booking best.
+// AttendeeCount defaults to the organizer plus AttendeeIDs. Rooms in
+// PreferredBuildingID score higher but others are not left out.
+type RoomRecommendationQuery struct {
+	StartTime           time.Time `json:"start_time" validate:"required"`
+	EndTime             time.Time `json:"end_time" validate:"required"`
+	AttendeeIDs         []uint    `json:"attendee_ids"`
+	AttendeeCount       int       `json:"attendee_count" validate:"min=0"`
+	PreferredBuildingID *uint     `json:"preferred_building_id"`
+	Limit               int       `json:"limit" validate:"min=0,max=20"`
+	RoomFilter
+	OrganizerID uint        `json:"-"`
+	Booker      *RoomBooker `json:"-"`
+}
+
+// RoomRecommendation is a free room and how well it suits the booking. Score
+// and the parts it is made of range from 0 to 1.
+type RoomRecommendation struct {
+	Room              *Room   `json:"room"`
+	Score             float64 `json:"score"`
+	CapacityFit       float64 `json:"capacity_fit"`
+	Proximity         float64 `json:"proximity"`
+	History           float64 `json:"history"`
+	PreferredBuilding bool    `json:"preferred_building"`
+}
@@ -0,0 +1,127 @@
+package models
+
+import "time"
+
+// Recurrence patterns shared by recurring meetings and blackout windows.
+const (
+	RecurrenceDaily    = "daily"
+	RecurrenceWeekdays = "weekdays"
+	RecurrenceWeekly   = "weekly"
+	RecurrenceBiweekly = "biweekly"
+	RecurrenceMonthly  = "monthly"
+)
+
+func IsValidRecurrencePattern(pattern string) bool {
+	switch pattern {
+	case RecurrenceDaily, RecurrenceWeekdays, RecurrenceWeekly, RecurrenceBiweekly, RecurrenceMonthly:
+		return true
+	}
+	return false
+}
+
+// Occurrence is one instance of a possibly recurring time slot.
+type Occurrence struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// Recurrence is a time slot that repeats by Pattern, anchored in TimeZone.
+// An empty or unknown Pattern means the slot happens once; a nil Until means
+// it repeats forever.
+type Recurrence struct {
+	StartTime time.Time
+	EndTime   time.Time
+	Pattern   string
+	TimeZone  string
+	Until     *time.Time
+}
+
+// Occurrences lists up to limit instances that overlap [from, to). Instances
+// repeat at the same wall-clock time in the recurrence's time zone, so a
+// 09:00 slot stays at 09:00 when daylight saving time starts or ends. Monthly
+// instances fall on the last day of months that are too short.
+func (r Recurrence) Occurrences(from, to time.Time, limit int) []Occurrence {
+	occurrences := []Occurrence{}
+	duration := r.EndTime.Sub(r.StartTime)
+
+	if !IsValidRecurrencePattern(r.Pattern) {
+		if r.StartTime.Before(to) && r.EndTime.After(from) {
+			occurrences = append(occurrences, Occurrence{StartTime: r.StartTime, EndTime: r.EndTime})
+		}
+		return occurrences
+	}
+
+	first := r.StartTime.In(locationOrUTC(r.TimeZone))
+	for i := r.firstIndex(first, from.Add(-duration)); len(occurrences) < limit; i++ {
+		var start time.Time
+		switch r.Pattern {
+		case RecurrenceDaily, RecurrenceWeekdays:
+			start = first.AddDate(0, 0, i)
+		case RecurrenceWeekly:
+			start = first.AddDate(0, 0, 7*i)
+		case RecurrenceBiweekly:
+			start = first.AddDate(0, 0, 14*i)
+		case RecurrenceMonthly:
+			start = addMonths(first, i)
+		}
+
+		if !start.Before(to) || (r.Until != nil && start.After(*r.Until)) {
+			break
+		}
+		if r.Pattern == RecurrenceWeekdays && (start.Weekday() == time.Saturday || start.Weekday() == time.Sunday) {
+			continue
+		}
+
+		end := start.Add(duration)
+		if end.After(from) {
+			occurrences = append(occurrences, Occurrence{StartTime: start.UTC(), EndTime: end.UTC()})
+		}
+	}
+
+	return occurrences
+}
+
+// firstIndex returns the index of an instance starting no later than the
+// first one that ends after earliest, so long series are not walked from
+// their start. It steps back one period to stay clear of daylight saving
+// shifts.
+func (r Recurrence) firstIndex(first, earliest time.Time) int {
+	if !earliest.After(first) {
+		return 0
+	}
+
+	const day = 24 * time.Hour
+	var index int
+	switch r.Pattern {
+	case RecurrenceDaily, RecurrenceWeekdays:
+		index = int(earliest.Sub(first) / day)
+	case RecurrenceWeekly:
+		index = int(earliest.Sub(first) / (7 * day))
+	case RecurrenceBiweekly:
+		index = int(earliest.Sub(first) / (14 * day))
+	case RecurrenceMonthly:
+		local := earliest.In(first.Location())
+		index = (local.Year()-first.Year())*12 + int(local.Month()-first.Month())
+	}
+
+	if index > 0 {
+		index--
+	}
+	return index
+}
+
+// addMonths moves t by months at the same wall-clock time, keeping its day of
+// the month or using the last day of months that do not have it.
+func addMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	firstOfMonth := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	if lastDay := firstOfMonth.AddDate(0, 1, -1).Day(); day > lastDay {
+		day = lastDay
+	}
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// Overlaps reports whether any instance overlaps [from, to).
+func (r Recurrence) Overlaps(from, to time.Time) bool {
+	return len(r.Occurrences(from, to, 1)) > 0
+}
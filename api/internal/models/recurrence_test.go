@@ -0,0 +1,104 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// at parses a "2006-01-02 15:04" wall-clock time in zone.
+func at(t *testing.T, zone, value string) time.Time {
+	t.Helper()
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func TestOccurrences(t *testing.T) {
+	const berlin = "Europe/Berlin"
+	until := at(t, "UTC", "2025-06-03 09:00")
+
+	tests := []struct {
+		name     string
+		pattern  string
+		zone     string
+		start    string
+		duration time.Duration
+		until    *time.Time
+		from     string
+		to       string
+		want     []string
+	}{
+		{"single meeting", "", "UTC", "2025-06-02 09:00", time.Hour, nil, "2025-06-01 00:00", "2025-06-30 00:00",
+			[]string{"2025-06-02 09:00"}},
+		{"weekly keeps wall-clock time when DST starts", RecurrenceWeekly, berlin, "2025-03-24 09:00", time.Hour, nil, "2025-03-24 00:00", "2025-04-08 00:00",
+			[]string{"2025-03-24 09:00", "2025-03-31 09:00", "2025-04-07 09:00"}},
+		{"daily keeps wall-clock time when DST ends", RecurrenceDaily, berlin, "2025-10-01 09:00", time.Hour, nil, "2025-10-25 00:00", "2025-10-28 00:00",
+			[]string{"2025-10-25 09:00", "2025-10-26 09:00", "2025-10-27 09:00"}},
+		{"monthly on the 31st uses the last day of short months", RecurrenceMonthly, "UTC", "2025-01-31 10:00", time.Hour, nil, "2025-01-01 00:00", "2025-06-01 00:00",
+			[]string{"2025-01-31 10:00", "2025-02-28 10:00", "2025-03-31 10:00", "2025-04-30 10:00", "2025-05-31 10:00"}},
+		{"monthly on the 31st in a leap year", RecurrenceMonthly, "UTC", "2024-01-31 10:00", time.Hour, nil, "2024-02-01 00:00", "2024-03-01 00:00",
+			[]string{"2024-02-29 10:00"}},
+		{"weekdays skip the weekend", RecurrenceWeekdays, "UTC", "2025-06-06 09:00", time.Hour, nil, "2025-06-06 00:00", "2025-06-11 00:00",
+			[]string{"2025-06-06 09:00", "2025-06-09 09:00", "2025-06-10 09:00"}},
+		{"until ends the series", RecurrenceDaily, "UTC", "2025-06-01 09:00", time.Hour, &until, "2025-06-01 00:00", "2025-06-10 00:00",
+			[]string{"2025-06-01 09:00", "2025-06-02 09:00", "2025-06-03 09:00"}},
+		{"daily series started years ago across DST", RecurrenceDaily, berlin, "2000-01-01 09:00", time.Hour, nil, "2025-03-29 00:00", "2025-04-01 00:00",
+			[]string{"2025-03-29 09:00", "2025-03-30 09:00", "2025-03-31 09:00"}},
+		{"biweekly series started a year ago", RecurrenceBiweekly, "UTC", "2024-01-01 09:00", time.Hour, nil, "2025-01-01 00:00", "2025-01-31 00:00",
+			[]string{"2025-01-13 09:00", "2025-01-27 09:00"}},
+		{"monthly series started years ago", RecurrenceMonthly, "UTC", "2020-01-31 10:00", time.Hour, nil, "2025-02-01 00:00", "2025-04-01 00:00",
+			[]string{"2025-02-28 10:00", "2025-03-31 10:00"}},
+		{"instance in progress at from", RecurrenceDaily, "UTC", "2025-01-01 09:00", time.Hour, nil, "2025-06-10 09:30", "2025-06-11 09:00",
+			[]string{"2025-06-10 09:00"}},
+		{"long instances overlapping from", RecurrenceWeekly, "UTC", "2025-01-06 09:00", 3 * 24 * time.Hour, nil, "2025-06-11 00:00", "2025-06-12 00:00",
+			[]string{"2025-06-09 09:00"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := at(t, tt.zone, tt.start)
+			recurrence := Recurrence{
+				StartTime: start.UTC(),
+				EndTime:   start.Add(tt.duration).UTC(),
+				Pattern:   tt.pattern,
+				TimeZone:  tt.zone,
+				Until:     tt.until,
+			}
+
+			occurrences := recurrence.Occurrences(at(t, tt.zone, tt.from), at(t, tt.zone, tt.to), 100)
+			loc := locationOrUTC(tt.zone)
+			var got []string
+			for _, occurrence := range occurrences {
+				got = append(got, occurrence.StartTime.In(loc).Format("2006-01-02 15:04"))
+				if length := occurrence.EndTime.Sub(occurrence.StartTime); length != tt.duration {
+					t.Errorf("instance at %s lasts %v, want %v", occurrence.StartTime, length, tt.duration)
+				}
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Occurrences() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Occurrences() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestOccurrencesLimit(t *testing.T) {
+	start := at(t, "UTC", "2025-01-01 09:00")
+	recurrence := Recurrence{StartTime: start, EndTime: start.Add(time.Hour), Pattern: RecurrenceDaily, TimeZone: "UTC"}
+
+	if got := recurrence.Occurrences(start, start.AddDate(1, 0, 0), 5); len(got) != 5 {
+		t.Errorf("Occurrences() returned %d instances, want 5", len(got))
+	}
+}
@@ -0,0 +1,16 @@
+package models
+
+// MeetingRelocation pairs an upcoming booking of a deactivated room with the
+// equivalent room it was, or could be, moved to. NewRoom is nil when no
+// equivalent room is free.
+type MeetingRelocation struct {
+	Meeting   *Meeting `json:"meeting"`
+	NewRoom   *Room    `json:"new_room"`
+	Relocated bool     `json:"relocated"`
+}
+
+// RoomDeactivationReport lists the bookings affected by deactivating Room.
+type RoomDeactivationReport struct {
+	Room        *Room               `json:"room"`
+	Relocations []MeetingRelocation `json:"relocations"`
+}
@@ -7,18 +7,66 @@ import (
 )
 
 type Room struct {
-	ID          uint           `json:"id" gorm:"primaryKey"`
-	Name        string         `json:"name" gorm:"not null"`
-	Description string         `json:"description"`
-	Capacity    int            `json:"capacity" gorm:"not null"`
-	Location    string         `json:"location"`
-	Features    []RoomFeature  `json:"features" gorm:"many2many:room_room_features;"`
-	IsActive    bool           `json:"is_active" gorm:"default:true"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID                   uint           `json:"id" gorm:"primaryKey"`
+	Name                 string         `json:"name" gorm:"not null"`
+	Description          string         `json:"description"`
+	SpaceType            SpaceType      `json:"space_type" gorm:"size:16;not null;default:'room';index"`
+	Capacity             int            `json:"capacity" gorm:"not null"`
+	Location             string         `json:"location"`
+	FloorID              *uint          `json:"floor_id" gorm:"index"`
+	Zone                 string         `json:"zone" gorm:"size:64"`
+	Features             []RoomFeature  `json:"features" gorm:"many2many:room_room_features;"`
+	IsActive             bool           `json:"is_active" gorm:"default:true"`
+	IsRestricted         bool           `json:"is_restricted" gorm:"default:false"`
+	RequiresApproval     bool           `json:"requires_approval" gorm:"default:false"`
+	WheelchairAccessible bool           `json:"wheelchair_accessible" gorm:"default:false"`
+	HearingLoop          bool           `json:"hearing_loop" gorm:"default:false"`
+	SetupMinutes         int            `json:"setup_minutes" gorm:"not null;default:0"`
+	CleanupMinutes       int            `json:"cleanup_minutes" gorm:"not null;default:0"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+	DeletedAt            gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Floor         *Floor            `json:"floor,omitempty" gorm:"foreignKey:FloorID"`
+	Meetings      []Meeting         `json:"meetings" gorm:"foreignKey:RoomID"`
+	AllowedUsers  []User            `json:"allowed_users,omitempty" gorm:"many2many:room_allowed_users;"`
+	AllowedGroups []UserGroup       `json:"allowed_groups,omitempty" gorm:"many2many:room_allowed_groups;"`
+	AllowedRoles  []RoomAllowedRole `json:"allowed_roles,omitempty" gorm:"foreignKey:RoomID"`
+	Approvers     []User            `json:"approvers,omitempty" gorm:"many2many:room_approvers;"`
+	// Components are the rooms a combined room is made of, such as the two
+	// halves of a hall with a movable wall. Booking either blocks the other.
+	Components []Room `json:"components,omitempty" gorm:"many2many:room_components;joinForeignKey:CompositeID;joinReferences:ComponentID"`
+}
+
+// RoomAllowedRole grants every user with Role the right to book a restricted
+// room.
+type RoomAllowedRole struct {
+	RoomID uint     `json:"room_id" gorm:"primaryKey"`
+	Role   UserRole `json:"role" gorm:"primaryKey;size:32"`
+}
+
+// RoomAccessControl is the booking ACL of a restricted room.
+type RoomAccessControl struct {
+	IsRestricted    bool       `json:"is_restricted"`
+	AllowedUserIDs  []uint     `json:"allowed_user_ids"`
+	AllowedRoles    []UserRole `json:"allowed_roles"`
+	AllowedGroupIDs []uint     `json:"allowed_group_ids"`
+}
+
+// RoomApprovalSettings controls whether bookings of a room must be signed
+// off and by whom.
+type RoomApprovalSettings struct {
+	RequiresApproval bool   `json:"requires_approval"`
+	ApproverIDs      []uint `json:"approver_ids"`
+}
 
-	Meetings []Meeting `json:"meetings" gorm:"foreignKey:RoomID"`
+// RoomBooker describes who is looking for a room, so availability queries
+// can leave out restricted rooms the user may not book.
+type RoomBooker struct {
+	UserID             uint
+	Role               UserRole
+	GroupIDs           []uint
+	BypassRestrictions bool
 }
 
 type RoomFeature struct {
@@ -31,20 +79,39 @@ type RoomFeature struct {
 }
 
 type CreateRoomRequest struct {
-	Name        string `json:"name" validate:"required"`
-	Description string `json:"description"`
-	Capacity    int    `json:"capacity" validate:"required,min=1"`
-	Location    string `json:"location"`
-	FeatureIDs  []uint `json:"feature_ids"`
+	Name                 string                `json:"name" validate:"required"`
+	Description          string                `json:"description"`
+	SpaceType            SpaceType             `json:"space_type" validate:"omitempty,oneof=room desk"`
+	Capacity             int                   `json:"capacity" validate:"omitempty,min=1"`
+	Location             string                `json:"location"`
+	FloorID              *uint                 `json:"floor_id"`
+	Zone                 string                `json:"zone"`
+	FeatureIDs           []uint                `json:"feature_ids"`
+	ComponentIDs         []uint                `json:"component_ids"`
+	WheelchairAccessible bool                  `json:"wheelchair_accessible"`
+	HearingLoop          bool                  `json:"hearing_loop"`
+	SetupMinutes         int                   `json:"setup_minutes" validate:"min=0,max=1440"`
+	CleanupMinutes       int                   `json:"cleanup_minutes" validate:"min=0,max=1440"`
+	AccessControl        *RoomAccessControl    `json:"access_control"`
+	Approval             *RoomApprovalSettings `json:"approval"`
 }
 
 type UpdateRoomRequest struct {
-	Name        *string `json:"name"`
-	Description *string `json:"description"`
-	Capacity    *int    `json:"capacity" validate:"omitempty,min=1"`
-	Location    *string `json:"location"`
-	FeatureIDs  []uint  `json:"feature_ids"`
-	IsActive    *bool   `json:"is_active"`
+	Name                 *string               `json:"name"`
+	Description          *string               `json:"description"`
+	Capacity             *int                  `json:"capacity" validate:"omitempty,min=1"`
+	Location             *string               `json:"location"`
+	FloorID              *uint                 `json:"floor_id"`
+	Zone                 *string               `json:"zone"`
+	FeatureIDs           []uint                `json:"feature_ids"`
+	ComponentIDs         []uint                `json:"component_ids"`
+	WheelchairAccessible *bool                 `json:"wheelchair_accessible"`
+	HearingLoop          *bool                 `json:"hearing_loop"`
+	SetupMinutes         *int                  `json:"setup_minutes" validate:"omitempty,min=0,max=1440"`
+	CleanupMinutes       *int                  `json:"cleanup_minutes" validate:"omitempty,min=0,max=1440"`
+	IsActive             *bool                 `json:"is_active"`
+	AccessControl        *RoomAccessControl    `json:"access_control"`
+	Approval             *RoomApprovalSettings `json:"approval"`
 }
 
 type CreateRoomFeatureRequest struct {
@@ -57,8 +124,113 @@ type UpdateRoomFeatureRequest struct {
 	Description *string `json:"description"`
 }
 
+// RoomFilter narrows room searches by space type, size, location, features
+// and accessibility. An empty SpaceType means meeting rooms. Rooms need every
+// requested feature, or at least one of them when AnyFeature is set.
+// FeatureNames are resolved to IDs by the room service before the filter
+// reaches the repository.
+type RoomFilter struct {
+	SpaceType            SpaceType      `json:"space_type"`
+	Capacity             *int           `json:"capacity"`
+	Location             LocationFilter `json:"location"`
+	Zone                 string         `json:"zone"`
+	FeatureIDs           []uint         `json:"feature_ids"`
+	FeatureNames         []string       `json:"feature_names"`
+	AnyFeature           bool           `json:"any_feature"`
+	WheelchairAccessible bool           `json:"wheelchair_accessible"`
+	HearingLoop          bool           `json:"hearing_loop"`
+}
+
+func (f RoomFilter) HasFeatures() bool {
+	return len(f.FeatureIDs) > 0 || len(f.FeatureNames) > 0
+}
+
+// RoomAvailabilityQuery looks for rooms matching RoomFilter that are free
+// from StartTime to EndTime.
 type RoomAvailabilityQuery struct {
 	StartTime time.Time `json:"start_time" validate:"required"`
 	EndTime   time.Time `json:"end_time" validate:"required"`
-	Capacity  *int      `json:"capacity"`
+	RoomFilter
+	Booker *RoomBooker `json:"-"`
+	// Buffers overrides the rooms' setup and cleanup defaults for the
+	// booking being looked for.
+	Buffers BookingBuffers `json:"-"`
+}
+
+// SpaceType tells meeting rooms from desks. Both are booked through the same
+// availability rules, but desks are reserved per day with desk bookings
+// instead of meetings.
+type SpaceType string
+
+const (
+	SpaceTypeRoom SpaceType = "room"
+	SpaceTypeDesk SpaceType = "desk"
+)
+
+func (r *Room) IsDesk() bool {
+	return r.SpaceType == SpaceTypeDesk
+}
+
+// IsCombined reports whether the room is made of other rooms. Components
+// must be loaded.
+func (r *Room) IsCombined() bool {
+	return len(r.Components) > 0
+}
+
+// Spaces returns the IDs of the rooms whose space the room takes up: its
+// components if it is combined, else only itself. Rooms whose spaces overlap
+// cannot be booked at the same time.
+func (r *Room) Spaces() []uint {
+	if !r.IsCombined() {
+		return []uint{r.ID}
+	}
+
+	spaces := make([]uint, len(r.Components))
+	for i, component := range r.Components {
+		spaces[i] = component.ID
+	}
+	return spaces
+}
+
+// SharesSpaceWith reports whether the two rooms take up any of the same space,
+// so they cannot be booked at the same time. Components must be loaded.
+func (r *Room) SharesSpaceWith(other *Room) bool {
+	for _, space := range r.Spaces() {
+		for _, otherSpace := range other.Spaces() {
+			if space == otherSpace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CanBeBookedBy reports whether user, a member of groupIDs, passes the room's
+// booking ACL. Unrestricted rooms can be booked by everyone.
+func (r *Room) CanBeBookedBy(user *User, groupIDs []uint) bool {
+	if !r.IsRestricted {
+		return true
+	}
+
+	for _, allowed := range r.AllowedUsers {
+		if allowed.ID == user.ID {
+			return true
+		}
+	}
+
+	for _, allowed := range r.AllowedRoles {
+		if allowed.Role == user.Role {
+			return true
+		}
+	}
+
+	for _, allowed := range r.AllowedGroups {
+		for _, groupID := range groupIDs {
+			if allowed.ID == groupID {
+				return true
+			}
+		}
+	}
+
+	return false
 }
\ No newline at end of file
@@ -0,0 +1,64 @@
+package models
+
+import "testing"
+
+func TestCombinedRoomAvailability(t *testing.T) {
+	hallA := Room{ID: 1, Floor: &Floor{ID: 10, BuildingID: 100}}
+	hallB := Room{ID: 2, Floor: &Floor{ID: 10, BuildingID: 100}}
+	hall := Room{ID: 3, Floor: &Floor{ID: 10, BuildingID: 100}, Components: []Room{hallA, hallB}}
+	office := Room{ID: 4, Floor: &Floor{ID: 20, BuildingID: 200}}
+	rooms := map[uint]*Room{1: &hallA, 2: &hallB, 3: &hall, 4: &office}
+
+	roomBlackout := func(id uint) RoomBlackout { return RoomBlackout{RoomID: &id, Room: rooms[id]} }
+	buildingBlackout := func(id uint) RoomBlackout { return RoomBlackout{BuildingID: &id} }
+
+	tests := []struct {
+		name     string
+		blackout RoomBlackout
+		room     Room
+		want     bool
+	}{
+		{"blackout of the room itself", roomBlackout(1), hallA, true},
+		{"blackout of a component blocks the combined room", roomBlackout(1), hall, true},
+		{"blackout of the other component", roomBlackout(2), hall, true},
+		{"blackout of the combined room blocks its components", roomBlackout(3), hallA, true},
+		{"blackout of the combined room itself", roomBlackout(3), hall, true},
+		{"blackout of a component leaves the other one alone", roomBlackout(1), hallB, false},
+		{"blackout of an unrelated room", roomBlackout(4), hall, false},
+		{"building blackout", buildingBlackout(100), hall, true},
+		{"other building", buildingBlackout(100), office, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.blackout.AppliesTo(&tt.room); got != tt.want {
+				t.Errorf("AppliesTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoomSpaces(t *testing.T) {
+	tests := []struct {
+		name string
+		room Room
+		want []uint
+	}{
+		{"plain room", Room{ID: 1}, []uint{1}},
+		{"combined room", Room{ID: 3, Components: []Room{{ID: 1}, {ID: 2}}}, []uint{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.room.Spaces()
+			if len(got) != len(tt.want) {
+				t.Fatalf("Spaces() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Spaces() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
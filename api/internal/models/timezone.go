@@ -0,0 +1,58 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultTimeZone is used for users, sites and meetings without a zone of
+// their own. Times are always stored in UTC; zones only decide how wall-clock
+// values such as dates and opening hours are interpreted.
+const DefaultTimeZone = "UTC"
+
+// LoadTimeZone resolves an IANA time zone name such as "Europe/Berlin". The
+// empty name and "Local" are rejected so results never depend on where the
+// server runs.
+func LoadTimeZone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return loc, nil
+}
+
+// locationOrUTC resolves a stored zone name, falling back to UTC for empty or
+// unknown names.
+func locationOrUTC(name string) *time.Location {
+	loc, err := LoadTimeZone(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// StartOfDay returns midnight of the day t falls on in loc.
+func StartOfDay(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+}
+
+func (s *Site) Location() *time.Location {
+	return locationOrUTC(s.TimeZone)
+}
+
+func (u *User) Location() *time.Location {
+	return locationOrUTC(u.TimeZone)
+}
+
+// SiteTimeZone returns the time zone of the site the room is on, or "" when
+// the room is not attached to a floor or its site was not loaded.
+func (r *Room) SiteTimeZone() string {
+	if r.Floor == nil || r.Floor.Building == nil || r.Floor.Building.Site == nil {
+		return ""
+	}
+	return r.Floor.Building.Site.TimeZone
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadTimeZone(t *testing.T) {
+	tests := []struct {
+		name    string
+		zone    string
+		wantErr bool
+	}{
+		{"IANA zone", "Europe/Berlin", false},
+		{"UTC", "UTC", false},
+		{"empty", "", true},
+		{"server zone", "Local", true},
+		{"unknown zone", "Mars/Olympus", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadTimeZone(tt.zone); (err != nil) != tt.wantErr {
+				t.Errorf("LoadTimeZone(%q) error = %v, wantErr %v", tt.zone, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStartOfDay(t *testing.T) {
+	tests := []struct {
+		name      string
+		zone      string
+		moment    string
+		want      string
+		dayLength time.Duration
+	}{
+		{"UTC", "UTC", "2025-06-10T15:00:00Z", "2025-06-10T00:00:00Z", 24 * time.Hour},
+		{"evening in UTC is the next day in Tokyo", "Asia/Tokyo", "2025-06-10T20:00:00Z", "2025-06-10T15:00:00Z", 24 * time.Hour},
+		{"morning in UTC is the previous day in New York", "America/New_York", "2025-06-10T02:00:00Z", "2025-06-09T04:00:00Z", 24 * time.Hour},
+		{"DST starts in Berlin", "Europe/Berlin", "2025-03-30T12:00:00Z", "2025-03-29T23:00:00Z", 23 * time.Hour},
+		{"DST ends in Berlin", "Europe/Berlin", "2025-10-26T12:00:00Z", "2025-10-25T22:00:00Z", 25 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := time.LoadLocation(tt.zone)
+			if err != nil {
+				t.Fatal(err)
+			}
+			moment, _ := time.Parse(time.RFC3339, tt.moment)
+
+			start := StartOfDay(moment, loc)
+			if got := start.UTC().Format(time.RFC3339); got != tt.want {
+				t.Errorf("StartOfDay() = %s, want %s", got, tt.want)
+			}
+			if got := start.AddDate(0, 0, 1).Sub(start); got != tt.dayLength {
+				t.Errorf("day lasts %v, want %v", got, tt.dayLength)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package models
+
+type TransferMeetingRequest struct {
+	NewOrganizerID uint `json:"new_organizer_id" validate:"required"`
+}
+
+// TransferMeetingsRequest hands every upcoming meeting FromUserID organizes
+// to NewOrganizerID.
+type TransferMeetingsRequest struct {
+	FromUserID     uint `json:"from_user_id" validate:"required"`
+	NewOrganizerID uint `json:"new_organizer_id" validate:"required"`
+}
+
+// MeetingTransferFailure is a meeting that could not be handed over, such as
+// one in a room the new organizer may not book.
+type MeetingTransferFailure struct {
+	Meeting *Meeting `json:"meeting"`
+	Reason  string   `json:"reason"`
+}
+
+// MeetingTransferReport lists the outcome of transferring a user's upcoming
+// meetings.
+type MeetingTransferReport struct {
+	FromUserID   uint                     `json:"from_user_id"`
+	NewOrganizer *User                    `json:"new_organizer"`
+	Transferred  []*Meeting               `json:"transferred"`
+	Failed       []MeetingTransferFailure `json:"failed"`
+}
+
+// UserDeactivationReport lists the upcoming meetings a deactivated user
+// still organizes and need a new organizer, after the transfer to one if it
+// was requested.
+type UserDeactivationReport struct {
+	User             *User                  `json:"user"`
+	UpcomingMeetings []*Meeting             `json:"upcoming_meetings"`
+	Transfer         *MeetingTransferReport `json:"transfer,omitempty"`
+}
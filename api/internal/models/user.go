@@ -7,19 +7,23 @@ import (
 )
 
 type User struct {
-	ID             uint           `json:"id" gorm:"primaryKey"`
-	MicrosoftID    string         `json:"microsoft_id" gorm:"uniqueIndex;not null"`
-	Email          string         `json:"email" gorm:"uniqueIndex;not null"`
-	FirstName      string         `json:"first_name" gorm:"not null"`
-	LastName       string         `json:"last_name" gorm:"not null"`
-	DisplayName    string         `json:"display_name"`
-	ProfilePicture string         `json:"profile_picture"`
-	Role           UserRole       `json:"role" gorm:"default:'employee'"`
-	IsActive       bool           `json:"is_active" gorm:"default:true"`
-	LastLogin      *time.Time     `json:"last_login"`
-	CreatedAt      time.Time      `json:"created_at"`
-	UpdatedAt      time.Time      `json:"updated_at"`
-	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
+	ID               uint           `json:"id" gorm:"primaryKey"`
+	MicrosoftID      *string        `json:"microsoft_id" gorm:"uniqueIndex"`
+	Email            string         `json:"email" gorm:"uniqueIndex;not null"`
+	FirstName        string         `json:"first_name" gorm:"not null"`
+	LastName         string         `json:"last_name" gorm:"not null"`
+	DisplayName      string         `json:"display_name"`
+	ProfilePicture   string         `json:"profile_picture"`
+	PasswordHash     string         `json:"-"`
+	Role             UserRole       `json:"role" gorm:"default:'employee'"`
+	IsActive         bool           `json:"is_active" gorm:"default:true"`
+	IsServiceAccount bool           `json:"is_service_account" gorm:"default:false;index"`
+	TimeZone         string         `json:"time_zone" gorm:"size:64;not null;default:'UTC'"`
+	HomeFloorID      *uint          `json:"home_floor_id" gorm:"index"`
+	LastLogin        *time.Time     `json:"last_login"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `json:"-" gorm:"index"`
 
 	OrganizedMeetings []Meeting `json:"organized_meetings" gorm:"foreignKey:OrganizerID"`
 	AttendedMeetings  []Meeting `json:"attended_meetings" gorm:"many2many:meeting_attendees;"`
@@ -34,7 +38,7 @@ const (
 )
 
 type CreateUserRequest struct {
-	MicrosoftID    string `json:"microsoft_id" validate:"required"`
+	MicrosoftID    string `json:"microsoft_id"`
 	Email          string `json:"email" validate:"required,email"`
 	FirstName      string `json:"first_name" validate:"required"`
 	LastName       string `json:"last_name" validate:"required"`
@@ -49,12 +53,69 @@ type UpdateUserRequest struct {
 	ProfilePicture *string   `json:"profile_picture"`
 	Role           *UserRole `json:"role"`
 	IsActive       *bool     `json:"is_active"`
+	TimeZone       *string   `json:"time_zone"`
+	HomeFloorID    *uint     `json:"home_floor_id"`
+}
+
+type LocalLoginRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type DevLoginRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type PasswordResetRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type PasswordResetConfirmRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
+
+type PasswordResetToken struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UserID    uint       `json:"user_id" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"size:64;uniqueIndex;not null"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	User User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+func (t *PasswordResetToken) IsUsable() bool {
+	return t.UsedAt == nil && time.Now().Before(t.ExpiresAt)
 }
 
 func (u *User) FullName() string {
 	return u.FirstName + " " + u.LastName
 }
 
+func (u *User) GetMicrosoftID() string {
+	if u.MicrosoftID == nil {
+		return ""
+	}
+	return *u.MicrosoftID
+}
+
+func (u *User) HasLocalPassword() bool {
+	return u.PasswordHash != ""
+}
+
+// CanUseLocalPassword reports whether the user may have a local password.
+// Service accounts only use API tokens, and accounts that sign in with
+// Microsoft alone do not get one added.
+func (u *User) CanUseLocalPassword() bool {
+	return !u.IsServiceAccount && (u.MicrosoftID == nil || u.HasLocalPassword())
+}
+
+func (u *User) IsOwnedBy(userID uint) bool {
+	return u.ID == userID
+}
+
 func (u *User) IsAdmin() bool {
 	return u.Role == RoleAdmin
 }
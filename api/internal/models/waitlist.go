@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WaitlistStatus string
+
+const (
+	WaitlistWaiting   WaitlistStatus = "waiting"
+	WaitlistOffered   WaitlistStatus = "offered"
+	WaitlistBooked    WaitlistStatus = "booked"
+	WaitlistExpired   WaitlistStatus = "expired"
+	WaitlistCancelled WaitlistStatus = "cancelled"
+)
+
+// WaitlistEntry is a booking request for a room that was taken at the time.
+// When the slot frees up the first waiting entry that fits is booked right
+// away if AutoBook is set, or else offered to its user until
+// OfferExpiresAt. Offers do not hold the room.
+type WaitlistEntry struct {
+	ID             uint           `json:"id" gorm:"primaryKey"`
+	RoomID         uint           `json:"room_id" gorm:"not null;index"`
+	UserID         uint           `json:"user_id" gorm:"not null;index"`
+	Title          string         `json:"title" gorm:"not null"`
+	Description    string         `json:"description"`
+	StartTime      time.Time      `json:"start_time" gorm:"not null"`
+	EndTime        time.Time      `json:"end_time" gorm:"not null"`
+	AttendeeIDs    []uint         `json:"attendee_ids" gorm:"serializer:json"`
+	AutoBook       bool           `json:"auto_book" gorm:"default:false"`
+	Status         WaitlistStatus `json:"status" gorm:"size:16;not null;default:'waiting';index"`
+	OfferExpiresAt *time.Time     `json:"offer_expires_at,omitempty"`
+	MeetingID      *uint          `json:"meeting_id,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
+
+	BookingBuffers
+
+	Room *Room `json:"room,omitempty" gorm:"foreignKey:RoomID"`
+	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+
+	// Position is the entry's place in line for its slot, starting at 1, set
+	// on entries that are still pending.
+	Position int `json:"position,omitempty" gorm:"-"`
+}
+
+type JoinWaitlistRequest struct {
+	Title       string    `json:"title" validate:"required"`
+	Description string    `json:"description"`
+	StartTime   time.Time `json:"start_time" validate:"required"`
+	EndTime     time.Time `json:"end_time" validate:"required"`
+	RoomID      uint      `json:"room_id" validate:"required"`
+	AttendeeIDs []uint    `json:"attendee_ids"`
+	AutoBook    bool      `json:"auto_book"`
+	BookingBuffers
+}
+
+// WaitlistPendingStatuses are the statuses of entries still in line.
+var WaitlistPendingStatuses = []WaitlistStatus{WaitlistWaiting, WaitlistOffered}
+
+func (e *WaitlistEntry) IsOwnedBy(userID uint) bool {
+	return e.UserID == userID
+}
+
+// MeetingRequest turns the entry into the booking it asks for.
+func (e *WaitlistEntry) MeetingRequest() *CreateMeetingRequest {
+	return &CreateMeetingRequest{
+		Title:          e.Title,
+		Description:    e.Description,
+		StartTime:      e.StartTime,
+		EndTime:        e.EndTime,
+		RoomID:         e.RoomID,
+		AttendeeIDs:    e.AttendeeIDs,
+		BookingBuffers: e.BookingBuffers,
+	}
+}
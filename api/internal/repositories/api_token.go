@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type APITokenRepository interface {
+	Create(token *models.APIToken) (*models.APIToken, error)
+	GetByID(id uint) (*models.APIToken, error)
+	GetByTokenHash(tokenHash string) (*models.APIToken, error)
+	GetByUserID(userID uint) ([]*models.APIToken, error)
+	Revoke(id uint) error
+	TouchLastUsed(id uint, usedAt time.Time, ip string) error
+}
+
+type apiTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewAPITokenRepository(db *gorm.DB) APITokenRepository {
+	return &apiTokenRepository{db: db}
+}
+
+func (r *apiTokenRepository) Create(token *models.APIToken) (*models.APIToken, error) {
+	if err := r.db.Create(token).Error; err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (r *apiTokenRepository) GetByID(id uint) (*models.APIToken, error) {
+	var token models.APIToken
+	if err := r.db.First(&token, id).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *apiTokenRepository) GetByTokenHash(tokenHash string) (*models.APIToken, error) {
+	var token models.APIToken
+	if err := r.db.Preload("User").Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *apiTokenRepository) GetByUserID(userID uint) ([]*models.APIToken, error) {
+	var tokens []*models.APIToken
+	if err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (r *apiTokenRepository) Revoke(id uint) error {
+	return r.db.Model(&models.APIToken{}).Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", time.Now()).Error
+}
+
+func (r *apiTokenRepository) TouchLastUsed(id uint, usedAt time.Time, ip string) error {
+	return r.db.Model(&models.APIToken{}).Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{"last_used_at": usedAt, "last_used_ip": ip}).Error
+}
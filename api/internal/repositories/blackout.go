@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type BlackoutRepository interface {
+	Create(blackout *models.RoomBlackout) (*models.RoomBlackout, error)
+	GetByID(id uint) (*models.RoomBlackout, error)
+	GetAll(filter models.RoomBlackoutFilter, offset, limit int) ([]*models.RoomBlackout, int64, error)
+	Update(blackout *models.RoomBlackout) (*models.RoomBlackout, error)
+	Delete(id uint) error
+	GetMeetingsInScope(blackout *models.RoomBlackout, from time.Time) ([]*models.Meeting, error)
+}
+
+type blackoutRepository struct {
+	db *gorm.DB
+}
+
+func NewBlackoutRepository(db *gorm.DB) BlackoutRepository {
+	return &blackoutRepository{db: db}
+}
+
+func (r *blackoutRepository) Create(blackout *models.RoomBlackout) (*models.RoomBlackout, error) {
+	if err := r.db.Create(blackout).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(blackout.ID)
+}
+
+func (r *blackoutRepository) GetByID(id uint) (*models.RoomBlackout, error) {
+	var blackout models.RoomBlackout
+	if err := r.db.Preload("Room").Preload("Building").First(&blackout, id).Error; err != nil {
+		return nil, err
+	}
+	return &blackout, nil
+}
+
+// GetAll lists blackouts, newest first. Filtering by room includes the
+// building-wide blackouts that cover it.
+func (r *blackoutRepository) GetAll(filter models.RoomBlackoutFilter, offset, limit int) ([]*models.RoomBlackout, int64, error) {
+	query := r.db.Model(&models.RoomBlackout{})
+
+	if filter.RoomID != nil {
+		query = query.Where(blackoutsForRoom(r.db, *filter.RoomID))
+	}
+	if filter.BuildingID != nil {
+		query = query.Where("building_id = ?", *filter.BuildingID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var blackouts []*models.RoomBlackout
+	if err := query.Preload("Room").Preload("Building").
+		Offset(offset).Limit(limit).Order("start_time DESC").Find(&blackouts).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return blackouts, total, nil
+}
+
+func (r *blackoutRepository) Update(blackout *models.RoomBlackout) (*models.RoomBlackout, error) {
+	if err := r.db.Omit("Room", "Building").Save(blackout).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(blackout.ID)
+}
+
+func (r *blackoutRepository) Delete(id uint) error {
+	return r.db.Delete(&models.RoomBlackout{}, id).Error
+}
+
+// GetMeetingsInScope returns the slot-holding meetings in the rooms sharing
+// space with the blackout's room, or in its building, that end after from and
+// may fall within the blackout. Callers still check the individual
+// occurrences with RoomBlackout.Blocks.
+func (r *blackoutRepository) GetMeetingsInScope(blackout *models.RoomBlackout, from time.Time) ([]*models.Meeting, error) {
+	query := r.db.Where("end_time > ? AND status IN ?", from, models.SlotHoldingStatuses)
+
+	if blackout.RoomID != nil {
+		linked, err := roomsSharingSpace(r.db, *blackout.RoomID)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("room_id IN ?", linked)
+	} else {
+		query = query.Where("room_id IN (?)", roomsInLocation(r.db, models.LocationFilter{BuildingID: blackout.BuildingID}))
+	}
+
+	if blackout.RecurrencePattern == "" {
+		query = query.Where("start_time < ?", blackout.EndTime)
+	} else if blackout.RecurrenceUntil != nil {
+		query = query.Where("start_time < ?", blackout.RecurrenceUntil.Add(blackout.EndTime.Sub(blackout.StartTime)))
+	}
+
+	var meetings []*models.Meeting
+	if err := query.Preload("Organizer").Preload("Room").
+		Order("start_time ASC").Find(&meetings).Error; err != nil {
+		return nil, err
+	}
+
+	return meetings, nil
+}
+
+// blackoutsForRoom matches blackouts of the room itself, of its components if
+// it is combined, of the combined rooms sharing space with it and of the
+// building it is in.
+func blackoutsForRoom(db *gorm.DB, roomID uint) *gorm.DB {
+	return db.Where("room_id = ?", roomID).
+		Or("room_id IN (?)", componentsOf(db, roomID)).
+		Or("room_id IN (?)", db.Table("room_components").Select("composite_id").
+			Where("component_id = ? OR component_id IN (?)", roomID, componentsOf(db, roomID))).
+		Or("building_id IN (?)", db.Model(&models.Floor{}).Select("floors.building_id").
+			Joins("JOIN rooms ON rooms.floor_id = floors.id").Where("rooms.id = ?", roomID))
+}
+
+// candidateBlackouts narrows blackouts to those that may overlap [startTime,
+// endTime). Recurring ones still have to be expanded with
+// RoomBlackout.Blocks.
+func candidateBlackouts(db *gorm.DB, startTime, endTime time.Time) *gorm.DB {
+	return db.Where("start_time < ?", endTime).
+		Where(db.Where("recurrence_pattern <> ''").Or("end_time > ?", startTime))
+}
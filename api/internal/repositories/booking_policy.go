@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"api/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BookingPolicyRepository interface {
+	GetByRoomID(roomID uint) (*models.RoomBookingPolicy, error)
+	Save(policy *models.RoomBookingPolicy) (*models.RoomBookingPolicy, error)
+	Delete(roomID uint) error
+}
+
+type bookingPolicyRepository struct {
+	db *gorm.DB
+}
+
+func NewBookingPolicyRepository(db *gorm.DB) BookingPolicyRepository {
+	return &bookingPolicyRepository{db: db}
+}
+
+// GetByRoomID returns the room's overrides, or nil when it has none.
+func (r *bookingPolicyRepository) GetByRoomID(roomID uint) (*models.RoomBookingPolicy, error) {
+	var policy models.RoomBookingPolicy
+	if err := r.db.Where("room_id = ?", roomID).First(&policy).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *bookingPolicyRepository) Save(policy *models.RoomBookingPolicy) (*models.RoomBookingPolicy, error) {
+	if err := r.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(policy).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByRoomID(policy.RoomID)
+}
+
+func (r *bookingPolicyRepository) Delete(roomID uint) error {
+	return r.db.Where("room_id = ?", roomID).Delete(&models.RoomBookingPolicy{}).Error
+}
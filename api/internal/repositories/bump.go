@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type MeetingBumpRepository interface {
+	Create(bump *models.MeetingBump) error
+	GetByFilter(filter models.MeetingBumpFilter, offset, limit int) ([]*models.MeetingBump, int64, error)
+}
+
+type meetingBumpRepository struct {
+	db *gorm.DB
+}
+
+func NewMeetingBumpRepository(db *gorm.DB) MeetingBumpRepository {
+	return &meetingBumpRepository{db: db}
+}
+
+func (r *meetingBumpRepository) Create(bump *models.MeetingBump) error {
+	return r.db.Create(bump).Error
+}
+
+// GetByFilter lists bumps, the most recent first. UserID matches both the
+// user who bumped and the organizer of the bumped meeting.
+func (r *meetingBumpRepository) GetByFilter(filter models.MeetingBumpFilter, offset, limit int) ([]*models.MeetingBump, int64, error) {
+	query := r.db.Model(&models.MeetingBump{})
+
+	if filter.MeetingID != nil {
+		query = query.Where("meeting_id = ? OR bumped_by_meeting_id = ?", *filter.MeetingID, *filter.MeetingID)
+	}
+	if filter.UserID != nil {
+		query = query.Where("bumped_by_user_id = ? OR meeting_id IN (?)", *filter.UserID,
+			r.db.Model(&models.Meeting{}).Select("id").Where("organizer_id = ?", *filter.UserID))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var bumps []*models.MeetingBump
+	if err := query.Preload("Meeting").Preload("BumpedBy").Preload("FromRoom").Preload("ToRoom").
+		Offset(offset).Limit(limit).Order("created_at DESC, id DESC").Find(&bumps).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return bumps, total, nil
+}
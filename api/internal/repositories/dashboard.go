@@ -13,8 +13,8 @@ type DashboardRepository interface {
 	GetMeetingsByStatus(filter models.DashboardFilter) ([]models.MeetingStatusCount, error)
 	GetMeetingsByMonth(filter models.DashboardFilter) ([]models.MeetingMonthlyCount, error)
 	GetTopActiveUsers(filter models.DashboardFilter, limit int) ([]models.UserActivityData, error)
-	GetRecentMeetings(limit int) ([]models.Meeting, error)
-	GetUpcomingMeetings7d() ([]models.Meeting, error)
+	GetRecentMeetings(filter models.DashboardFilter, limit int) ([]models.Meeting, error)
+	GetUpcomingMeetings7d(filter models.DashboardFilter) ([]models.Meeting, error)
 }
 
 type dashboardRepository struct {
@@ -36,15 +36,23 @@ func (r *dashboardRepository) GetDashboardStats(filter models.DashboardFilter) (
 		return nil, err
 	}
 
-	if err := r.db.Model(&models.Room{}).Count(&stats.TotalRooms).Error; err != nil {
+	roomQuery := r.db.Model(&models.Room{})
+	if !filter.LocationFilter.IsEmpty() {
+		roomQuery = roomQuery.Where("id IN (?)", roomsInLocation(r.db, filter.LocationFilter))
+	}
+
+	if err := roomQuery.Session(&gorm.Session{}).Count(&stats.TotalRooms).Error; err != nil {
 		return nil, err
 	}
 
-	if err := r.db.Model(&models.Room{}).Where("is_active = ?", true).Count(&stats.ActiveRooms).Error; err != nil {
+	if err := roomQuery.Session(&gorm.Session{}).Where("is_active = ?", true).Count(&stats.ActiveRooms).Error; err != nil {
 		return nil, err
 	}
 
 	meetingQuery := r.db.Model(&models.Meeting{})
+	if !filter.LocationFilter.IsEmpty() {
+		meetingQuery = meetingQuery.Where("room_id IN (?)", roomsInLocation(r.db, filter.LocationFilter))
+	}
 	if filter.StartDate != nil {
 		meetingQuery = meetingQuery.Where("start_time >= ?", *filter.StartDate)
 	}
@@ -99,13 +107,13 @@ func (r *dashboardRepository) GetDashboardStats(filter models.DashboardFilter) (
 	}
 	stats.TopActiveUsers = topActiveUsers
 
-	recentMeetings, err := r.GetRecentMeetings(10)
+	recentMeetings, err := r.GetRecentMeetings(filter, 10)
 	if err != nil {
 		return nil, err
 	}
 	stats.RecentMeetings = recentMeetings
 
-	upcomingMeetings7d, err := r.GetUpcomingMeetings7d()
+	upcomingMeetings7d, err := r.GetUpcomingMeetings7d(filter)
 	if err != nil {
 		return nil, err
 	}
@@ -141,6 +149,10 @@ func (r *dashboardRepository) GetRoomUtilization(filter models.DashboardFilter)
 		conditions = append(conditions, "r.id = ?")
 		args = append(args, *filter.RoomID)
 	}
+	if !filter.LocationFilter.IsEmpty() {
+		conditions = append(conditions, "r.id IN (?)")
+		args = append(args, roomsInLocation(r.db, filter.LocationFilter))
+	}
 
 	if len(conditions) > 0 {
 		query += " WHERE " + conditions[0]
@@ -171,6 +183,9 @@ func (r *dashboardRepository) GetMeetingsByStatus(filter models.DashboardFilter)
 	if filter.RoomID != nil {
 		query = query.Where("room_id = ?", *filter.RoomID)
 	}
+	if !filter.LocationFilter.IsEmpty() {
+		query = query.Where("room_id IN (?)", roomsInLocation(r.db, filter.LocationFilter))
+	}
 	if filter.UserID != nil {
 		query = query.Where("organizer_id = ? OR id IN (SELECT meeting_id FROM meeting_attendees WHERE user_id = ?)", 
 			*filter.UserID, *filter.UserID)
@@ -185,8 +200,7 @@ func (r *dashboardRepository) GetMeetingsByStatus(filter models.DashboardFilter)
 }
 
 func (r *dashboardRepository) GetMeetingsByMonth(filter models.DashboardFilter) ([]models.MeetingMonthlyCount, error) {
-	query := r.db.Model(&models.Meeting{}).
-		Select("DATE_FORMAT(start_time, '%Y-%m') as month, COUNT(*) as count")
+	query := r.db.Model(&models.Meeting{})
 
 	if filter.StartDate != nil {
 		query = query.Where("start_time >= ?", *filter.StartDate)
@@ -197,16 +211,36 @@ func (r *dashboardRepository) GetMeetingsByMonth(filter models.DashboardFilter)
 	if filter.RoomID != nil {
 		query = query.Where("room_id = ?", *filter.RoomID)
 	}
+	if !filter.LocationFilter.IsEmpty() {
+		query = query.Where("room_id IN (?)", roomsInLocation(r.db, filter.LocationFilter))
+	}
 	if filter.UserID != nil {
 		query = query.Where("organizer_id = ? OR id IN (SELECT meeting_id FROM meeting_attendees WHERE user_id = ?)", 
 			*filter.UserID, *filter.UserID)
 	}
 
-	var monthlyCounts []models.MeetingMonthlyCount
-	if err := query.Group("month").Order("month ASC").Scan(&monthlyCounts).Error; err != nil {
+	// Months are bucketed in Go rather than with DATE_FORMAT so they follow
+	// the caller's time zone, including DST, without MySQL zone tables.
+	var startTimes []time.Time
+	if err := query.Order("start_time ASC").Pluck("start_time", &startTimes).Error; err != nil {
 		return nil, err
 	}
 
+	loc := filter.TimeZone
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	monthlyCounts := []models.MeetingMonthlyCount{}
+	for _, startTime := range startTimes {
+		month := startTime.In(loc).Format("2006-01")
+		if n := len(monthlyCounts); n > 0 && monthlyCounts[n-1].Month == month {
+			monthlyCounts[n-1].Count++
+			continue
+		}
+		monthlyCounts = append(monthlyCounts, models.MeetingMonthlyCount{Month: month, Count: 1})
+	}
+
 	return monthlyCounts, nil
 }
 
@@ -240,6 +274,10 @@ func (r *dashboardRepository) GetTopActiveUsers(filter models.DashboardFilter, l
 		conditions = append(conditions, "(m1.room_id = ? OR m2.room_id = ?)")
 		args = append(args, *filter.RoomID, *filter.RoomID)
 	}
+	if !filter.LocationFilter.IsEmpty() {
+		conditions = append(conditions, "(m1.room_id IN (?) OR m2.room_id IN (?))")
+		args = append(args, roomsInLocation(r.db, filter.LocationFilter), roomsInLocation(r.db, filter.LocationFilter))
+	}
 
 	if len(conditions) > 0 {
 		query += " WHERE " + conditions[0]
@@ -259,9 +297,14 @@ func (r *dashboardRepository) GetTopActiveUsers(filter models.DashboardFilter, l
 	return userActivity, nil
 }
 
-func (r *dashboardRepository) GetRecentMeetings(limit int) ([]models.Meeting, error) {
+func (r *dashboardRepository) GetRecentMeetings(filter models.DashboardFilter, limit int) ([]models.Meeting, error) {
+	query := r.db
+	if !filter.LocationFilter.IsEmpty() {
+		query = query.Where("room_id IN (?)", roomsInLocation(r.db, filter.LocationFilter))
+	}
+
 	var meetings []models.Meeting
-	if err := r.db.Preload("Organizer").Preload("Room").
+	if err := query.Preload("Organizer").Preload("Room").
 		Order("created_at DESC").Limit(limit).Find(&meetings).Error; err != nil {
 		return nil, err
 	}
@@ -269,12 +312,17 @@ func (r *dashboardRepository) GetRecentMeetings(limit int) ([]models.Meeting, er
 	return meetings, nil
 }
 
-func (r *dashboardRepository) GetUpcomingMeetings7d() ([]models.Meeting, error) {
+func (r *dashboardRepository) GetUpcomingMeetings7d(filter models.DashboardFilter) ([]models.Meeting, error) {
 	startTime := time.Now()
 	endTime := startTime.AddDate(0, 0, 7)
 
+	query := r.db
+	if !filter.LocationFilter.IsEmpty() {
+		query = query.Where("room_id IN (?)", roomsInLocation(r.db, filter.LocationFilter))
+	}
+
 	var meetings []models.Meeting
-	if err := r.db.Preload("Organizer").Preload("Room").
+	if err := query.Preload("Organizer").Preload("Room").
 		Where("start_time >= ? AND start_time <= ? AND status = ?", 
 			startTime, endTime, models.StatusScheduled).
 		Order("start_time ASC").Find(&meetings).Error; err != nil {
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"api/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+type DelegateRepository interface {
+	Save(grant *models.DelegateGrant) (*models.DelegateGrant, error)
+	GetByID(id uint) (*models.DelegateGrant, error)
+	GetByPair(principalID, delegateID uint) (*models.DelegateGrant, error)
+	GetForUser(userID uint) ([]*models.DelegateGrant, error)
+	Delete(id uint) error
+}
+
+type delegateRepository struct {
+	db *gorm.DB
+}
+
+func NewDelegateRepository(db *gorm.DB) DelegateRepository {
+	return &delegateRepository{db: db}
+}
+
+// Save creates the grant, or changes the scope of an existing grant between
+// the same users.
+func (r *delegateRepository) Save(grant *models.DelegateGrant) (*models.DelegateGrant, error) {
+	existing, err := r.GetByPair(grant.PrincipalID, grant.DelegateID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		grant.ID = existing.ID
+		grant.CreatedAt = existing.CreatedAt
+	}
+
+	if err := r.db.Omit("Principal", "Delegate").Save(grant).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(grant.ID)
+}
+
+func (r *delegateRepository) GetByID(id uint) (*models.DelegateGrant, error) {
+	var grant models.DelegateGrant
+	if err := r.db.Preload("Principal").Preload("Delegate").First(&grant, id).Error; err != nil {
+		return nil, err
+	}
+	return &grant, nil
+}
+
+// GetByPair returns the grant principalID gave delegateID, or nil if there
+// is none.
+func (r *delegateRepository) GetByPair(principalID, delegateID uint) (*models.DelegateGrant, error) {
+	var grant models.DelegateGrant
+	err := r.db.Where("principal_id = ? AND delegate_id = ?", principalID, delegateID).First(&grant).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &grant, nil
+}
+
+// GetForUser lists the grants userID gave or received.
+func (r *delegateRepository) GetForUser(userID uint) ([]*models.DelegateGrant, error) {
+	var grants []*models.DelegateGrant
+	if err := r.db.Preload("Principal").Preload("Delegate").
+		Where("principal_id = ? OR delegate_id = ?", userID, userID).
+		Order("created_at ASC").Find(&grants).Error; err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
+
+func (r *delegateRepository) Delete(id uint) error {
+	return r.db.Delete(&models.DelegateGrant{}, id).Error
+}
@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DeskBookingRepository interface {
+	Create(booking *models.DeskBooking) (*models.DeskBooking, error)
+	GetByID(id uint) (*models.DeskBooking, error)
+	GetByFilter(filter models.DeskBookingFilter, offset, limit int) ([]*models.DeskBooking, int64, error)
+	UpdateStatus(booking *models.DeskBooking) error
+	Book(booking *models.DeskBooking) (*models.DeskBooking, models.DeskBookingConflict, error)
+}
+
+type deskBookingRepository struct {
+	db *gorm.DB
+}
+
+func NewDeskBookingRepository(db *gorm.DB) DeskBookingRepository {
+	return &deskBookingRepository{db: db}
+}
+
+func (r *deskBookingRepository) Create(booking *models.DeskBooking) (*models.DeskBooking, error) {
+	if err := r.db.Create(booking).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(booking.ID)
+}
+
+func (r *deskBookingRepository) GetByID(id uint) (*models.DeskBooking, error) {
+	var booking models.DeskBooking
+	if err := r.db.Preload("Desk.Floor.Building").Preload("User").First(&booking, id).Error; err != nil {
+		return nil, err
+	}
+	return &booking, nil
+}
+
+// GetByFilter lists desk bookings, earliest first. Cancelled bookings are
+// left out.
+func (r *deskBookingRepository) GetByFilter(filter models.DeskBookingFilter, offset, limit int) ([]*models.DeskBooking, int64, error) {
+	query := r.db.Model(&models.DeskBooking{}).Where("status IN ?", models.DeskHoldingStatuses)
+
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.DeskID != nil {
+		query = query.Where("desk_id = ?", *filter.DeskID)
+	}
+	if filter.StartDate != nil {
+		query = query.Where("end_time > ?", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		query = query.Where("start_time < ?", *filter.EndDate)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var bookings []*models.DeskBooking
+	if err := query.Preload("Desk.Floor.Building").Preload("User").
+		Offset(offset).Limit(limit).Order("start_time ASC").Find(&bookings).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return bookings, total, nil
+}
+
+func (r *deskBookingRepository) UpdateStatus(booking *models.DeskBooking) error {
+	return r.db.Model(&models.DeskBooking{}).Where("id = ?", booking.ID).Updates(map[string]interface{}{
+		"status":        booking.Status,
+		"checked_in_at": booking.CheckedInAt,
+	}).Error
+}
+
+// Book creates booking unless its user already holds a desk on its date or
+// the desk is not free for its period, in which case nothing is created and
+// the conflict is returned. The user and desk rows are locked while this is
+// checked, so concurrent bookings of the same user or desk cannot both
+// succeed.
+func (r *deskBookingRepository) Book(booking *models.DeskBooking) (*models.DeskBooking, models.DeskBookingConflict, error) {
+	conflict := models.DeskConflictNone
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.User{}, booking.UserID).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.Room{}, booking.DeskID).Error; err != nil {
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&models.DeskBooking{}).
+			Where("user_id = ? AND date = ? AND status IN ?", booking.UserID, booking.Date, models.DeskHoldingStatuses).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			conflict = models.DeskConflictUserBooked
+			return nil
+		}
+
+		available, err := isRoomAvailable(tx, booking.DeskID, booking.StartTime, booking.EndTime, models.BookingBuffers{}, nil)
+		if err != nil {
+			return err
+		}
+		if !available {
+			conflict = models.DeskConflictDeskTaken
+			return nil
+		}
+
+		return tx.Create(booking).Error
+	})
+	if err != nil || conflict != models.DeskConflictNone {
+		return nil, conflict, err
+	}
+
+	created, err := r.GetByID(booking.ID)
+	return created, models.DeskConflictNone, err
+}
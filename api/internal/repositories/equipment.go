@@ -0,0 +1,260 @@
+package repositories
+
+import (
+	"api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EquipmentRepository interface {
+	Create(equipment *models.Equipment) (*models.Equipment, error)
+	GetByID(id uint) (*models.Equipment, error)
+	GetByIDs(ids []uint) ([]*models.Equipment, error)
+	GetAll(filter models.EquipmentFilter, offset, limit int) ([]*models.Equipment, int64, error)
+	GetActive(filter models.EquipmentFilter) ([]*models.Equipment, error)
+	Update(equipment *models.Equipment) (*models.Equipment, error)
+	Delete(id uint) error
+	GetReservedQuantities(equipmentIDs []uint, startTime, endTime time.Time, excludeMeetingID *uint) (map[uint]int, error)
+	CountUpcomingBookings(equipmentID uint, from time.Time) (int64, error)
+	Reserve(meetingID uint, requests []models.EquipmentRequest, startTime, endTime time.Time) ([]models.EquipmentAvailability, error)
+}
+
+type equipmentRepository struct {
+	db *gorm.DB
+}
+
+func NewEquipmentRepository(db *gorm.DB) EquipmentRepository {
+	return &equipmentRepository{db: db}
+}
+
+func (r *equipmentRepository) Create(equipment *models.Equipment) (*models.Equipment, error) {
+	if err := r.db.Create(equipment).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(equipment.ID)
+}
+
+func (r *equipmentRepository) GetByID(id uint) (*models.Equipment, error) {
+	var equipment models.Equipment
+	if err := r.db.Preload("Site").First(&equipment, id).Error; err != nil {
+		return nil, err
+	}
+	return &equipment, nil
+}
+
+func (r *equipmentRepository) GetByIDs(ids []uint) ([]*models.Equipment, error) {
+	var equipment []*models.Equipment
+	if len(ids) == 0 {
+		return equipment, nil
+	}
+	if err := r.db.Where("id IN ?", ids).Find(&equipment).Error; err != nil {
+		return nil, err
+	}
+	return equipment, nil
+}
+
+func (r *equipmentRepository) GetAll(filter models.EquipmentFilter, offset, limit int) ([]*models.Equipment, int64, error) {
+	query := filterEquipment(r.db.Model(&models.Equipment{}), filter)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var equipment []*models.Equipment
+	if err := query.Preload("Site").Offset(offset).Limit(limit).Order("name ASC").Find(&equipment).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return equipment, total, nil
+}
+
+// GetActive lists every active piece of equipment matching filter.
+func (r *equipmentRepository) GetActive(filter models.EquipmentFilter) ([]*models.Equipment, error) {
+	var equipment []*models.Equipment
+	if err := filterEquipment(r.db.Where("is_active = ?", true), filter).
+		Preload("Site").Order("name ASC").Find(&equipment).Error; err != nil {
+		return nil, err
+	}
+	return equipment, nil
+}
+
+func (r *equipmentRepository) Update(equipment *models.Equipment) (*models.Equipment, error) {
+	if err := r.db.Omit("Site").Save(equipment).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(equipment.ID)
+}
+
+func (r *equipmentRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Equipment{}, id).Error
+}
+
+// GetReservedQuantities returns, per piece of equipment, the most units held
+// by other meetings at any moment within [startTime, endTime).
+func (r *equipmentRepository) GetReservedQuantities(equipmentIDs []uint, startTime, endTime time.Time, excludeMeetingID *uint) (map[uint]int, error) {
+	return reservedQuantities(r.db, equipmentIDs, startTime, endTime, excludeMeetingID)
+}
+
+// CountUpcomingBookings counts the meetings that end after from and still
+// hold units of the equipment.
+func (r *equipmentRepository) CountUpcomingBookings(equipmentID uint, from time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.EquipmentBooking{}).
+		Joins("JOIN meetings ON meetings.id = equipment_bookings.meeting_id AND meetings.deleted_at IS NULL").
+		Where("equipment_bookings.equipment_id = ? AND meetings.end_time > ? AND meetings.status IN ?",
+			equipmentID, from, models.SlotHoldingStatuses).
+		Count(&count).Error
+	return count, err
+}
+
+// Reserve replaces the meeting's equipment bookings with requests. The
+// equipment rows are locked while the free units are counted, so two
+// meetings cannot both take the last unit. When any request asks for more
+// than is free nothing is changed and the short equipment is returned.
+func (r *equipmentRepository) Reserve(meetingID uint, requests []models.EquipmentRequest, startTime, endTime time.Time) ([]models.EquipmentAvailability, error) {
+	var shortages []models.EquipmentAvailability
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		shortages, err = reserveEquipment(tx, meetingID, requests, startTime, endTime)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return shortages, nil
+}
+
+// reserveEquipment is Reserve within the transaction tx.
+func reserveEquipment(tx *gorm.DB, meetingID uint, requests []models.EquipmentRequest, startTime, endTime time.Time) ([]models.EquipmentAvailability, error) {
+	var shortages []models.EquipmentAvailability
+
+	ids := make([]uint, len(requests))
+	for i, request := range requests {
+		ids[i] = request.EquipmentID
+	}
+
+	if len(ids) > 0 {
+		var equipment []*models.Equipment
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", ids).Find(&equipment).Error; err != nil {
+			return nil, err
+		}
+
+		reserved, err := reservedQuantities(tx, ids, startTime, endTime, &meetingID)
+		if err != nil {
+			return nil, err
+		}
+
+		byID := make(map[uint]*models.Equipment, len(equipment))
+		for _, item := range equipment {
+			byID[item.ID] = item
+		}
+
+		for _, request := range requests {
+			item := byID[request.EquipmentID]
+			if item == nil {
+				return nil, gorm.ErrRecordNotFound
+			}
+			if available := item.Quantity - reserved[item.ID]; request.Quantity > available {
+				shortages = append(shortages, models.EquipmentAvailability{
+					Equipment: item,
+					Reserved:  reserved[item.ID],
+					Available: max(available, 0),
+				})
+			}
+		}
+		if len(shortages) > 0 {
+			return shortages, nil
+		}
+	}
+
+	if err := tx.Where("meeting_id = ?", meetingID).Delete(&models.EquipmentBooking{}).Error; err != nil {
+		return nil, err
+	}
+	if len(requests) == 0 {
+		return nil, nil
+	}
+
+	bookings := make([]models.EquipmentBooking, len(requests))
+	for i, request := range requests {
+		bookings[i] = models.EquipmentBooking{
+			MeetingID:   meetingID,
+			EquipmentID: request.EquipmentID,
+			Quantity:    request.Quantity,
+		}
+	}
+	return nil, tx.Create(&bookings).Error
+}
+
+func filterEquipment(query *gorm.DB, filter models.EquipmentFilter) *gorm.DB {
+	if filter.Category != nil {
+		query = query.Where("category = ?", *filter.Category)
+	}
+	if filter.SiteID != nil {
+		query = query.Where("(site_id = ? OR site_id IS NULL)", *filter.SiteID)
+	}
+	return query
+}
+
+// reservedQuantities returns, per piece of equipment, the most units that
+// slot-holding meetings hold at any one moment within [startTime, endTime).
+// Bookings that follow each other within the period do not add up.
+func reservedQuantities(db *gorm.DB, equipmentIDs []uint, startTime, endTime time.Time, excludeMeetingID *uint) (map[uint]int, error) {
+	reserved := make(map[uint]int)
+	if len(equipmentIDs) == 0 {
+		return reserved, nil
+	}
+
+	query := db.Model(&models.EquipmentBooking{}).
+		Select("equipment_bookings.equipment_id, equipment_bookings.quantity, meetings.start_time, meetings.end_time").
+		Joins("JOIN meetings ON meetings.id = equipment_bookings.meeting_id AND meetings.deleted_at IS NULL").
+		Where("equipment_bookings.equipment_id IN ?", equipmentIDs).
+		Where("meetings.status IN ? AND meetings.start_time < ? AND meetings.end_time > ?",
+			models.SlotHoldingStatuses, endTime, startTime)
+
+	if excludeMeetingID != nil {
+		query = query.Where("equipment_bookings.meeting_id != ?", *excludeMeetingID)
+	}
+
+	var rows []equipmentUsage
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return peakQuantities(rows, startTime), nil
+}
+
+// equipmentUsage is one meeting's booking of a piece of equipment.
+type equipmentUsage struct {
+	EquipmentID uint
+	Quantity    int
+	StartTime   time.Time
+	EndTime     time.Time
+}
+
+// peakQuantities returns, per piece of equipment, the most units rows hold
+// at any one moment from startTime on.
+func peakQuantities(rows []equipmentUsage, startTime time.Time) map[uint]int {
+	reserved := make(map[uint]int)
+
+	// Usage only rises when a booking starts, so the peak is reached at the
+	// start of one of them or at the start of the period.
+	for _, row := range rows {
+		at := row.StartTime
+		if at.Before(startTime) {
+			at = startTime
+		}
+
+		inUse := 0
+		for _, other := range rows {
+			if other.EquipmentID == row.EquipmentID && !other.StartTime.After(at) && other.EndTime.After(at) {
+				inUse += other.Quantity
+			}
+		}
+		reserved[row.EquipmentID] = max(reserved[row.EquipmentID], inUse)
+	}
+	return reserved
+}
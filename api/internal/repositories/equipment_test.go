@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeakQuantities(t *testing.T) {
+	day := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	usage := func(equipmentID uint, quantity, fromHour, toHour int) equipmentUsage {
+		return equipmentUsage{EquipmentID: equipmentID, Quantity: quantity, StartTime: at(fromHour, 0), EndTime: at(toHour, 0)}
+	}
+
+	tests := []struct {
+		name  string
+		rows  []equipmentUsage
+		start time.Time
+		want  map[uint]int
+	}{
+		{"no bookings", nil, at(9, 0), map[uint]int{}},
+		{"one booking", []equipmentUsage{usage(1, 2, 9, 10)}, at(9, 0), map[uint]int{1: 2}},
+		{"consecutive bookings do not add up", []equipmentUsage{usage(1, 2, 9, 10), usage(1, 3, 10, 11)}, at(9, 0), map[uint]int{1: 3}},
+		{"overlapping bookings add up", []equipmentUsage{usage(1, 2, 9, 11), usage(1, 3, 10, 12)}, at(9, 0), map[uint]int{1: 5}},
+		{"peak inside a longer booking", []equipmentUsage{usage(1, 1, 8, 17), usage(1, 2, 9, 10), usage(1, 4, 13, 14)}, at(8, 0), map[uint]int{1: 5}},
+		{"booking running into the period", []equipmentUsage{usage(1, 2, 8, 10), usage(1, 1, 9, 11)}, at(9, 30), map[uint]int{1: 3}},
+		{"equipment counted separately", []equipmentUsage{usage(1, 2, 9, 11), usage(2, 3, 10, 12)}, at(9, 0), map[uint]int{1: 2, 2: 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := peakQuantities(tt.rows, tt.start)
+			if len(got) != len(tt.want) {
+				t.Fatalf("peakQuantities() = %v, want %v", got, tt.want)
+			}
+			for id, quantity := range tt.want {
+				if got[id] != quantity {
+					t.Errorf("peakQuantities() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
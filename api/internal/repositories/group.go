@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type GroupRepository interface {
+	Create(group *models.UserGroup) (*models.UserGroup, error)
+	GetByID(id uint) (*models.UserGroup, error)
+	GetAll(offset, limit int) ([]*models.UserGroup, int64, error)
+	Update(group *models.UserGroup) (*models.UserGroup, error)
+	Delete(id uint) error
+	AddMember(groupID, userID uint) error
+	RemoveMember(groupID, userID uint) error
+	GetGroupIDsForUser(userID uint) ([]uint, error)
+	GetByExternalID(externalID string) (*models.UserGroup, error)
+	GetDirectoryGroupsForUser(userID uint) ([]*models.UserGroup, error)
+}
+
+type groupRepository struct {
+	db *gorm.DB
+}
+
+func NewGroupRepository(db *gorm.DB) GroupRepository {
+	return &groupRepository{db: db}
+}
+
+func (r *groupRepository) Create(group *models.UserGroup) (*models.UserGroup, error) {
+	if err := r.db.Create(group).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(group.ID)
+}
+
+func (r *groupRepository) GetByID(id uint) (*models.UserGroup, error) {
+	var group models.UserGroup
+	if err := r.db.Preload("Members").First(&group, id).Error; err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+func (r *groupRepository) GetAll(offset, limit int) ([]*models.UserGroup, int64, error) {
+	var groups []*models.UserGroup
+	var total int64
+
+	if err := r.db.Model(&models.UserGroup{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.db.Order("name").Offset(offset).Limit(limit).Find(&groups).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return groups, total, nil
+}
+
+func (r *groupRepository) Update(group *models.UserGroup) (*models.UserGroup, error) {
+	if err := r.db.Model(group).Select("name", "description").Updates(group).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(group.ID)
+}
+
+func (r *groupRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("user_group_members").Where("user_group_id = ?", id).Delete(nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Table("room_allowed_groups").Where("user_group_id = ?", id).Delete(nil).Error; err != nil {
+			return err
+		}
+		// Members invited with the group stay on its meetings as if invited
+		// individually.
+		if err := tx.Table("meeting_attendee_groups").Where("user_group_id = ?", id).Delete(nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("group_id = ?", id).Delete(&models.MeetingGroupAttendee{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.UserGroup{}, id).Error
+	})
+}
+
+func (r *groupRepository) AddMember(groupID, userID uint) error {
+	return r.db.Model(&models.UserGroup{ID: groupID}).Association("Members").Append(&models.User{ID: userID})
+}
+
+func (r *groupRepository) RemoveMember(groupID, userID uint) error {
+	return r.db.Model(&models.UserGroup{ID: groupID}).Association("Members").Delete(&models.User{ID: userID})
+}
+
+func (r *groupRepository) GetGroupIDsForUser(userID uint) ([]uint, error) {
+	var groupIDs []uint
+	err := r.db.Table("user_group_members").
+		Joins("JOIN user_groups ON user_groups.id = user_group_members.user_group_id AND user_groups.deleted_at IS NULL").
+		Where("user_group_members.user_id = ?", userID).
+		Pluck("user_group_members.user_group_id", &groupIDs).Error
+	return groupIDs, err
+}
+
+func (r *groupRepository) GetByExternalID(externalID string) (*models.UserGroup, error) {
+	var group models.UserGroup
+	if err := r.db.Where("external_id = ?", externalID).First(&group).Error; err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// GetDirectoryGroupsForUser returns the directory groups userID is a member of.
+func (r *groupRepository) GetDirectoryGroupsForUser(userID uint) ([]*models.UserGroup, error) {
+	var groups []*models.UserGroup
+	err := r.db.Joins("JOIN user_group_members ON user_group_members.user_group_id = user_groups.id").
+		Where("user_group_members.user_id = ? AND user_groups.source = ?", userID, models.GroupSourceDirectory).
+		Find(&groups).Error
+	return groups, err
+}
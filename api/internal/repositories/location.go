@@ -0,0 +1,224 @@
+package repositories
+
+import (
+	"api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type SiteRepository interface {
+	Create(site *models.Site) (*models.Site, error)
+	GetByID(id uint) (*models.Site, error)
+	GetAll(offset, limit int) ([]*models.Site, int64, error)
+	Update(site *models.Site) (*models.Site, error)
+	Delete(id uint) error
+	CountBuildings(id uint) (int64, error)
+}
+
+type BuildingRepository interface {
+	Create(building *models.Building) (*models.Building, error)
+	GetByID(id uint) (*models.Building, error)
+	GetAll(siteID *uint, offset, limit int) ([]*models.Building, int64, error)
+	Update(building *models.Building) (*models.Building, error)
+	Delete(id uint) error
+	CountFloors(id uint) (int64, error)
+}
+
+type FloorRepository interface {
+	Create(floor *models.Floor) (*models.Floor, error)
+	GetByID(id uint) (*models.Floor, error)
+	GetAll(buildingID *uint, offset, limit int) ([]*models.Floor, int64, error)
+	Update(floor *models.Floor) (*models.Floor, error)
+	Delete(id uint) error
+	CountRooms(id uint) (int64, error)
+}
+
+type siteRepository struct {
+	db *gorm.DB
+}
+
+type buildingRepository struct {
+	db *gorm.DB
+}
+
+type floorRepository struct {
+	db *gorm.DB
+}
+
+func NewSiteRepository(db *gorm.DB) SiteRepository {
+	return &siteRepository{db: db}
+}
+
+func NewBuildingRepository(db *gorm.DB) BuildingRepository {
+	return &buildingRepository{db: db}
+}
+
+func NewFloorRepository(db *gorm.DB) FloorRepository {
+	return &floorRepository{db: db}
+}
+
+func (r *siteRepository) Create(site *models.Site) (*models.Site, error) {
+	if err := r.db.Create(site).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(site.ID)
+}
+
+func (r *siteRepository) GetByID(id uint) (*models.Site, error) {
+	var site models.Site
+	if err := r.db.Preload("Buildings").Preload("Buildings.Floors").First(&site, id).Error; err != nil {
+		return nil, err
+	}
+	return &site, nil
+}
+
+func (r *siteRepository) GetAll(offset, limit int) ([]*models.Site, int64, error) {
+	var sites []*models.Site
+	var total int64
+
+	if err := r.db.Model(&models.Site{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.db.Order("name").Offset(offset).Limit(limit).Find(&sites).Error; err != nil {
+		return nil, 0, err
+	}
+	return sites, total, nil
+}
+
+func (r *siteRepository) Update(site *models.Site) (*models.Site, error) {
+	if err := r.db.Omit("Buildings").Save(site).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(site.ID)
+}
+
+func (r *siteRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Site{}, id).Error
+}
+
+func (r *siteRepository) CountBuildings(id uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Building{}).Where("site_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+func (r *buildingRepository) Create(building *models.Building) (*models.Building, error) {
+	if err := r.db.Create(building).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(building.ID)
+}
+
+func (r *buildingRepository) GetByID(id uint) (*models.Building, error) {
+	var building models.Building
+	if err := r.db.Preload("Site").Preload("Floors").First(&building, id).Error; err != nil {
+		return nil, err
+	}
+	return &building, nil
+}
+
+func (r *buildingRepository) GetAll(siteID *uint, offset, limit int) ([]*models.Building, int64, error) {
+	query := r.db.Model(&models.Building{})
+	if siteID != nil {
+		query = query.Where("site_id = ?", *siteID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var buildings []*models.Building
+	if err := query.Preload("Site").Order("name").Offset(offset).Limit(limit).Find(&buildings).Error; err != nil {
+		return nil, 0, err
+	}
+	return buildings, total, nil
+}
+
+func (r *buildingRepository) Update(building *models.Building) (*models.Building, error) {
+	if err := r.db.Omit("Site", "Floors").Save(building).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(building.ID)
+}
+
+func (r *buildingRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Building{}, id).Error
+}
+
+func (r *buildingRepository) CountFloors(id uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Floor{}).Where("building_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+func (r *floorRepository) Create(floor *models.Floor) (*models.Floor, error) {
+	if err := r.db.Create(floor).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(floor.ID)
+}
+
+func (r *floorRepository) GetByID(id uint) (*models.Floor, error) {
+	var floor models.Floor
+	if err := r.db.Preload("Building").Preload("Building.Site").First(&floor, id).Error; err != nil {
+		return nil, err
+	}
+	return &floor, nil
+}
+
+func (r *floorRepository) GetAll(buildingID *uint, offset, limit int) ([]*models.Floor, int64, error) {
+	query := r.db.Model(&models.Floor{})
+	if buildingID != nil {
+		query = query.Where("building_id = ?", *buildingID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var floors []*models.Floor
+	if err := query.Preload("Building").Order("building_id, level").Offset(offset).Limit(limit).Find(&floors).Error; err != nil {
+		return nil, 0, err
+	}
+	return floors, total, nil
+}
+
+func (r *floorRepository) Update(floor *models.Floor) (*models.Floor, error) {
+	if err := r.db.Omit("Building").Save(floor).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(floor.ID)
+}
+
+func (r *floorRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Floor{}, id).Error
+}
+
+func (r *floorRepository) CountRooms(id uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Room{}).Where("floor_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// roomsInLocation selects the IDs of rooms within filter, for use as a
+// subquery.
+func roomsInLocation(db *gorm.DB, filter models.LocationFilter) *gorm.DB {
+	query := db.Model(&models.Room{}).Select("rooms.id")
+
+	switch {
+	case filter.FloorID != nil:
+		query = query.Where("rooms.floor_id = ?", *filter.FloorID)
+	case filter.BuildingID != nil:
+		query = query.Joins("JOIN floors ON floors.id = rooms.floor_id").
+			Where("floors.building_id = ?", *filter.BuildingID)
+	case filter.SiteID != nil:
+		query = query.Joins("JOIN floors ON floors.id = rooms.floor_id").
+			Joins("JOIN buildings ON buildings.id = floors.building_id").
+			Where("buildings.site_id = ?", *filter.SiteID)
+	}
+
+	return query
+}
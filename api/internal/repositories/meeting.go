@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"api/internal/models"
+	"errors"
 	"time"
 
 	"gorm.io/gorm"
@@ -18,11 +19,34 @@ type MeetingRepository interface {
 	GetMeetingsByDateRange(startDate, endDate time.Time, userID *uint) ([]*models.Meeting, error)
 	GetMeetingsByRoom(roomID uint, offset, limit int) ([]*models.Meeting, int64, error)
 	GetMeetingsByOrganizer(organizerID uint, offset, limit int) ([]*models.Meeting, int64, error)
-	GetConflictingMeetings(roomID uint, startTime, endTime time.Time, excludeMeetingID *uint) ([]*models.Meeting, error)
+	GetConflictingMeetings(roomID uint, startTime, endTime time.Time, buffers models.BookingBuffers, excludeMeetingID *uint) ([]*models.Meeting, error)
 	UpdateMeetingStatus(id uint, status models.MeetingStatus) error
 	GetMeetingAttendees(meetingID uint) ([]*models.User, error)
 	AddAttendee(meetingID, userID uint) error
 	RemoveAttendee(meetingID, userID uint) error
+	UpdateApprovalState(meeting *models.Meeting, from models.MeetingStatus) (bool, error)
+	GetPendingApprovals(approverID *uint, offset, limit int) ([]*models.Meeting, int64, error)
+	GetExpiredPendingApprovals(now time.Time) ([]*models.Meeting, error)
+	UpdateHoldState(meeting *models.Meeting, from models.MeetingStatus) (bool, error)
+	RecordActivity(activity *models.MeetingActivity) error
+	GetActivity(meetingID uint) ([]*models.MeetingActivity, error)
+	GetExpiredHolds(now time.Time) ([]*models.Meeting, error)
+	CountActiveByOrganizer(organizerID uint, now time.Time, excludeMeetingID *uint) (int64, error)
+	GetActiveByRoom(roomID uint, from time.Time) ([]*models.Meeting, error)
+	GetActiveByOrganizer(organizerID uint, from time.Time) ([]*models.Meeting, error)
+	UpdateOrganizer(meetingID, organizerID uint) error
+	GetBlockingByRoom(roomID uint, startTime, endTime time.Time, buffers models.BookingBuffers) ([]*models.Meeting, error)
+	UpdateRoomAssignment(meeting *models.Meeting) error
+	CreateWithRelocations(meeting *models.Meeting, relocations []models.MeetingRelocation, equipment []models.EquipmentRequest) (bool, []models.EquipmentAvailability, error)
+	CountBookingsByRoom(userIDs []uint, from, to time.Time) (map[uint]int64, error)
+	SetAttendeeGroups(meetingID uint, groupIDs []uint) error
+	GetActiveByAttendeeGroup(groupID uint, from time.Time) ([]*models.Meeting, error)
+	GetAttendeeConflicts(userIDs []uint, startTime, endTime time.Time, excludeMeetingID *uint) ([]models.AttendeeConflict, error)
+	GetGroupAttendees(meetingID uint) ([]*models.MeetingGroupAttendee, error)
+	SetGroupAttendees(meetingID uint, records []*models.MeetingGroupAttendee) error
+	AddGroupAttendee(record *models.MeetingGroupAttendee) error
+	RemoveGroupAttendee(meetingID, userID, groupID uint) error
+	RemoveGroupAttendees(meetingID, userID uint) error
 }
 
 type meetingRepository struct {
@@ -42,7 +66,8 @@ func (r *meetingRepository) Create(meeting *models.Meeting) (*models.Meeting, er
 
 func (r *meetingRepository) GetByID(id uint) (*models.Meeting, error) {
 	var meeting models.Meeting
-	if err := r.db.Preload("Organizer").Preload("Room").Preload("Room.Features").Preload("Attendees").First(&meeting, id).Error; err != nil {
+	if err := r.db.Preload("Organizer").Preload("Room").Preload("Room.Features").Preload("Room.Approvers").
+		Preload("Attendees").Preload("AttendeeGroups").Preload("Equipment.Equipment").First(&meeting, id).Error; err != nil {
 		return nil, err
 	}
 	return &meeting, nil
@@ -64,8 +89,10 @@ func (r *meetingRepository) GetAll(offset, limit int) ([]*models.Meeting, int64,
 	return meetings, total, nil
 }
 
+// Update saves the meeting's own columns. Equipment bookings are changed
+// through the equipment repository only.
 func (r *meetingRepository) Update(meeting *models.Meeting) (*models.Meeting, error) {
-	if err := r.db.Save(meeting).Error; err != nil {
+	if err := r.db.Omit("Equipment", "AttendeeGroups").Save(meeting).Error; err != nil {
 		return nil, err
 	}
 	return r.GetByID(meeting.ID)
@@ -187,12 +214,21 @@ func (r *meetingRepository) GetMeetingsByOrganizer(organizerID uint, offset, lim
 	return meetings, total, nil
 }
 
-func (r *meetingRepository) GetConflictingMeetings(roomID uint, startTime, endTime time.Time, excludeMeetingID *uint) ([]*models.Meeting, error) {
-	query := r.db.Where("room_id = ? AND start_time < ? AND end_time > ? AND status IN ?", 
-		roomID, endTime, startTime, []models.MeetingStatus{models.StatusScheduled, models.StatusInProgress})
+func (r *meetingRepository) GetConflictingMeetings(roomID uint, startTime, endTime time.Time, buffers models.BookingBuffers, excludeMeetingID *uint) ([]*models.Meeting, error) {
+	linked, err := roomsSharingSpace(r.db, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockedStart, blockedEnd, err := blockedInterval(r.db, roomID, startTime, endTime, buffers)
+	if err != nil {
+		return nil, err
+	}
+
+	query := blockingMeetings(r.db, blockedStart, blockedEnd).Where("meetings.room_id IN ?", linked)
 
 	if excludeMeetingID != nil {
-		query = query.Where("id != ?", *excludeMeetingID)
+		query = query.Where("meetings.id != ?", *excludeMeetingID)
 	}
 
 	var meetings []*models.Meeting
@@ -229,4 +265,360 @@ func (r *meetingRepository) AddAttendee(meetingID, userID uint) error {
 func (r *meetingRepository) RemoveAttendee(meetingID, userID uint) error {
 	return r.db.Exec("DELETE FROM meeting_attendees WHERE meeting_id = ? AND user_id = ?", 
 		meetingID, userID).Error
+}
+
+// UpdateApprovalState persists an approval decision. It only applies while
+// the meeting still has status from, and reports whether it did.
+func (r *meetingRepository) UpdateApprovalState(meeting *models.Meeting, from models.MeetingStatus) (bool, error) {
+	result := r.db.Model(&models.Meeting{}).Where("id = ? AND status = ?", meeting.ID, from).Updates(map[string]interface{}{
+		"status":              meeting.Status,
+		"approval_expires_at": meeting.ApprovalExpiresAt,
+		"approval_decided_by": meeting.ApprovalDecidedBy,
+		"approval_decided_at": meeting.ApprovalDecidedAt,
+		"approval_note":       meeting.ApprovalNote,
+	})
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetPendingApprovals lists bookings waiting for sign-off, oldest first. With
+// an approverID only rooms that user approves are included.
+func (r *meetingRepository) GetPendingApprovals(approverID *uint, offset, limit int) ([]*models.Meeting, int64, error) {
+	query := r.db.Model(&models.Meeting{}).Where("status = ?", models.StatusPendingApproval)
+
+	if approverID != nil {
+		query = query.Where("room_id IN (SELECT room_id FROM room_approvers WHERE user_id = ?)", *approverID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var meetings []*models.Meeting
+	if err := query.Preload("Organizer").Preload("Room").Preload("Attendees").
+		Offset(offset).Limit(limit).Order("created_at ASC").Find(&meetings).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return meetings, total, nil
+}
+
+func (r *meetingRepository) GetExpiredPendingApprovals(now time.Time) ([]*models.Meeting, error) {
+	var meetings []*models.Meeting
+	if err := r.db.Preload("Organizer").Preload("Room").
+		Where("status = ? AND approval_expires_at <= ?", models.StatusPendingApproval, now).
+		Find(&meetings).Error; err != nil {
+		return nil, err
+	}
+	return meetings, nil
+}
+
+// UpdateHoldState persists a hold being confirmed or released, including the
+// approval request a confirmation may open. It only applies while the meeting
+// still has status from, and reports whether it did.
+func (r *meetingRepository) UpdateHoldState(meeting *models.Meeting, from models.MeetingStatus) (bool, error) {
+	result := r.db.Model(&models.Meeting{}).Where("id = ? AND status = ?", meeting.ID, from).Updates(map[string]interface{}{
+		"status":              meeting.Status,
+		"hold_expires_at":     meeting.HoldExpiresAt,
+		"approval_expires_at": meeting.ApprovalExpiresAt,
+	})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *meetingRepository) GetExpiredHolds(now time.Time) ([]*models.Meeting, error) {
+	var meetings []*models.Meeting
+	if err := r.db.Preload("Organizer").Preload("Room").
+		Where("status = ? AND hold_expires_at <= ?", models.StatusHeld, now).
+		Find(&meetings).Error; err != nil {
+		return nil, err
+	}
+	return meetings, nil
+}
+
+func (r *meetingRepository) RecordActivity(activity *models.MeetingActivity) error {
+	return r.db.Create(activity).Error
+}
+
+func (r *meetingRepository) GetActivity(meetingID uint) ([]*models.MeetingActivity, error) {
+	var activities []*models.MeetingActivity
+	if err := r.db.Preload("Actor").Preload("OnBehalfOf").Preload("PreviousOrganizer").Preload("NewOrganizer").
+		Where("meeting_id = ?", meetingID).
+		Order("created_at ASC, id ASC").Find(&activities).Error; err != nil {
+		return nil, err
+	}
+	return activities, nil
+}
+
+// CountActiveByOrganizer counts the organizer's bookings that have not ended
+// yet and still hold their room.
+func (r *meetingRepository) CountActiveByOrganizer(organizerID uint, now time.Time, excludeMeetingID *uint) (int64, error) {
+	query := r.db.Model(&models.Meeting{}).
+		Where("organizer_id = ? AND end_time > ? AND status IN ?", organizerID, now, models.SlotHoldingStatuses)
+
+	if excludeMeetingID != nil {
+		query = query.Where("id != ?", *excludeMeetingID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetActiveByRoom returns the bookings that still hold the room after from,
+// earliest first: those of the room itself and of the combined rooms it is
+// part of.
+func (r *meetingRepository) GetActiveByRoom(roomID uint, from time.Time) ([]*models.Meeting, error) {
+	combined := r.db.Table("room_components").Select("composite_id").Where("component_id = ?", roomID)
+
+	var meetings []*models.Meeting
+	if err := r.db.Preload("Organizer").Preload("Room.Features").Preload("Room.Floor.Building").Preload("Attendees").
+		Where("(room_id = ? OR room_id IN (?)) AND end_time > ? AND status IN ?", roomID, combined, from, models.SlotHoldingStatuses).
+		Order("start_time ASC").Find(&meetings).Error; err != nil {
+		return nil, err
+	}
+	return meetings, nil
+}
+
+// GetBlockingByRoom returns the meetings that keep a booking of roomID from
+// startTime to endTime with buffers out of it: those in the room itself and
+// in the rooms sharing its space.
+func (r *meetingRepository) GetBlockingByRoom(roomID uint, startTime, endTime time.Time, buffers models.BookingBuffers) ([]*models.Meeting, error) {
+	linked, err := roomsSharingSpace(r.db, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockedStart, blockedEnd, err := blockedInterval(r.db, roomID, startTime, endTime, buffers)
+	if err != nil {
+		return nil, err
+	}
+
+	var meetings []*models.Meeting
+	if err := blockingMeetings(r.db, blockedStart, blockedEnd).
+		Preload("Organizer").Preload("Room").Preload("Attendees").
+		Where("meetings.room_id IN ?", linked).
+		Order("meetings.start_time ASC").Find(&meetings).Error; err != nil {
+		return nil, err
+	}
+	return meetings, nil
+}
+
+// GetActiveByOrganizer returns the organizer's bookings that end after from
+// and still hold their room, soonest first.
+func (r *meetingRepository) GetActiveByOrganizer(organizerID uint, from time.Time) ([]*models.Meeting, error) {
+	var meetings []*models.Meeting
+	if err := r.db.Preload("Organizer").Preload("Room").Preload("Attendees").
+		Where("organizer_id = ? AND end_time > ? AND status IN ?", organizerID, from, models.SlotHoldingStatuses).
+		Order("start_time ASC").Find(&meetings).Error; err != nil {
+		return nil, err
+	}
+	return meetings, nil
+}
+
+func (r *meetingRepository) UpdateOrganizer(meetingID, organizerID uint) error {
+	return r.db.Model(&models.Meeting{}).Where("id = ?", meetingID).Update("organizer_id", organizerID).Error
+}
+
+func (r *meetingRepository) UpdateRoomAssignment(meeting *models.Meeting) error {
+	return updateRoomAssignment(r.db, meeting)
+}
+
+func updateRoomAssignment(db *gorm.DB, meeting *models.Meeting) error {
+	return db.Model(&models.Meeting{}).Where("id = ?", meeting.ID).Updates(map[string]interface{}{
+		"room_id":             meeting.RoomID,
+		"status":              meeting.Status,
+		"approval_expires_at": meeting.ApprovalExpiresAt,
+	}).Error
+}
+
+// errRelocationConflict rolls back CreateWithRelocations.
+var errRelocationConflict = errors.New("relocation conflict")
+
+// CreateWithRelocations moves each relocated meeting to its new room, creates
+// meeting in the room they made way for and reserves equipment for it, all in
+// one transaction. It reports false and changes nothing when a new room or
+// meeting's room is no longer free; equipment that is short is returned,
+// again without changing anything.
+func (r *meetingRepository) CreateWithRelocations(meeting *models.Meeting, relocations []models.MeetingRelocation, equipment []models.EquipmentRequest) (bool, []models.EquipmentAvailability, error) {
+	free := true
+	var shortages []models.EquipmentAvailability
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, relocation := range relocations {
+			bumped := *relocation.Meeting
+			available, err := isRoomAvailable(tx, relocation.NewRoom.ID, bumped.StartTime, bumped.EndTime, bumped.BookingBuffers, []uint{bumped.ID})
+			if err != nil {
+				return err
+			}
+			if !available {
+				free = false
+				return errRelocationConflict
+			}
+
+			bumped.RoomID = relocation.NewRoom.ID
+			if err := updateRoomAssignment(tx, &bumped); err != nil {
+				return err
+			}
+		}
+
+		available, err := isRoomAvailable(tx, meeting.RoomID, meeting.StartTime, meeting.EndTime, meeting.BookingBuffers, nil)
+		if err != nil {
+			return err
+		}
+		if !available {
+			free = false
+			return errRelocationConflict
+		}
+
+		if err := tx.Create(meeting).Error; err != nil {
+			return err
+		}
+
+		if len(equipment) > 0 {
+			if shortages, err = reserveEquipment(tx, meeting.ID, equipment, meeting.StartTime, meeting.EndTime); err != nil {
+				return err
+			}
+			if len(shortages) > 0 {
+				return errRelocationConflict
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, errRelocationConflict) {
+		meeting.ID = 0
+		return free, shortages, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+
+	for _, relocation := range relocations {
+		relocation.Meeting.RoomID = relocation.NewRoom.ID
+	}
+	return true, nil, nil
+}
+
+// CountBookingsByRoom counts, per room, the meetings between from and to that
+// any of userIDs organized or attended. Cancelled and rejected bookings are
+// left out.
+func (r *meetingRepository) CountBookingsByRoom(userIDs []uint, from, to time.Time) (map[uint]int64, error) {
+	var rows []struct {
+		RoomID   uint
+		Bookings int64
+	}
+
+	if err := r.db.Model(&models.Meeting{}).
+		Select("room_id, COUNT(*) AS bookings").
+		Where("start_time >= ? AND start_time < ?", from, to).
+		Where("status NOT IN ?", []models.MeetingStatus{models.StatusCancelled, models.StatusRejected}).
+		Where("organizer_id IN ? OR id IN (?)", userIDs,
+			r.db.Table("meeting_attendees").Select("meeting_id").Where("user_id IN ?", userIDs)).
+		Group("room_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.RoomID] = row.Bookings
+	}
+	return counts, nil
+}
+
+// SetAttendeeGroups replaces the groups invited to the meeting.
+func (r *meetingRepository) SetAttendeeGroups(meetingID uint, groupIDs []uint) error {
+	groups := make([]models.UserGroup, len(groupIDs))
+	for i, id := range groupIDs {
+		groups[i] = models.UserGroup{ID: id}
+	}
+	return r.db.Model(&models.Meeting{ID: meetingID}).Association("AttendeeGroups").Replace(groups)
+}
+
+// GetActiveByAttendeeGroup returns the meetings the group is invited to that
+// end after from and still hold their room.
+func (r *meetingRepository) GetActiveByAttendeeGroup(groupID uint, from time.Time) ([]*models.Meeting, error) {
+	var meetings []*models.Meeting
+	if err := r.db.Preload("Organizer").Preload("Room").Preload("Room.Features").Preload("Attendees").
+		Joins("JOIN meeting_attendee_groups ON meeting_attendee_groups.meeting_id = meetings.id").
+		Where("meeting_attendee_groups.user_group_id = ? AND meetings.end_time > ? AND meetings.status IN ?", groupID, from, models.SlotHoldingStatuses).
+		Find(&meetings).Error; err != nil {
+		return nil, err
+	}
+	return meetings, nil
+}
+
+// GetAttendeeConflicts lists the meetings holding their slot between
+// startTime and endTime that any of userIDs organizes or attends.
+func (r *meetingRepository) GetAttendeeConflicts(userIDs []uint, startTime, endTime time.Time, excludeMeetingID *uint) ([]models.AttendeeConflict, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	overlapping := func(query *gorm.DB) *gorm.DB {
+		query = query.Where("meetings.status IN ? AND meetings.start_time < ? AND meetings.end_time > ?",
+			models.SlotHoldingStatuses, endTime, startTime)
+		if excludeMeetingID != nil {
+			query = query.Where("meetings.id != ?", *excludeMeetingID)
+		}
+		return query
+	}
+
+	var organizing []models.AttendeeConflict
+	if err := overlapping(r.db.Model(&models.Meeting{})).
+		Select("meetings.organizer_id AS user_id, meetings.id AS meeting_id, meetings.start_time, meetings.end_time").
+		Where("meetings.organizer_id IN ?", userIDs).
+		Scan(&organizing).Error; err != nil {
+		return nil, err
+	}
+
+	var attending []models.AttendeeConflict
+	if err := overlapping(r.db.Table("meeting_attendees").
+		Joins("JOIN meetings ON meetings.id = meeting_attendees.meeting_id")).
+		Select("meeting_attendees.user_id, meetings.id AS meeting_id, meetings.start_time, meetings.end_time").
+		Where("meeting_attendees.user_id IN ?", userIDs).
+		Scan(&attending).Error; err != nil {
+		return nil, err
+	}
+
+	return append(organizing, attending...), nil
+}
+
+func (r *meetingRepository) GetGroupAttendees(meetingID uint) ([]*models.MeetingGroupAttendee, error) {
+	var records []*models.MeetingGroupAttendee
+	if err := r.db.Where("meeting_id = ?", meetingID).Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// SetGroupAttendees replaces the record of which attendees of the meeting
+// came with which group.
+func (r *meetingRepository) SetGroupAttendees(meetingID uint, records []*models.MeetingGroupAttendee) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("meeting_id = ?", meetingID).Delete(&models.MeetingGroupAttendee{}).Error; err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+		return tx.Create(records).Error
+	})
+}
+
+func (r *meetingRepository) AddGroupAttendee(record *models.MeetingGroupAttendee) error {
+	return r.db.Exec("INSERT IGNORE INTO meeting_group_attendees (meeting_id, user_id, group_id) VALUES (?, ?, ?)",
+		record.MeetingID, record.UserID, record.GroupID).Error
+}
+
+func (r *meetingRepository) RemoveGroupAttendee(meetingID, userID, groupID uint) error {
+	return r.db.Where("meeting_id = ? AND user_id = ? AND group_id = ?", meetingID, userID, groupID).
+		Delete(&models.MeetingGroupAttendee{}).Error
+}
+
+// RemoveGroupAttendees forgets through which groups userID attends the
+// meeting, as when they no longer attend it.
+func (r *meetingRepository) RemoveGroupAttendees(meetingID, userID uint) error {
+	return r.db.Where("meeting_id = ? AND user_id = ?", meetingID, userID).
+		Delete(&models.MeetingGroupAttendee{}).Error
 }
\ No newline at end of file
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PasswordResetRepository interface {
+	Create(token *models.PasswordResetToken) (*models.PasswordResetToken, error)
+	GetByTokenHash(tokenHash string) (*models.PasswordResetToken, error)
+	InvalidateForUser(userID uint) error
+	Redeem(id, userID uint, passwordHash string) (bool, error)
+}
+
+type passwordResetRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepository{db: db}
+}
+
+func (r *passwordResetRepository) Create(token *models.PasswordResetToken) (*models.PasswordResetToken, error) {
+	if err := r.db.Create(token).Error; err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (r *passwordResetRepository) GetByTokenHash(tokenHash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	if err := r.db.Preload("User").Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *passwordResetRepository) InvalidateForUser(userID uint) error {
+	return r.db.Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).Error
+}
+
+// Redeem marks the token used and sets the user's password in one
+// transaction. It reports false, changing nothing, when the token was used
+// in the meantime.
+func (r *passwordResetRepository) Redeem(id, userID uint, passwordHash string) (bool, error) {
+	redeemed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.PasswordResetToken{}).
+			Where("id = ? AND used_at IS NULL", id).
+			Update("used_at", time.Now())
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).Update("password_hash", passwordHash).Error; err != nil {
+			return err
+		}
+		redeemed = true
+		return nil
+	})
+	return redeemed, err
+}
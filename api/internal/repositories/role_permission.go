@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RolePermissionRepository interface {
+	GetAll() ([]*models.RolePermission, error)
+	ReplaceForRole(role models.UserRole, permissions []models.Permission) error
+	GetAppliedDefaults() ([]*models.AppliedDefaultPermission, error)
+	ApplyDefaults(role models.UserRole, permissions []models.Permission) error
+}
+
+type rolePermissionRepository struct {
+	db *gorm.DB
+}
+
+func NewRolePermissionRepository(db *gorm.DB) RolePermissionRepository {
+	return &rolePermissionRepository{db: db}
+}
+
+func (r *rolePermissionRepository) GetAll() ([]*models.RolePermission, error) {
+	var rolePermissions []*models.RolePermission
+	if err := r.db.Order("role, permission").Find(&rolePermissions).Error; err != nil {
+		return nil, err
+	}
+	return rolePermissions, nil
+}
+
+func (r *rolePermissionRepository) ReplaceForRole(role models.UserRole, permissions []models.Permission) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role = ?", role).Delete(&models.RolePermission{}).Error; err != nil {
+			return err
+		}
+
+		if len(permissions) == 0 {
+			return nil
+		}
+
+		rows := make([]models.RolePermission, len(permissions))
+		for i, permission := range permissions {
+			rows[i] = models.RolePermission{Role: role, Permission: permission}
+		}
+		return tx.Create(&rows).Error
+	})
+}
+
+func (r *rolePermissionRepository) GetAppliedDefaults() ([]*models.AppliedDefaultPermission, error) {
+	var applied []*models.AppliedDefaultPermission
+	if err := r.db.Find(&applied).Error; err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+// ApplyDefaults grants permissions to role and records them as applied.
+func (r *rolePermissionRepository) ApplyDefaults(role models.UserRole, permissions []models.Permission) error {
+	if len(permissions) == 0 {
+		return nil
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		rows := make([]models.RolePermission, len(permissions))
+		for i, permission := range permissions {
+			rows[i] = models.RolePermission{Role: role, Permission: permission}
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+			return err
+		}
+		return markDefaultsApplied(tx, role, permissions)
+	})
+}
+
+func markDefaultsApplied(db *gorm.DB, role models.UserRole, permissions []models.Permission) error {
+	if len(permissions) == 0 {
+		return nil
+	}
+
+	rows := make([]models.AppliedDefaultPermission, len(permissions))
+	for i, permission := range permissions {
+		rows[i] = models.AppliedDefaultPermission{Role: role, Permission: permission}
+	}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
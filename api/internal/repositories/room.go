@@ -5,24 +5,32 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type RoomRepository interface {
 	Create(room *models.Room) (*models.Room, error)
 	GetByID(id uint) (*models.Room, error)
-	GetAll(offset, limit int) ([]*models.Room, int64, error)
+	GetAll(location models.LocationFilter, offset, limit int) ([]*models.Room, int64, error)
 	Update(room *models.Room) (*models.Room, error)
 	Delete(id uint) error
 	GetActiveRooms() ([]*models.Room, error)
-	SearchRooms(query string, offset, limit int) ([]*models.Room, int64, error)
-	GetAvailableRooms(startTime, endTime time.Time, capacity *int) ([]*models.Room, error)
-	IsRoomAvailable(roomID uint, startTime, endTime time.Time, excludeMeetingID *uint) (bool, error)
+	SearchRooms(query string, filter models.RoomFilter, offset, limit int) ([]*models.Room, int64, error)
+	GetAvailableRooms(query *models.RoomAvailabilityQuery) ([]*models.Room, error)
+	IsRoomAvailable(roomID uint, startTime, endTime time.Time, buffers models.BookingBuffers, excludeMeetingID *uint) (bool, error)
+	IsRoomAvailableWithout(roomID uint, startTime, endTime time.Time, buffers models.BookingBuffers, meetingIDs []uint) (bool, error)
+	UpdateAccessControl(roomID uint, acl *models.RoomAccessControl) error
+	UpdateApproval(roomID uint, settings *models.RoomApprovalSettings) error
+	UpdateComponents(roomID uint, componentIDs []uint) error
+	GetCombinedRoomIDs(componentID uint) ([]uint, error)
+	RefreshCombinedRoom(roomID uint) error
 }
 
 type RoomFeatureRepository interface {
 	Create(feature *models.RoomFeature) (*models.RoomFeature, error)
 	GetByID(id uint) (*models.RoomFeature, error)
 	GetAll() ([]*models.RoomFeature, error)
+	GetByNames(names []string) ([]*models.RoomFeature, error)
 	Update(feature *models.RoomFeature) (*models.RoomFeature, error)
 	Delete(id uint) error
 }
@@ -52,21 +60,29 @@ func (r *roomRepository) Create(room *models.Room) (*models.Room, error) {
 
 func (r *roomRepository) GetByID(id uint) (*models.Room, error) {
 	var room models.Room
-	if err := r.db.Preload("Features").Preload("Meetings").First(&room, id).Error; err != nil {
+	if err := r.db.Preload("Features").Preload("Meetings").Preload("Floor.Building.Site").Preload("Components").
+		Preload("AllowedUsers").Preload("AllowedGroups").Preload("AllowedRoles").Preload("Approvers").
+		First(&room, id).Error; err != nil {
 		return nil, err
 	}
 	return &room, nil
 }
 
-func (r *roomRepository) GetAll(offset, limit int) ([]*models.Room, int64, error) {
+func (r *roomRepository) GetAll(location models.LocationFilter, offset, limit int) ([]*models.Room, int64, error) {
 	var rooms []*models.Room
 	var total int64
 
-	if err := r.db.Model(&models.Room{}).Count(&total).Error; err != nil {
+	query := r.db.Model(&models.Room{}).Where("space_type = ?", models.SpaceTypeRoom)
+	if !location.IsEmpty() {
+		query = query.Where("id IN (?)", roomsInLocation(r.db, location))
+	}
+
+	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	if err := r.db.Preload("Features").Offset(offset).Limit(limit).Find(&rooms).Error; err != nil {
+	if err := query.Preload("Features").Preload("Floor.Building.Site").Preload("Components").
+		Offset(offset).Limit(limit).Find(&rooms).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -74,7 +90,7 @@ func (r *roomRepository) GetAll(offset, limit int) ([]*models.Room, int64, error
 }
 
 func (r *roomRepository) Update(room *models.Room) (*models.Room, error) {
-	if err := r.db.Save(room).Error; err != nil {
+	if err := r.db.Omit("Floor", "Components").Save(room).Error; err != nil {
 		return nil, err
 	}
 	return r.GetByID(room.ID)
@@ -86,68 +102,424 @@ func (r *roomRepository) Delete(id uint) error {
 
 func (r *roomRepository) GetActiveRooms() ([]*models.Room, error) {
 	var rooms []*models.Room
-	if err := r.db.Preload("Features").Where("is_active = ?", true).Find(&rooms).Error; err != nil {
+	if err := r.db.Preload("Features").Where("is_active = ? AND space_type = ?", true, models.SpaceTypeRoom).Find(&rooms).Error; err != nil {
 		return nil, err
 	}
 	return rooms, nil
 }
 
-func (r *roomRepository) SearchRooms(query string, offset, limit int) ([]*models.Room, int64, error) {
+// SearchRooms matches query against name, description and location and
+// narrows the result with filter. An empty query matches every room. Results
+// are ranked by fit.
+func (r *roomRepository) SearchRooms(query string, filter models.RoomFilter, offset, limit int) ([]*models.Room, int64, error) {
 	var rooms []*models.Room
 	var total int64
 
-	searchQuery := "%" + query + "%"
-	dbQuery := r.db.Model(&models.Room{}).Where(
-		"name LIKE ? OR description LIKE ? OR location LIKE ?",
-		searchQuery, searchQuery, searchQuery,
-	)
+	dbQuery := r.filtered(r.db.Model(&models.Room{}), filter)
+	if query != "" {
+		searchQuery := "%" + query + "%"
+		dbQuery = dbQuery.Where(
+			"name LIKE ? OR description LIKE ? OR location LIKE ?",
+			searchQuery, searchQuery, searchQuery,
+		)
+	}
 
 	if err := dbQuery.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	if err := dbQuery.Preload("Features").Offset(offset).Limit(limit).Find(&rooms).Error; err != nil {
+	if err := orderByFit(dbQuery, filter).Preload("Features").Preload("Floor.Building.Site").Preload("Components").
+		Offset(offset).Limit(limit).Find(&rooms).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return rooms, total, nil
 }
 
-func (r *roomRepository) GetAvailableRooms(startTime, endTime time.Time, capacity *int) ([]*models.Room, error) {
-	query := r.db.Where("is_active = ?", true)
-	
-	if capacity != nil {
-		query = query.Where("capacity >= ?", *capacity)
+func (r *roomRepository) GetAvailableRooms(availability *models.RoomAvailabilityQuery) ([]*models.Room, error) {
+	query := r.filtered(r.db.Where("is_active = ?", true), availability.RoomFilter)
+
+	if booker := availability.Booker; booker != nil && !booker.BypassRestrictions {
+		query = query.Where(r.bookableBy(booker))
 	}
 
-	query = query.Where("id NOT IN (?)", r.db.Model(&models.Meeting{}).
-		Select("room_id").
-		Where("start_time < ? AND end_time > ? AND status IN ?", 
-			endTime, startTime, []models.MeetingStatus{models.StatusScheduled, models.StatusInProgress}))
+	query = query.Where("id NOT IN (?)", blockingDeskBookings(r.db, availability.StartTime, availability.EndTime).
+		Select("desk_id"))
 
 	var rooms []*models.Room
-	if err := query.Preload("Features").Find(&rooms).Error; err != nil {
+	if err := orderByFit(query, availability.RoomFilter).Preload("Features").Preload("Floor.Building.Site").
+		Preload("Components").Find(&rooms).Error; err != nil {
 		return nil, err
 	}
 
-	return rooms, nil
-}
+	// Each room widens the booking by its own default buffers, so meetings
+	// and blackouts are loaded for the widest of them and matched per room.
+	var widestSetup, widestCleanup time.Duration
+	for _, room := range rooms {
+		setup, cleanup := availability.Buffers.Resolve(room)
+		widestSetup, widestCleanup = max(widestSetup, setup), max(widestCleanup, cleanup)
+	}
+	fromTime, toTime := availability.StartTime.Add(-widestSetup), availability.EndTime.Add(widestCleanup)
+
+	var occupying []*models.Meeting
+	if err := blockingMeetings(r.db, fromTime, toTime).Preload("Room.Components").Find(&occupying).Error; err != nil {
+		return nil, err
+	}
+
+	var blackouts []*models.RoomBlackout
+	if err := candidateBlackouts(r.db, fromTime, toTime).Preload("Room.Components").Find(&blackouts).Error; err != nil {
+		return nil, err
+	}
+
+	available := rooms[:0]
+	for _, room := range rooms {
+		setup, cleanup := availability.Buffers.Resolve(room)
+		startTime, endTime := availability.StartTime.Add(-setup), availability.EndTime.Add(cleanup)
+		if takesOccupiedSpace(room, occupying, startTime, endTime) || hasInactiveComponent(room) {
+			continue
+		}
+		if !isBlackedOut(room, blackouts, startTime, endTime) {
+			available = append(available, room)
+		}
+	}
 
-func (r *roomRepository) IsRoomAvailable(roomID uint, startTime, endTime time.Time, excludeMeetingID *uint) (bool, error) {
-	query := r.db.Model(&models.Meeting{}).
-		Where("room_id = ? AND start_time < ? AND end_time > ? AND status IN ?", 
-			roomID, endTime, startTime, []models.MeetingStatus{models.StatusScheduled, models.StatusInProgress})
+	return available, nil
+}
 
+// IsRoomAvailable reports whether roomID is free from startTime to endTime.
+// Combined rooms are taken when any of their components is, and components
+// when a combined room they are part of is. Desks are also taken by desk
+// bookings.
+func (r *roomRepository) IsRoomAvailable(roomID uint, startTime, endTime time.Time, buffers models.BookingBuffers, excludeMeetingID *uint) (bool, error) {
+	var excluded []uint
 	if excludeMeetingID != nil {
-		query = query.Where("id != ?", *excludeMeetingID)
+		excluded = []uint{*excludeMeetingID}
+	}
+	return isRoomAvailable(r.db, roomID, startTime, endTime, buffers, excluded)
+}
+
+// IsRoomAvailableWithout reports whether roomID would be free from startTime
+// to endTime if meetingIDs were moved elsewhere.
+func (r *roomRepository) IsRoomAvailableWithout(roomID uint, startTime, endTime time.Time, buffers models.BookingBuffers, meetingIDs []uint) (bool, error) {
+	return isRoomAvailable(r.db, roomID, startTime, endTime, buffers, meetingIDs)
+}
+
+// isRoomAvailable is IsRoomAvailable on db, so it can run inside a
+// transaction, leaving out the meetings excludeMeetingIDs.
+func isRoomAvailable(db *gorm.DB, roomID uint, startTime, endTime time.Time, buffers models.BookingBuffers, excludeMeetingIDs []uint) (bool, error) {
+	var inactive int64
+	if err := db.Model(&models.Room{}).
+		Where("is_active = ? AND id IN (?)", false, componentsOf(db, roomID)).
+		Count(&inactive).Error; err != nil {
+		return false, err
+	}
+	if inactive > 0 {
+		return false, nil
+	}
+
+	linked, err := roomsSharingSpace(db, roomID)
+	if err != nil {
+		return false, err
+	}
+
+	blockedStart, blockedEnd, err := blockedInterval(db, roomID, startTime, endTime, buffers)
+	if err != nil {
+		return false, err
+	}
+
+	query := blockingMeetings(db, blockedStart, blockedEnd).Where("meetings.room_id IN ?", linked)
+
+	if len(excludeMeetingIDs) > 0 {
+		query = query.Where("meetings.id NOT IN ?", excludeMeetingIDs)
 	}
 
 	var count int64
 	if err := query.Count(&count).Error; err != nil {
 		return false, err
 	}
+	if count > 0 {
+		return false, nil
+	}
+
+	if err := blockingDeskBookings(db, startTime, endTime).Where("desk_id = ?", roomID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return false, nil
+	}
+
+	var blackouts []*models.RoomBlackout
+	if err := candidateBlackouts(db, blockedStart, blockedEnd).Where(blackoutsForRoom(db, roomID)).
+		Find(&blackouts).Error; err != nil {
+		return false, err
+	}
+
+	for _, blackout := range blackouts {
+		if blackout.Blocks(blockedStart, blockedEnd) {
+			return false, nil
+		}
+	}
 
-	return count == 0, nil
+	return true, nil
+}
+
+// blockedInterval returns the time a booking of roomID from startTime to
+// endTime keeps the room, widened by buffers or, where they are not set, by
+// the room's defaults.
+func blockedInterval(db *gorm.DB, roomID uint, startTime, endTime time.Time, buffers models.BookingBuffers) (time.Time, time.Time, error) {
+	var room models.Room
+	if err := db.Select("id", "setup_minutes", "cleanup_minutes").Limit(1).Find(&room, roomID).Error; err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	setup, cleanup := buffers.Resolve(&room)
+	return startTime.Add(-setup), endTime.Add(cleanup), nil
+}
+
+// blockingMeetings selects the slot-holding meetings whose interval, widened
+// by their setup and cleanup buffers, overlaps blockedStart to blockedEnd.
+// Buffers that are not set fall back to the defaults of the meeting's room.
+func blockingMeetings(db *gorm.DB, blockedStart, blockedEnd time.Time) *gorm.DB {
+	return db.Model(&models.Meeting{}).
+		Joins("JOIN rooms ON rooms.id = meetings.room_id").
+		Where("meetings.status IN ?", models.SlotHoldingStatuses).
+		Where("DATE_SUB(meetings.start_time, INTERVAL COALESCE(meetings.setup_minutes, rooms.setup_minutes) MINUTE) < ?", blockedEnd).
+		Where("DATE_ADD(meetings.end_time, INTERVAL COALESCE(meetings.cleanup_minutes, rooms.cleanup_minutes) MINUTE) > ?", blockedStart)
+}
+
+// blockingDeskBookings selects the desk bookings that hold their desk at some
+// point from startTime to endTime.
+func blockingDeskBookings(db *gorm.DB, startTime, endTime time.Time) *gorm.DB {
+	return db.Model(&models.DeskBooking{}).
+		Where("status IN ? AND start_time < ? AND end_time > ?", models.DeskHoldingStatuses, endTime, startTime)
+}
+
+// filtered applies the space type, capacity, location, feature and
+// accessibility conditions of filter to query.
+func (r *roomRepository) filtered(query *gorm.DB, filter models.RoomFilter) *gorm.DB {
+	spaceType := filter.SpaceType
+	if spaceType == "" {
+		spaceType = models.SpaceTypeRoom
+	}
+	query = query.Where("space_type = ?", spaceType)
+
+	if filter.Capacity != nil {
+		query = query.Where("capacity >= ?", *filter.Capacity)
+	}
+
+	if !filter.Location.IsEmpty() {
+		query = query.Where("id IN (?)", roomsInLocation(r.db, filter.Location))
+	}
+	if filter.Zone != "" {
+		query = query.Where("zone = ?", filter.Zone)
+	}
+
+	if len(filter.FeatureIDs) > 0 {
+		withFeatures := r.db.Table("room_room_features").Select("room_id").
+			Where("room_feature_id IN ?", filter.FeatureIDs)
+		if !filter.AnyFeature {
+			withFeatures = withFeatures.Group("room_id").
+				Having("COUNT(DISTINCT room_feature_id) = ?", len(filter.FeatureIDs))
+		}
+		query = query.Where("id IN (?)", withFeatures)
+	}
+
+	if filter.WheelchairAccessible {
+		query = query.Where("wheelchair_accessible = ?", true)
+	}
+	if filter.HearingLoop {
+		query = query.Where("hearing_loop = ?", true)
+	}
+
+	return query
+}
+
+// orderByFit ranks the smallest rooms first and, among rooms of the same
+// size, those with the fewest features that were not asked for.
+func orderByFit(query *gorm.DB, filter models.RoomFilter) *gorm.DB {
+	// A single expression, since gorm drops plain columns from an ORDER BY
+	// that has one.
+	order := clause.Expr{SQL: "capacity ASC, (SELECT COUNT(*) FROM room_room_features WHERE room_room_features.room_id = rooms.id) ASC, id ASC"}
+	if len(filter.FeatureIDs) > 0 {
+		order = clause.Expr{
+			SQL:  "capacity ASC, (SELECT COUNT(*) FROM room_room_features WHERE room_room_features.room_id = rooms.id AND room_feature_id NOT IN ?) ASC, id ASC",
+			Vars: []interface{}{filter.FeatureIDs},
+		}
+	}
+
+	return query.Order(clause.OrderBy{Expression: order})
+}
+
+// componentsOf selects the IDs of the components of roomID.
+func componentsOf(db *gorm.DB, roomID uint) *gorm.DB {
+	return db.Table("room_components").Select("component_id").Where("composite_id = ?", roomID)
+}
+
+// roomsSharingSpace returns roomID and every room whose space overlaps it:
+// its components, the combined rooms it is part of and the combined rooms
+// that share a component with it.
+func roomsSharingSpace(db *gorm.DB, roomID uint) ([]uint, error) {
+	var spaces []uint
+	if err := componentsOf(db, roomID).Pluck("component_id", &spaces).Error; err != nil {
+		return nil, err
+	}
+	spaces = append(spaces, roomID)
+
+	var combined []uint
+	if err := db.Table("room_components").Where("component_id IN ?", spaces).
+		Pluck("composite_id", &combined).Error; err != nil {
+		return nil, err
+	}
+
+	return append(spaces, combined...), nil
+}
+
+// takesOccupiedSpace reports whether room shares space with one of the
+// meetings whose blocked interval overlaps startTime to endTime. The rooms of
+// the meetings must have their components loaded.
+func takesOccupiedSpace(room *models.Room, meetings []*models.Meeting, startTime, endTime time.Time) bool {
+	for _, meeting := range meetings {
+		blockedStart, blockedEnd := meeting.BlockedInterval(&meeting.Room)
+		if blockedStart.Before(endTime) && blockedEnd.After(startTime) && room.SharesSpaceWith(&meeting.Room) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasInactiveComponent(room *models.Room) bool {
+	for _, component := range room.Components {
+		if !component.IsActive {
+			return true
+		}
+	}
+	return false
+}
+
+func isBlackedOut(room *models.Room, blackouts []*models.RoomBlackout, startTime, endTime time.Time) bool {
+	for _, blackout := range blackouts {
+		if blackout.AppliesTo(room) && blackout.Blocks(startTime, endTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// bookableBy matches unrestricted rooms and restricted rooms whose ACL admits
+// the booker by user, role or group membership.
+func (r *roomRepository) bookableBy(booker *models.RoomBooker) *gorm.DB {
+	condition := r.db.Where("is_restricted = ?", false).
+		Or("id IN (?)", r.db.Table("room_allowed_users").Select("room_id").Where("user_id = ?", booker.UserID)).
+		Or("id IN (?)", r.db.Model(&models.RoomAllowedRole{}).Select("room_id").Where("role = ?", booker.Role))
+
+	if len(booker.GroupIDs) > 0 {
+		condition = condition.Or("id IN (?)", r.db.Table("room_allowed_groups").
+			Select("room_id").Where("user_group_id IN ?", booker.GroupIDs))
+	}
+
+	return condition
+}
+
+func (r *roomRepository) UpdateAccessControl(roomID uint, acl *models.RoomAccessControl) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		room := &models.Room{ID: roomID}
+		if err := tx.Model(room).Update("is_restricted", acl.IsRestricted).Error; err != nil {
+			return err
+		}
+
+		users := make([]models.User, len(acl.AllowedUserIDs))
+		for i, id := range acl.AllowedUserIDs {
+			users[i] = models.User{ID: id}
+		}
+		if err := tx.Model(room).Association("AllowedUsers").Replace(users); err != nil {
+			return err
+		}
+
+		groups := make([]models.UserGroup, len(acl.AllowedGroupIDs))
+		for i, id := range acl.AllowedGroupIDs {
+			groups[i] = models.UserGroup{ID: id}
+		}
+		if err := tx.Model(room).Association("AllowedGroups").Replace(groups); err != nil {
+			return err
+		}
+
+		if err := tx.Where("room_id = ?", roomID).Delete(&models.RoomAllowedRole{}).Error; err != nil {
+			return err
+		}
+		if len(acl.AllowedRoles) == 0 {
+			return nil
+		}
+
+		roles := make([]models.RoomAllowedRole, len(acl.AllowedRoles))
+		for i, role := range acl.AllowedRoles {
+			roles[i] = models.RoomAllowedRole{RoomID: roomID, Role: role}
+		}
+		return tx.Create(&roles).Error
+	})
+}
+
+func (r *roomRepository) UpdateApproval(roomID uint, settings *models.RoomApprovalSettings) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		room := &models.Room{ID: roomID}
+		if err := tx.Model(room).Update("requires_approval", settings.RequiresApproval).Error; err != nil {
+			return err
+		}
+
+		approvers := make([]models.User, len(settings.ApproverIDs))
+		for i, id := range settings.ApproverIDs {
+			approvers[i] = models.User{ID: id}
+		}
+		return tx.Model(room).Association("Approvers").Replace(approvers)
+	})
+}
+
+func (r *roomRepository) UpdateComponents(roomID uint, componentIDs []uint) error {
+	components := make([]models.Room, len(componentIDs))
+	for i, id := range componentIDs {
+		components[i] = models.Room{ID: id}
+	}
+	return r.db.Model(&models.Room{ID: roomID}).Association("Components").Replace(components)
+}
+
+// GetCombinedRoomIDs returns the combined rooms componentID is part of.
+func (r *roomRepository) GetCombinedRoomIDs(componentID uint) ([]uint, error) {
+	var ids []uint
+	if err := r.db.Table("room_components").Where("component_id = ?", componentID).
+		Pluck("composite_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// RefreshCombinedRoom derives a combined room's capacity and features from
+// its components: capacities add up and features are merged.
+func (r *roomRepository) RefreshCombinedRoom(roomID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var components []models.Room
+		if err := tx.Preload("Features").Where("id IN (?)", componentsOf(tx, roomID)).
+			Find(&components).Error; err != nil {
+			return err
+		}
+
+		capacity := 0
+		features := []models.RoomFeature{}
+		seen := make(map[uint]bool)
+		for _, component := range components {
+			capacity += component.Capacity
+			for _, feature := range component.Features {
+				if !seen[feature.ID] {
+					seen[feature.ID] = true
+					features = append(features, feature)
+				}
+			}
+		}
+
+		room := &models.Room{ID: roomID}
+		if err := tx.Model(room).Update("capacity", capacity).Error; err != nil {
+			return err
+		}
+		return tx.Model(room).Association("Features").Replace(features)
+	})
 }
 
 func (r *roomFeatureRepository) Create(feature *models.RoomFeature) (*models.RoomFeature, error) {
@@ -173,6 +545,14 @@ func (r *roomFeatureRepository) GetAll() ([]*models.RoomFeature, error) {
 	return features, nil
 }
 
+func (r *roomFeatureRepository) GetByNames(names []string) ([]*models.RoomFeature, error) {
+	var features []*models.RoomFeature
+	if err := r.db.Where("name IN ?", names).Find(&features).Error; err != nil {
+		return nil, err
+	}
+	return features, nil
+}
+
 func (r *roomFeatureRepository) Update(feature *models.RoomFeature) (*models.RoomFeature, error) {
 	if err := r.db.Save(feature).Error; err != nil {
 		return nil, err
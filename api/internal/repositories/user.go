@@ -9,6 +9,7 @@ import (
 type UserRepository interface {
 	Create(user *models.User) (*models.User, error)
 	GetByID(id uint) (*models.User, error)
+	GetByIDs(ids []uint) ([]*models.User, error)
 	GetByEmail(email string) (*models.User, error)
 	GetByMicrosoftID(microsoftID string) (*models.User, error)
 	GetAll(offset, limit int) ([]*models.User, int64, error)
@@ -16,6 +17,7 @@ type UserRepository interface {
 	Delete(id uint) error
 	GetActiveUsers() ([]*models.User, error)
 	SearchUsers(query string, offset, limit int) ([]*models.User, int64, error)
+	GetServiceAccounts() ([]*models.User, error)
 }
 
 type userRepository struct {
@@ -41,6 +43,14 @@ func (r *userRepository) GetByID(id uint) (*models.User, error) {
 	return &user, nil
 }
 
+func (r *userRepository) GetByIDs(ids []uint) ([]*models.User, error) {
+	var users []*models.User
+	if err := r.db.Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 func (r *userRepository) GetByEmail(email string) (*models.User, error) {
 	var user models.User
 	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
@@ -110,4 +120,12 @@ func (r *userRepository) SearchUsers(query string, offset, limit int) ([]*models
 	}
 
 	return users, total, nil
+}
+
+func (r *userRepository) GetServiceAccounts() ([]*models.User, error) {
+	var users []*models.User
+	if err := r.db.Where("is_service_account = ?", true).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
 }
\ No newline at end of file
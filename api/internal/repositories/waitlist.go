@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"api/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WaitlistRepository interface {
+	Create(entry *models.WaitlistEntry) (*models.WaitlistEntry, error)
+	GetByID(id uint) (*models.WaitlistEntry, error)
+	GetByUser(userID uint, offset, limit int) ([]*models.WaitlistEntry, int64, error)
+	Update(entry *models.WaitlistEntry) error
+	GetWaitingForSlot(roomID uint, startTime, endTime, now time.Time) ([]*models.WaitlistEntry, error)
+	GetLatestWaitingEnd(roomID uint, now time.Time) (*time.Time, error)
+	CountAhead(entry *models.WaitlistEntry) (int64, error)
+	GetExpired(now time.Time) ([]*models.WaitlistEntry, error)
+}
+
+type waitlistRepository struct {
+	db *gorm.DB
+}
+
+func NewWaitlistRepository(db *gorm.DB) WaitlistRepository {
+	return &waitlistRepository{db: db}
+}
+
+func (r *waitlistRepository) Create(entry *models.WaitlistEntry) (*models.WaitlistEntry, error) {
+	if err := r.db.Create(entry).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(entry.ID)
+}
+
+func (r *waitlistRepository) GetByID(id uint) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	if err := r.db.Preload("Room").Preload("User").First(&entry, id).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetByUser lists the user's entries, the soonest slot first.
+func (r *waitlistRepository) GetByUser(userID uint, offset, limit int) ([]*models.WaitlistEntry, int64, error) {
+	query := r.db.Model(&models.WaitlistEntry{}).Where("user_id = ?", userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var entries []*models.WaitlistEntry
+	if err := query.Preload("Room").Offset(offset).Limit(limit).Order("start_time ASC").Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+func (r *waitlistRepository) Update(entry *models.WaitlistEntry) error {
+	return r.db.Omit("Room", "User").Save(entry).Error
+}
+
+// GetWaitingForSlot returns the entries still waiting for roomID whose slot
+// overlaps [startTime, endTime) and starts after now, first come first.
+func (r *waitlistRepository) GetWaitingForSlot(roomID uint, startTime, endTime, now time.Time) ([]*models.WaitlistEntry, error) {
+	var entries []*models.WaitlistEntry
+	if err := r.db.Preload("Room").Preload("User").
+		Where("room_id = ? AND status = ? AND start_time > ?", roomID, models.WaitlistWaiting, now).
+		Where("start_time < ? AND end_time > ?", endTime, startTime).
+		Order("created_at ASC, id ASC").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetLatestWaitingEnd returns when the last slot still waited for in roomID
+// ends, or nil when nobody is waiting for a slot after now.
+func (r *waitlistRepository) GetLatestWaitingEnd(roomID uint, now time.Time) (*time.Time, error) {
+	var latest struct {
+		EndTime *time.Time
+	}
+	if err := r.db.Model(&models.WaitlistEntry{}).Select("MAX(end_time) AS end_time").
+		Where("room_id = ? AND status = ? AND start_time > ?", roomID, models.WaitlistWaiting, now).
+		Scan(&latest).Error; err != nil {
+		return nil, err
+	}
+	return latest.EndTime, nil
+}
+
+// CountAhead counts the pending entries for the same room that overlap entry
+// and joined before it.
+func (r *waitlistRepository) CountAhead(entry *models.WaitlistEntry) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.WaitlistEntry{}).
+		Where("room_id = ? AND status IN ? AND id < ?", entry.RoomID, models.WaitlistPendingStatuses, entry.ID).
+		Where("start_time < ? AND end_time > ?", entry.EndTime, entry.StartTime).
+		Count(&count).Error
+	return count, err
+}
+
+// GetExpired returns the offers that ran out and the pending entries whose
+// slot has started.
+func (r *waitlistRepository) GetExpired(now time.Time) ([]*models.WaitlistEntry, error) {
+	var entries []*models.WaitlistEntry
+	if err := r.db.Preload("Room").Preload("User").
+		Where(r.db.Where("status = ? AND offer_expires_at <= ?", models.WaitlistOffered, now).
+			Or("status IN ? AND start_time <= ?", models.WaitlistPendingStatuses, now)).
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"api/internal/models"
+	"api/internal/repositories"
+	"api/internal/utils"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// lastUsedResolution limits how often a busy token writes its last-used
+// timestamp back to the database.
+const lastUsedResolution = time.Minute
+
+type APITokenService struct {
+	tokenRepo repositories.APITokenRepository
+	userRepo  repositories.UserRepository
+}
+
+func NewAPITokenService(tokenRepo repositories.APITokenRepository, userRepo repositories.UserRepository) *APITokenService {
+	return &APITokenService{
+		tokenRepo: tokenRepo,
+		userRepo:  userRepo,
+	}
+}
+
+func (s *APITokenService) CreateToken(ownerID, creatorID uint, req *models.CreateAPITokenRequest) (*models.CreateAPITokenResponse, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.New("validation failed")
+	}
+
+	for _, scope := range req.Scopes {
+		if !models.IsValidTokenScope(scope) {
+			return nil, fmt.Errorf("unknown scope %q", scope)
+		}
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, errors.New("expiry must be in the future")
+	}
+
+	owner, err := s.userRepo.GetByID(ownerID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	if !owner.IsActive {
+		return nil, errors.New("user is not active")
+	}
+
+	secret, _, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+	plain := models.APITokenPrefix + secret
+
+	token := &models.APIToken{
+		UserID:      ownerID,
+		Name:        req.Name,
+		Prefix:      plain[:len(models.APITokenPrefix)+6],
+		TokenHash:   utils.HashToken(plain),
+		Scopes:      req.Scopes,
+		ExpiresAt:   req.ExpiresAt,
+		CreatedByID: creatorID,
+	}
+
+	createdToken, err := s.tokenRepo.Create(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CreateAPITokenResponse{
+		Token:    plain,
+		APIToken: createdToken,
+	}, nil
+}
+
+func (s *APITokenService) GetTokensByUser(userID uint) ([]*models.APIToken, error) {
+	return s.tokenRepo.GetByUserID(userID)
+}
+
+func (s *APITokenService) RevokeToken(id, userID uint) error {
+	token, err := s.tokenRepo.GetByID(id)
+	if err != nil {
+		return errors.New("token not found")
+	}
+
+	if token.UserID != userID {
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil || !user.IsAdmin() {
+			return errors.New("only the token owner or an admin can revoke a token")
+		}
+	}
+
+	if token.IsRevoked() {
+		return errors.New("token is already revoked")
+	}
+
+	return s.tokenRepo.Revoke(id)
+}
+
+// Authenticate resolves a raw personal access token to its stored record and
+// owning user, recording when and from where it was last used.
+func (s *APITokenService) Authenticate(rawToken, clientIP string) (*models.APIToken, error) {
+	token, err := s.tokenRepo.GetByTokenHash(utils.HashToken(rawToken))
+	if err != nil {
+		return nil, errors.New("invalid token")
+	}
+
+	if !token.IsUsable() {
+		return nil, errors.New("token is expired or revoked")
+	}
+
+	if !token.User.IsActive {
+		return nil, errors.New("token owner is not active")
+	}
+
+	now := time.Now()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastUsedResolution || token.LastUsedIP != clientIP {
+		if err := s.tokenRepo.TouchLastUsed(token.ID, now, clientIP); err == nil {
+			token.LastUsedAt = &now
+			token.LastUsedIP = clientIP
+		}
+	}
+
+	return token, nil
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"api/internal/models"
+	"errors"
+	"fmt"
+	"log"
+)
+
+// authorizeGroupInvites requires userID to hold group.invite when groupIDs
+// names a group that is not among those already invited.
+func (s *MeetingService) authorizeGroupInvites(userID uint, groupIDs []uint, invited []models.UserGroup) error {
+	already := make(map[uint]bool, len(invited))
+	for _, group := range invited {
+		already[group.ID] = true
+	}
+	for _, id := range groupIDs {
+		if !already[id] {
+			return s.authorize(userID, models.ActionGroupInvite, nil)
+		}
+	}
+	return nil
+}
+
+// expandAttendeeGroups loads the groups invited to a meeting and maps each of
+// their active members other than organizerID to the groups they come with.
+func (s *MeetingService) expandAttendeeGroups(organizerID uint, groupIDs []uint) ([]*models.UserGroup, map[uint][]uint, error) {
+	groups := make([]*models.UserGroup, 0, len(groupIDs))
+	members := make(map[uint][]uint)
+	for _, groupID := range uniqueIDs(append([]uint{}, groupIDs...)) {
+		group, err := s.groupRepo.GetByID(groupID)
+		if err != nil {
+			return nil, nil, errors.New("attendee group not found")
+		}
+		groups = append(groups, group)
+
+		for _, member := range group.Members {
+			if member.ID != organizerID && member.IsActive {
+				members[member.ID] = append(members[member.ID], group.ID)
+			}
+		}
+	}
+	return groups, members, nil
+}
+
+// admitGroupMember reports whether user, who just joined a group invited to
+// meeting, fits in its room. Under hard capacity enforcement they are left
+// out; under soft enforcement they are admitted. The organizer is told either
+// way when the meeting is over capacity.
+func (s *MeetingService) admitGroupMember(meeting *models.Meeting, user *models.User) bool {
+	attendeeIDs := []uint{user.ID}
+	for _, attendee := range meeting.Attendees {
+		attendeeIDs = append(attendeeIDs, attendee.ID)
+	}
+
+	warning, err := s.checkCapacity(&meeting.Room, &meeting.Organizer, meetingHeadcount(meeting.OrganizerID, attendeeIDs),
+		meeting.StartTime, meeting.EndTime, meeting.BookingBuffers)
+	var violations *BookingPolicyError
+	if errors.As(err, &violations) {
+		s.notifier.Notify(&meeting.Organizer, "Group member not invited",
+			fmt.Sprintf("%s joined a group invited to %q but was not added: %s", user.FullName(), meeting.Title, violations.Violations[0].Message))
+		return false
+	}
+	if err != nil {
+		log.Printf("Failed to check the capacity of meeting %d for group member %d: %v", meeting.ID, user.ID, err)
+		return false
+	}
+
+	if warning != nil {
+		s.notifier.Notify(&meeting.Organizer, "Meeting over capacity",
+			fmt.Sprintf("%s joined a group invited to %q: %s", user.FullName(), meeting.Title, warning.Message))
+	}
+	return true
+}
+
+// individualAttendeeIDs returns the attendees of meeting that were invited
+// individually rather than with a group.
+func (s *MeetingService) individualAttendeeIDs(meeting *models.Meeting) ([]uint, error) {
+	records, err := s.meetingRepo.GetGroupAttendees(meeting.ID)
+	if err != nil {
+		return nil, err
+	}
+	viaGroup := make(map[uint]bool)
+	for _, record := range records {
+		viaGroup[record.UserID] = true
+	}
+
+	var ids []uint
+	for _, attendee := range meeting.Attendees {
+		if !viaGroup[attendee.ID] {
+			ids = append(ids, attendee.ID)
+		}
+	}
+	return ids, nil
+}
+
+// attendeeUnion lists attendeeIDs together with the group members that are
+// not among them.
+func attendeeUnion(attendeeIDs []uint, groupMembers map[uint][]uint) []uint {
+	ids := append([]uint{}, attendeeIDs...)
+	for id := range groupMembers {
+		ids = append(ids, id)
+	}
+	return uniqueIDs(ids)
+}
+
+// setAttendees makes the individual invitees and the members of groups the
+// attendees of meeting, remembering which of them came with a group.
+// Inactive users are left out.
+func (s *MeetingService) setAttendees(meeting *models.Meeting, attendeeIDs []uint, groups []*models.UserGroup, groupMembers map[uint][]uint) {
+	current := make(map[uint]bool)
+	for _, attendee := range meeting.Attendees {
+		current[attendee.ID] = true
+	}
+
+	individual := make(map[uint]bool)
+	wanted := make(map[uint]bool)
+	for _, id := range attendeeUnion(attendeeIDs, groupMembers) {
+		if id != meeting.OrganizerID {
+			wanted[id] = true
+		}
+	}
+	for _, id := range attendeeIDs {
+		individual[id] = true
+	}
+
+	for id := range current {
+		if !wanted[id] {
+			if err := s.meetingRepo.RemoveAttendee(meeting.ID, id); err != nil {
+				log.Printf("Failed to remove attendee %d from meeting %d: %v", id, meeting.ID, err)
+			}
+		}
+	}
+	for id := range wanted {
+		if current[id] {
+			continue
+		}
+		attendee, err := s.userRepo.GetByID(id)
+		if err != nil || !attendee.IsActive {
+			continue
+		}
+		if err := s.meetingRepo.AddAttendee(meeting.ID, id); err != nil {
+			log.Printf("Failed to add attendee %d to meeting %d: %v", id, meeting.ID, err)
+		}
+	}
+
+	groupIDs := make([]uint, len(groups))
+	for i, group := range groups {
+		groupIDs[i] = group.ID
+	}
+	if err := s.meetingRepo.SetAttendeeGroups(meeting.ID, groupIDs); err != nil {
+		log.Printf("Failed to set the attendee groups of meeting %d: %v", meeting.ID, err)
+	}
+
+	var records []*models.MeetingGroupAttendee
+	for userID, memberOf := range groupMembers {
+		if individual[userID] {
+			continue
+		}
+		for _, groupID := range memberOf {
+			records = append(records, &models.MeetingGroupAttendee{MeetingID: meeting.ID, UserID: userID, GroupID: groupID})
+		}
+	}
+	if err := s.meetingRepo.SetGroupAttendees(meeting.ID, records); err != nil {
+		log.Printf("Failed to record the group attendees of meeting %d: %v", meeting.ID, err)
+	}
+}
@@ -3,8 +3,11 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"strings"
 	"time"
 
 	"api/internal/config"
@@ -17,10 +20,15 @@ import (
 )
 
 type AuthService struct {
-	userRepo   repositories.UserRepository
-	jwtManager *utils.JWTManager
-	config     *config.Config
-	oauthConfig *oauth2.Config
+	userRepo          repositories.UserRepository
+	passwordResetRepo repositories.PasswordResetRepository
+	apiTokenService   *APITokenService
+	groupService      *GroupService
+	jwtManager        *utils.JWTManager
+	passwordHasher    *utils.PasswordHasher
+	dummyPasswordHash string
+	config            *config.Config
+	oauthConfig       *oauth2.Config
 }
 
 type MicrosoftUser struct {
@@ -31,14 +39,38 @@ type MicrosoftUser struct {
 	DisplayName string `json:"displayName"`
 }
 
+type microsoftGroupPage struct {
+	Value []struct {
+		ID          string `json:"id"`
+		DisplayName string `json:"displayName"`
+	} `json:"value"`
+	NextLink string `json:"@odata.nextLink"`
+}
+
 type LoginResponse struct {
 	User  *models.User `json:"user"`
 	Token string       `json:"token"`
 }
 
-func NewAuthService(userRepo repositories.UserRepository, config *config.Config) *AuthService {
+var (
+	ErrInvalidCredentials   = errors.New("invalid email or password")
+	ErrLocalAuthDisabled    = errors.New("local authentication is disabled")
+	ErrDevLoginDisabled     = errors.New("dev login is disabled")
+	ErrMicrosoftAuthMissing = errors.New("microsoft authentication is not configured")
+	ErrInvalidResetToken    = errors.New("invalid or expired password reset token")
+	ErrNoLocalPassword      = errors.New("this account cannot have a local password")
+)
+
+func NewAuthService(userRepo repositories.UserRepository, passwordResetRepo repositories.PasswordResetRepository, apiTokenService *APITokenService, groupService *GroupService, config *config.Config) (*AuthService, error) {
 	jwtManager := utils.NewJWTManager(config.Auth.JWTSecret)
-	
+	if config.Auth.JWTKeysFile != "" {
+		keySet, err := utils.LoadKeySetFromFile(config.Auth.JWTKeysFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load JWT keys: %v", err)
+		}
+		jwtManager = utils.NewJWTManagerWithKeySet(keySet, config.JWTFallbackSecret())
+	}
+
 	oauthConfig := &oauth2.Config{
 		ClientID:     config.Auth.MicrosoftClientID,
 		ClientSecret: config.Auth.MicrosoftClientSecret,
@@ -46,20 +78,80 @@ func NewAuthService(userRepo repositories.UserRepository, config *config.Config)
 		Scopes:       []string{"openid", "profile", "email"},
 		Endpoint:     microsoft.AzureADEndpoint(""),
 	}
+	if config.Auth.MicrosoftSyncGroups {
+		oauthConfig.Scopes = append(oauthConfig.Scopes, "GroupMember.Read.All")
+	}
+
+	passwordHasher := utils.NewPasswordHasher(config.Auth.LocalAuth.PasswordHashAlgo)
+	dummyPasswordHash, err := passwordHasher.Hash("no account has this password")
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash dummy password: %v", err)
+	}
 
 	return &AuthService{
-		userRepo:    userRepo,
-		jwtManager:  jwtManager,
-		config:      config,
-		oauthConfig: oauthConfig,
+		userRepo:          userRepo,
+		passwordResetRepo: passwordResetRepo,
+		apiTokenService:   apiTokenService,
+		groupService:      groupService,
+		jwtManager:        jwtManager,
+		passwordHasher:    passwordHasher,
+		dummyPasswordHash: dummyPasswordHash,
+		config:            config,
+		oauthConfig:       oauthConfig,
+	}, nil
+}
+
+// StartKeyReloader periodically re-reads the JWT key manifest so keys added
+// for an upcoming rotation are picked up without a restart. A manifest that
+// fails to load keeps the previous key set in place.
+func (s *AuthService) StartKeyReloader(interval time.Duration) {
+	if s.config.Auth.JWTKeysFile == "" || interval <= 0 {
+		return
 	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			keySet, err := utils.LoadKeySetFromFile(s.config.Auth.JWTKeysFile)
+			if err != nil {
+				log.Printf("Failed to reload JWT keys: %v", err)
+				continue
+			}
+			s.jwtManager.SetKeySet(keySet)
+		}
+	}()
 }
 
-func (s *AuthService) GetAuthURL(state string) string {
-	return s.oauthConfig.AuthCodeURL(state, oauth2.AccessTypeOffline)
+func (s *AuthService) JWKS() utils.JWKS {
+	return s.jwtManager.JWKS()
+}
+
+func (s *AuthService) MicrosoftAuthEnabled() bool {
+	return s.config.MicrosoftAuthEnabled()
+}
+
+func (s *AuthService) LocalAuthEnabled() bool {
+	return s.config.Auth.LocalAuth.Enabled
+}
+
+func (s *AuthService) DevLoginEnabled() bool {
+	return s.config.Auth.DevLoginEnabled && !s.config.IsProduction()
+}
+
+func (s *AuthService) GetAuthURL(state string) (string, error) {
+	if !s.MicrosoftAuthEnabled() {
+		return "", ErrMicrosoftAuthMissing
+	}
+	return s.oauthConfig.AuthCodeURL(state, oauth2.AccessTypeOffline), nil
 }
 
 func (s *AuthService) HandleCallback(code string) (*LoginResponse, error) {
+	if !s.MicrosoftAuthEnabled() {
+		return nil, ErrMicrosoftAuthMissing
+	}
+
 	token, err := s.oauthConfig.Exchange(context.Background(), code)
 	if err != nil {
 		return nil, fmt.Errorf("failed to exchange code: %v", err)
@@ -75,14 +167,247 @@ func (s *AuthService) HandleCallback(code string) (*LoginResponse, error) {
 		return nil, fmt.Errorf("failed to find or create user: %v", err)
 	}
 
+	// A failed sync keeps the memberships from the previous sign-in.
+	if s.config.Auth.MicrosoftSyncGroups {
+		if err := s.syncMicrosoftGroups(token, user); err != nil {
+			log.Printf("Failed to sync directory groups of user %d: %v", user.ID, err)
+		}
+	}
+
+	return s.completeLogin(user)
+}
+
+func (s *AuthService) LocalLogin(req *models.LocalLoginRequest) (*LoginResponse, error) {
+	if !s.LocalAuthEnabled() {
+		return nil, ErrLocalAuthDisabled
+	}
+
+	user, err := s.userRepo.GetByEmail(normalizeEmail(req.Email))
+	if err != nil || !user.HasLocalPassword() || user.IsServiceAccount {
+		// Hash anyway so the response time does not tell which emails have
+		// a local account.
+		s.passwordHasher.Verify(s.dummyPasswordHash, req.Password)
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := s.passwordHasher.Verify(user.PasswordHash, req.Password); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	if !user.IsActive {
+		return nil, errors.New("user is not active")
+	}
+
+	return s.completeLogin(user)
+}
+
+// DevLogin issues a token for an existing user without any credential check.
+// It is only reachable when DEV_LOGIN_ENABLED is set outside production.
+func (s *AuthService) DevLogin(req *models.DevLoginRequest) (*LoginResponse, error) {
+	if !s.DevLoginEnabled() {
+		return nil, ErrDevLoginDisabled
+	}
+
+	user, err := s.userRepo.GetByEmail(normalizeEmail(req.Email))
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	if user.IsServiceAccount {
+		return nil, errors.New("service accounts can only authenticate with API tokens")
+	}
+
+	if !user.IsActive {
+		return nil, errors.New("user is not active")
+	}
+
+	log.Printf("Dev login: issuing token for %s (user %d)", user.Email, user.ID)
+	return s.completeLogin(user)
+}
+
+// BootstrapAdmin makes sure the configured local admin account exists and has
+// the admin role. An existing password is left untouched so a reset done
+// through the API is not overwritten on the next restart.
+func (s *AuthService) BootstrapAdmin() error {
+	cfg := s.config.Auth.LocalAuth
+	if !cfg.Enabled || cfg.AdminEmail == "" {
+		return nil
+	}
+
+	email := normalizeEmail(cfg.AdminEmail)
+	user, err := s.userRepo.GetByEmail(email)
+	if err != nil {
+		if err := s.validatePassword(cfg.AdminPassword); err != nil {
+			return fmt.Errorf("LOCAL_ADMIN_PASSWORD: %v", err)
+		}
+
+		hash, err := s.passwordHasher.Hash(cfg.AdminPassword)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.userRepo.Create(&models.User{
+			Email:        email,
+			FirstName:    "Local",
+			LastName:     "Admin",
+			DisplayName:  "Local Admin",
+			PasswordHash: hash,
+			Role:         models.RoleAdmin,
+			IsActive:     true,
+		})
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Bootstrapped local admin account %s", email)
+		return nil
+	}
+
+	if user.IsServiceAccount {
+		return fmt.Errorf("LOCAL_ADMIN_EMAIL: %s is a service account", email)
+	}
+
+	// A Microsoft-only account is promoted but keeps signing in with Microsoft.
+	setPassword := !user.HasLocalPassword() && user.CanUseLocalPassword()
+	if user.Role == models.RoleAdmin && !setPassword {
+		return nil
+	}
+
+	user.Role = models.RoleAdmin
+	if setPassword {
+		if err := s.validatePassword(cfg.AdminPassword); err != nil {
+			return fmt.Errorf("LOCAL_ADMIN_PASSWORD: %v", err)
+		}
+
+		hash, err := s.passwordHasher.Hash(cfg.AdminPassword)
+		if err != nil {
+			return err
+		}
+		user.PasswordHash = hash
+	}
+
+	_, err = s.userRepo.Update(user)
+	return err
+}
+
+// RequestPasswordReset creates a reset token for email. Unknown addresses are
+// not reported back so the endpoint cannot be used to enumerate accounts.
+func (s *AuthService) RequestPasswordReset(req *models.PasswordResetRequest) error {
+	if !s.LocalAuthEnabled() {
+		return ErrLocalAuthDisabled
+	}
+
+	user, err := s.userRepo.GetByEmail(normalizeEmail(req.Email))
+	if err != nil || !user.IsActive || !user.CanUseLocalPassword() {
+		return nil
+	}
+
+	token, err := s.IssuePasswordReset(user.ID)
+	if err != nil {
+		return err
+	}
+
+	// There is no mail delivery yet; outside production the token is logged so
+	// the flow can be exercised locally.
+	if !s.config.IsProduction() {
+		log.Printf("Password reset token for %s: %s", user.Email, token)
+	}
+
+	return nil
+}
+
+// IssuePasswordReset creates a single-use reset token for userID and returns
+// the plain token. Only its hash is stored.
+func (s *AuthService) IssuePasswordReset(userID uint) (string, error) {
+	if !s.LocalAuthEnabled() {
+		return "", ErrLocalAuthDisabled
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return "", errors.New("user not found")
+	}
+
+	if !user.IsActive {
+		return "", errors.New("user is not active")
+	}
+
+	if !user.CanUseLocalPassword() {
+		return "", ErrNoLocalPassword
+	}
+
+	if err := s.passwordResetRepo.InvalidateForUser(userID); err != nil {
+		return "", err
+	}
+
+	token, tokenHash, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = s.passwordResetRepo.Create(&models.PasswordResetToken{
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(s.config.Auth.LocalAuth.PasswordResetTTL),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *AuthService) ResetPassword(req *models.PasswordResetConfirmRequest) error {
+	if !s.LocalAuthEnabled() {
+		return ErrLocalAuthDisabled
+	}
+
+	resetToken, err := s.passwordResetRepo.GetByTokenHash(utils.HashToken(req.Token))
+	if err != nil || !resetToken.IsUsable() {
+		return ErrInvalidResetToken
+	}
+
+	if err := s.validatePassword(req.NewPassword); err != nil {
+		return err
+	}
+
+	hash, err := s.passwordHasher.Hash(req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(resetToken.UserID)
+	if err != nil || !user.IsActive || !user.CanUseLocalPassword() {
+		return ErrInvalidResetToken
+	}
+
+	redeemed, err := s.passwordResetRepo.Redeem(resetToken.ID, user.ID, hash)
+	if err != nil {
+		return err
+	}
+	if !redeemed {
+		return ErrInvalidResetToken
+	}
+
+	return nil
+}
+
+func (s *AuthService) validatePassword(password string) error {
+	if len(password) < s.config.Auth.LocalAuth.MinPasswordLength {
+		return fmt.Errorf("password must be at least %d characters", s.config.Auth.LocalAuth.MinPasswordLength)
+	}
+	return nil
+}
+
+func (s *AuthService) completeLogin(user *models.User) (*LoginResponse, error) {
 	now := time.Now()
 	user.LastLogin = &now
-	user, err = s.userRepo.Update(user)
+	user, err := s.userRepo.Update(user)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update last login: %v", err)
 	}
 
-	jwtToken, err := s.jwtManager.GenerateToken(user.ID, user.Email, string(user.Role), user.MicrosoftID)
+	jwtToken, err := s.jwtManager.GenerateToken(user.ID, user.Email, string(user.Role), user.GetMicrosoftID())
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate token: %v", err)
 	}
@@ -97,6 +422,10 @@ func (s *AuthService) ValidateToken(tokenString string) (*utils.JWTClaims, error
 	return s.jwtManager.ValidateToken(tokenString)
 }
 
+func (s *AuthService) AuthenticateAPIToken(tokenString, clientIP string) (*models.APIToken, error) {
+	return s.apiTokenService.Authenticate(tokenString, clientIP)
+}
+
 func (s *AuthService) RefreshToken(tokenString string) (string, error) {
 	return s.jwtManager.RefreshToken(tokenString)
 }
@@ -122,6 +451,38 @@ func (s *AuthService) getMicrosoftUserInfo(token *oauth2.Token) (*MicrosoftUser,
 	return &msUser, nil
 }
 
+// syncMicrosoftGroups mirrors the directory groups the user is a member of.
+func (s *AuthService) syncMicrosoftGroups(token *oauth2.Token, user *models.User) error {
+	client := s.oauthConfig.Client(context.Background(), token)
+
+	var groups []models.DirectoryGroup
+	url := "https://graph.microsoft.com/v1.0/me/memberOf/microsoft.graph.group?$select=id,displayName"
+	for url != "" {
+		resp, err := client.Get(url)
+		if err != nil {
+			return err
+		}
+
+		var page microsoftGroupPage
+		if resp.StatusCode != http.StatusOK {
+			err = fmt.Errorf("microsoft graph API returned status %d", resp.StatusCode)
+		} else {
+			err = json.NewDecoder(resp.Body).Decode(&page)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		for _, group := range page.Value {
+			groups = append(groups, models.DirectoryGroup{ExternalID: group.ID, Name: group.DisplayName})
+		}
+		url = page.NextLink
+	}
+
+	return s.groupService.SyncDirectoryGroups(user, groups)
+}
+
 func (s *AuthService) findOrCreateUser(msUser *MicrosoftUser) (*models.User, error) {
 	user, err := s.userRepo.GetByMicrosoftID(msUser.ID)
 	if err == nil {
@@ -130,12 +491,12 @@ func (s *AuthService) findOrCreateUser(msUser *MicrosoftUser) (*models.User, err
 
 	user, err = s.userRepo.GetByEmail(msUser.Email)
 	if err == nil {
-		user.MicrosoftID = msUser.ID
+		user.MicrosoftID = &msUser.ID
 		return s.userRepo.Update(user)
 	}
 
 	newUser := &models.User{
-		MicrosoftID: msUser.ID,
+		MicrosoftID: &msUser.ID,
 		Email:       msUser.Email,
 		FirstName:   msUser.FirstName,
 		LastName:    msUser.LastName,
@@ -145,4 +506,8 @@ func (s *AuthService) findOrCreateUser(msUser *MicrosoftUser) (*models.User, err
 	}
 
 	return s.userRepo.Create(newUser)
-}
\ No newline at end of file
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
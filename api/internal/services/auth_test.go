@@ -0,0 +1,186 @@
+package services
+
+import (
+	"api/internal/config"
+	"api/internal/models"
+	"api/internal/repositories"
+	"api/internal/utils"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeUserRepository keeps users in memory. Methods a test does not need
+// fall through to the nil embedded interface and panic.
+type fakeUserRepository struct {
+	repositories.UserRepository
+	users map[uint]*models.User
+}
+
+func newFakeUserRepository(users ...*models.User) *fakeUserRepository {
+	r := &fakeUserRepository{users: make(map[uint]*models.User)}
+	for _, user := range users {
+		r.users[user.ID] = user
+	}
+	return r
+}
+
+func (r *fakeUserRepository) GetByID(id uint) (*models.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (r *fakeUserRepository) GetByEmail(email string) (*models.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			copied := *user
+			return &copied, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (r *fakeUserRepository) Update(user *models.User) (*models.User, error) {
+	copied := *user
+	r.users[user.ID] = &copied
+	return user, nil
+}
+
+type fakePasswordResetRepository struct {
+	tokens map[uint]*models.PasswordResetToken
+	users  *fakeUserRepository
+}
+
+func (r *fakePasswordResetRepository) Create(token *models.PasswordResetToken) (*models.PasswordResetToken, error) {
+	token.ID = uint(len(r.tokens) + 1)
+	r.tokens[token.ID] = token
+	return token, nil
+}
+
+func (r *fakePasswordResetRepository) GetByTokenHash(tokenHash string) (*models.PasswordResetToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (r *fakePasswordResetRepository) InvalidateForUser(userID uint) error {
+	for _, token := range r.tokens {
+		if token.UserID == userID && token.UsedAt == nil {
+			now := time.Now()
+			token.UsedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *fakePasswordResetRepository) Redeem(id, userID uint, passwordHash string) (bool, error) {
+	token := r.tokens[id]
+	if token.UsedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	token.UsedAt = &now
+	r.users.users[userID].PasswordHash = passwordHash
+	return true, nil
+}
+
+func newTestAuthService(users *fakeUserRepository) (*AuthService, *fakePasswordResetRepository) {
+	resets := &fakePasswordResetRepository{tokens: make(map[uint]*models.PasswordResetToken), users: users}
+	cfg := &config.Config{}
+	cfg.Auth.LocalAuth = config.LocalAuthConfig{
+		Enabled:           true,
+		PasswordHashAlgo:  "bcrypt",
+		PasswordResetTTL:  time.Hour,
+		MinPasswordLength: 12,
+	}
+	return &AuthService{
+		userRepo:          users,
+		passwordResetRepo: resets,
+		passwordHasher:    utils.NewPasswordHasher("bcrypt"),
+		config:            cfg,
+	}, resets
+}
+
+func TestResetPassword(t *testing.T) {
+	microsoftID := "ms-1"
+	tests := []struct {
+		name    string
+		user    models.User
+		used    bool
+		expired bool
+		want    error
+	}{
+		{"local account", models.User{IsActive: true}, false, false, nil},
+		{"token already used", models.User{IsActive: true}, true, false, ErrInvalidResetToken},
+		{"token expired", models.User{IsActive: true}, false, true, ErrInvalidResetToken},
+		{"inactive user", models.User{IsActive: false}, false, false, ErrInvalidResetToken},
+		{"service account", models.User{IsActive: true, IsServiceAccount: true}, false, false, ErrInvalidResetToken},
+		{"microsoft-only account", models.User{IsActive: true, MicrosoftID: &microsoftID}, false, false, ErrInvalidResetToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := tt.user
+			user.ID = 1
+			users := newFakeUserRepository(&user)
+			authService, resets := newTestAuthService(users)
+
+			token, tokenHash, err := utils.GenerateSecureToken(32)
+			if err != nil {
+				t.Fatal(err)
+			}
+			reset := &models.PasswordResetToken{UserID: 1, TokenHash: tokenHash, ExpiresAt: time.Now().Add(time.Hour)}
+			if tt.expired {
+				reset.ExpiresAt = time.Now().Add(-time.Minute)
+			}
+			if tt.used {
+				now := time.Now()
+				reset.UsedAt = &now
+			}
+			resets.Create(reset)
+
+			err = authService.ResetPassword(&models.PasswordResetConfirmRequest{Token: token, NewPassword: "correct horse battery"})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("error = %v, want %v", err, tt.want)
+			}
+			if changed := users.users[1].HasLocalPassword(); changed != (tt.want == nil) {
+				t.Errorf("password changed = %v, want %v", changed, tt.want == nil)
+			}
+			if tt.want == nil {
+				if err := authService.ResetPassword(&models.PasswordResetConfirmRequest{Token: token, NewPassword: "another long password"}); !errors.Is(err, ErrInvalidResetToken) {
+					t.Errorf("second redemption error = %v, want %v", err, ErrInvalidResetToken)
+				}
+			}
+		})
+	}
+}
+
+func TestCanUseLocalPassword(t *testing.T) {
+	microsoftID := "ms-1"
+	tests := []struct {
+		name string
+		user models.User
+		want bool
+	}{
+		{"local account", models.User{}, true},
+		{"service account", models.User{IsServiceAccount: true}, false},
+		{"microsoft-only account", models.User{MicrosoftID: &microsoftID}, false},
+		{"microsoft account with local password", models.User{MicrosoftID: &microsoftID, PasswordHash: "hash"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.user.CanUseLocalPassword(); got != tt.want {
+				t.Errorf("CanUseLocalPassword() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
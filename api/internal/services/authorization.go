@@ -0,0 +1,155 @@
+package services
+
+import (
+	"api/internal/models"
+	"api/internal/repositories"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var ErrPermissionDenied = errors.New("permission denied")
+
+// AuthorizationService is the single place where access decisions are made.
+// Role-to-permission mappings live in the database and are cached in memory;
+// they are reloaded whenever they are changed through this service.
+type AuthorizationService struct {
+	rolePermissionRepo repositories.RolePermissionRepository
+	mu                 sync.RWMutex
+	permissions        map[models.UserRole]map[models.Permission]bool
+}
+
+func NewAuthorizationService(rolePermissionRepo repositories.RolePermissionRepository) *AuthorizationService {
+	return &AuthorizationService{
+		rolePermissionRepo: rolePermissionRepo,
+		permissions:        make(map[models.UserRole]map[models.Permission]bool),
+	}
+}
+
+// Load reads the role mappings after granting each role the defaults it has
+// not been given before. Defaults an admin removed stay removed.
+func (s *AuthorizationService) Load() error {
+	if err := s.applyDefaults(); err != nil {
+		return err
+	}
+
+	return s.reload()
+}
+
+func (s *AuthorizationService) applyDefaults() error {
+	appliedRows, err := s.rolePermissionRepo.GetAppliedDefaults()
+	if err != nil {
+		return err
+	}
+
+	applied := make(map[models.UserRole]map[models.Permission]bool)
+	for _, row := range appliedRows {
+		if applied[row.Role] == nil {
+			applied[row.Role] = make(map[models.Permission]bool)
+		}
+		applied[row.Role][row.Permission] = true
+	}
+
+	for _, role := range models.AllRoles {
+		var missing []models.Permission
+		for _, permission := range models.DefaultRolePermissions[role] {
+			if !applied[role][permission] {
+				missing = append(missing, permission)
+			}
+		}
+		if err := s.rolePermissionRepo.ApplyDefaults(role, missing); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *AuthorizationService) reload() error {
+	rolePermissions, err := s.rolePermissionRepo.GetAll()
+	if err != nil {
+		return err
+	}
+
+	permissions := make(map[models.UserRole]map[models.Permission]bool)
+	for _, rp := range rolePermissions {
+		if permissions[rp.Role] == nil {
+			permissions[rp.Role] = make(map[models.Permission]bool)
+		}
+		permissions[rp.Role][rp.Permission] = true
+	}
+
+	s.mu.Lock()
+	s.permissions = permissions
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *AuthorizationService) HasPermission(role models.UserRole, permission models.Permission) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.permissions[role][permission]
+}
+
+// Authorize decides whether subject may perform action on resource. A plain
+// permission named after the action or its ".any" variant grants access to
+// every resource; the ".own" variant only when the subject owns resource.
+// resource may be nil for actions that are not tied to a specific record.
+func (s *AuthorizationService) Authorize(subject models.Subject, action models.Action, resource models.Resource) error {
+	if s.HasPermission(subject.Role, models.Permission(action)) ||
+		s.HasPermission(subject.Role, models.Permission(action+".any")) {
+		return nil
+	}
+
+	if resource != nil && resource.IsOwnedBy(subject.UserID) &&
+		s.HasPermission(subject.Role, models.Permission(action+".own")) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrPermissionDenied, action)
+}
+
+func (s *AuthorizationService) GetRolePermissions() map[models.UserRole][]models.Permission {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make(map[models.UserRole][]models.Permission)
+	for _, role := range models.AllRoles {
+		result[role] = []models.Permission{}
+		for _, permission := range models.AllPermissions {
+			if s.permissions[role][permission] {
+				result[role] = append(result[role], permission)
+			}
+		}
+	}
+	return result
+}
+
+func (s *AuthorizationService) UpdateRolePermissions(role models.UserRole, permissions []models.Permission) error {
+	if !models.IsValidRole(role) {
+		return fmt.Errorf("unknown role %q", role)
+	}
+
+	seen := make(map[models.Permission]bool)
+	unique := make([]models.Permission, 0, len(permissions))
+	for _, permission := range permissions {
+		if !models.IsValidPermission(permission) {
+			return fmt.Errorf("unknown permission %q", permission)
+		}
+		if !seen[permission] {
+			seen[permission] = true
+			unique = append(unique, permission)
+		}
+	}
+
+	// Keep at least one role able to manage permissions, otherwise nobody
+	// could undo the change.
+	if role == models.RoleAdmin && !seen[models.PermUserAdmin] {
+		return errors.New("admin role must keep the user.admin permission")
+	}
+
+	if err := s.rolePermissionRepo.ReplaceForRole(role, unique); err != nil {
+		return err
+	}
+
+	return s.reload()
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"api/internal/models"
+	"testing"
+)
+
+// fakeRolePermissionRepository keeps role permissions and applied defaults
+// as sets in memory.
+type fakeRolePermissionRepository struct {
+	granted map[models.UserRole]map[models.Permission]bool
+	applied map[models.UserRole]map[models.Permission]bool
+}
+
+func newFakeRolePermissionRepository() *fakeRolePermissionRepository {
+	return &fakeRolePermissionRepository{
+		granted: make(map[models.UserRole]map[models.Permission]bool),
+		applied: make(map[models.UserRole]map[models.Permission]bool),
+	}
+}
+
+func addPermissions(set map[models.UserRole]map[models.Permission]bool, role models.UserRole, permissions []models.Permission) {
+	if set[role] == nil {
+		set[role] = make(map[models.Permission]bool)
+	}
+	for _, permission := range permissions {
+		set[role][permission] = true
+	}
+}
+
+func (r *fakeRolePermissionRepository) GetAll() ([]*models.RolePermission, error) {
+	var rows []*models.RolePermission
+	for role, permissions := range r.granted {
+		for permission := range permissions {
+			rows = append(rows, &models.RolePermission{Role: role, Permission: permission})
+		}
+	}
+	return rows, nil
+}
+
+func (r *fakeRolePermissionRepository) ReplaceForRole(role models.UserRole, permissions []models.Permission) error {
+	delete(r.granted, role)
+	addPermissions(r.granted, role, permissions)
+	return nil
+}
+
+func (r *fakeRolePermissionRepository) GetAppliedDefaults() ([]*models.AppliedDefaultPermission, error) {
+	var rows []*models.AppliedDefaultPermission
+	for role, permissions := range r.applied {
+		for permission := range permissions {
+			rows = append(rows, &models.AppliedDefaultPermission{Role: role, Permission: permission})
+		}
+	}
+	return rows, nil
+}
+
+func (r *fakeRolePermissionRepository) ApplyDefaults(role models.UserRole, permissions []models.Permission) error {
+	addPermissions(r.granted, role, permissions)
+	addPermissions(r.applied, role, permissions)
+	return nil
+}
+
+func without(permissions []models.Permission, removed models.Permission) []models.Permission {
+	var kept []models.Permission
+	for _, permission := range permissions {
+		if permission != removed {
+			kept = append(kept, permission)
+		}
+	}
+	return kept
+}
+
+func TestLoadAppliesDefaults(t *testing.T) {
+	// An installation that was given every default but bump before it was
+	// introduced.
+	upgraded := newFakeRolePermissionRepository()
+	for _, role := range models.AllRoles {
+		upgraded.ApplyDefaults(role, without(models.DefaultRolePermissions[role], models.PermMeetingBump))
+	}
+
+	tests := []struct {
+		name       string
+		repo       *fakeRolePermissionRepository
+		role       models.UserRole
+		permission models.Permission
+		want       bool
+	}{
+		{"fresh: employee books desks", newFakeRolePermissionRepository(), models.RoleEmployee, models.PermDeskBook, true},
+		{"fresh: manager bumps", newFakeRolePermissionRepository(), models.RoleManager, models.PermMeetingBump, true},
+		{"fresh: employee cannot bump", newFakeRolePermissionRepository(), models.RoleEmployee, models.PermMeetingBump, false},
+		{"upgraded: manager bumps", upgraded, models.RoleManager, models.PermMeetingBump, true},
+		{"upgraded: employee still cannot bump", upgraded, models.RoleEmployee, models.PermMeetingBump, false},
+		{"upgraded: kept permission", upgraded, models.RoleEmployee, models.PermMeetingCreate, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authzService := NewAuthorizationService(tt.repo)
+			if err := authzService.Load(); err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got := authzService.HasPermission(tt.role, tt.permission); got != tt.want {
+				t.Errorf("HasPermission(%s, %s) = %v, want %v", tt.role, tt.permission, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadKeepsRemovedDefaultsRemoved(t *testing.T) {
+	repo := newFakeRolePermissionRepository()
+	authzService := NewAuthorizationService(repo)
+	if err := authzService.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	employee := without(models.DefaultRolePermissions[models.RoleEmployee], models.PermDeskBook)
+	if err := authzService.UpdateRolePermissions(models.RoleEmployee, employee); err != nil {
+		t.Fatalf("UpdateRolePermissions() error = %v", err)
+	}
+
+	restarted := NewAuthorizationService(repo)
+	if err := restarted.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if restarted.HasPermission(models.RoleEmployee, models.PermDeskBook) {
+		t.Error("desk.book was granted again after an admin removed it")
+	}
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"api/internal/models"
+	"api/internal/repositories"
+	"api/internal/utils"
+	"errors"
+	"strings"
+	"time"
+)
+
+type BlackoutService struct {
+	blackoutRepo  repositories.BlackoutRepository
+	roomRepo      repositories.RoomRepository
+	buildingRepo  repositories.BuildingRepository
+	policyService *BookingPolicyService
+}
+
+func NewBlackoutService(blackoutRepo repositories.BlackoutRepository, roomRepo repositories.RoomRepository, buildingRepo repositories.BuildingRepository, policyService *BookingPolicyService) *BlackoutService {
+	return &BlackoutService{
+		blackoutRepo:  blackoutRepo,
+		roomRepo:      roomRepo,
+		buildingRepo:  buildingRepo,
+		policyService: policyService,
+	}
+}
+
+// CreateBlackout blocks a room or a building and reports the existing
+// bookings that collide with it. Those bookings are left untouched.
+func (s *BlackoutService) CreateBlackout(userID uint, req *models.CreateRoomBlackoutRequest) (*models.RoomBlackoutReport, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.New("validation failed")
+	}
+
+	if (req.RoomID == nil) == (req.BuildingID == nil) {
+		return nil, errors.New("exactly one of room_id and building_id is required")
+	}
+
+	blackout := &models.RoomBlackout{
+		RoomID:            req.RoomID,
+		BuildingID:        req.BuildingID,
+		Reason:            strings.TrimSpace(req.Reason),
+		StartTime:         req.StartTime,
+		EndTime:           req.EndTime,
+		RecurrencePattern: req.RecurrencePattern,
+		RecurrenceUntil:   req.RecurrenceUntil,
+		TimeZone:          req.TimeZone,
+		CreatedByID:       userID,
+	}
+
+	if req.RoomID != nil {
+		room, err := s.roomRepo.GetByID(*req.RoomID)
+		if err != nil {
+			return nil, errors.New("room not found")
+		}
+		if blackout.TimeZone == "" {
+			blackout.TimeZone = s.policyService.RoomLocation(room).String()
+		}
+	} else {
+		building, err := s.buildingRepo.GetByID(*req.BuildingID)
+		if err != nil {
+			return nil, errors.New("building not found")
+		}
+		if blackout.TimeZone == "" && building.Site != nil {
+			blackout.TimeZone = building.Site.TimeZone
+		}
+	}
+	if blackout.TimeZone == "" {
+		blackout.TimeZone = models.DefaultTimeZone
+	}
+
+	if err := validateBlackout(blackout); err != nil {
+		return nil, err
+	}
+
+	createdBlackout, err := s.blackoutRepo.Create(blackout)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.report(createdBlackout)
+}
+
+func (s *BlackoutService) GetBlackoutByID(id uint) (*models.RoomBlackout, error) {
+	return s.blackoutRepo.GetByID(id)
+}
+
+func (s *BlackoutService) GetAllBlackouts(filter models.RoomBlackoutFilter, page, limit int) ([]*models.RoomBlackout, utils.PaginationMeta, error) {
+	offset := utils.GetOffset(page, limit)
+	blackouts, total, err := s.blackoutRepo.GetAll(filter, offset, limit)
+	if err != nil {
+		return nil, utils.PaginationMeta{}, err
+	}
+
+	meta := utils.CreatePaginationMeta(page, limit, total)
+	return blackouts, meta, nil
+}
+
+func (s *BlackoutService) UpdateBlackout(id uint, req *models.UpdateRoomBlackoutRequest) (*models.RoomBlackoutReport, error) {
+	blackout, err := s.blackoutRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("blackout not found")
+	}
+
+	if req.Reason != nil {
+		blackout.Reason = strings.TrimSpace(*req.Reason)
+	}
+	if req.StartTime != nil {
+		blackout.StartTime = *req.StartTime
+	}
+	if req.EndTime != nil {
+		blackout.EndTime = *req.EndTime
+	}
+	if req.RecurrencePattern != nil {
+		blackout.RecurrencePattern = *req.RecurrencePattern
+	}
+	if req.RecurrenceUntil != nil {
+		blackout.RecurrenceUntil = req.RecurrenceUntil
+	}
+	if req.TimeZone != nil {
+		blackout.TimeZone = *req.TimeZone
+	}
+
+	if err := validateBlackout(blackout); err != nil {
+		return nil, err
+	}
+
+	updatedBlackout, err := s.blackoutRepo.Update(blackout)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.report(updatedBlackout)
+}
+
+func (s *BlackoutService) DeleteBlackout(id uint) error {
+	if _, err := s.blackoutRepo.GetByID(id); err != nil {
+		return errors.New("blackout not found")
+	}
+
+	return s.blackoutRepo.Delete(id)
+}
+
+// GetConflictingMeetings lists the upcoming bookings that fall within the
+// blackout.
+func (s *BlackoutService) GetConflictingMeetings(id uint) ([]*models.Meeting, error) {
+	blackout, err := s.blackoutRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("blackout not found")
+	}
+
+	return s.conflictingMeetings(blackout)
+}
+
+func (s *BlackoutService) report(blackout *models.RoomBlackout) (*models.RoomBlackoutReport, error) {
+	conflicts, err := s.conflictingMeetings(blackout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RoomBlackoutReport{
+		Blackout:            blackout,
+		ConflictingMeetings: conflicts,
+	}, nil
+}
+
+func (s *BlackoutService) conflictingMeetings(blackout *models.RoomBlackout) ([]*models.Meeting, error) {
+	meetings, err := s.blackoutRepo.GetMeetingsInScope(blackout, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := []*models.Meeting{}
+	for _, meeting := range meetings {
+		if blackout.Blocks(meeting.StartTime, meeting.EndTime) {
+			conflicts = append(conflicts, meeting)
+		}
+	}
+
+	return conflicts, nil
+}
+
+func validateBlackout(blackout *models.RoomBlackout) error {
+	if blackout.Reason == "" {
+		return errors.New("reason is required")
+	}
+
+	if !blackout.EndTime.After(blackout.StartTime) {
+		return errors.New("end time must be after start time")
+	}
+
+	if _, err := models.LoadTimeZone(blackout.TimeZone); err != nil {
+		return err
+	}
+
+	if blackout.RecurrencePattern == "" {
+		blackout.RecurrenceUntil = nil
+		return nil
+	}
+
+	if !models.IsValidRecurrencePattern(blackout.RecurrencePattern) {
+		return errors.New("invalid recurrence pattern: use daily, weekdays, weekly, biweekly or monthly")
+	}
+
+	if blackout.RecurrenceUntil != nil && blackout.RecurrenceUntil.Before(blackout.StartTime) {
+		return errors.New("recurrence_until must not be before start time")
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package services
+
+import (
+	"api/internal/config"
+	"api/internal/models"
+	"api/internal/repositories"
+	"api/internal/utils"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BookingPolicyError lists every policy rule a booking breaks, in the same
+// shape as request validation errors.
+type BookingPolicyError struct {
+	Violations []utils.ValidationError
+}
+
+func (e *BookingPolicyError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.Message
+	}
+	return "booking policy violated: " + strings.Join(messages, "; ")
+}
+
+func (e *BookingPolicyError) add(field, tag, message string) {
+	e.Violations = append(e.Violations, utils.ValidationError{Field: field, Tag: tag, Message: message})
+}
+
+type BookingPolicyService struct {
+	policyRepo    repositories.BookingPolicyRepository
+	roomRepo      repositories.RoomRepository
+	meetingRepo   repositories.MeetingRepository
+	defaultPolicy models.BookingPolicy
+	defaultZone   *time.Location
+}
+
+func NewBookingPolicyService(policyRepo repositories.BookingPolicyRepository, roomRepo repositories.RoomRepository, meetingRepo repositories.MeetingRepository, cfg config.BookingConfig) (*BookingPolicyService, error) {
+	defaultPolicy := models.BookingPolicy{
+		MinDurationMinutes:  cfg.Policy.MinDurationMinutes,
+		MaxDurationMinutes:  cfg.Policy.MaxDurationMinutes,
+		MaxAdvanceDays:      cfg.Policy.MaxAdvanceDays,
+		MinLeadTimeMinutes:  cfg.Policy.MinLeadTimeMinutes,
+		EarliestStart:       cfg.Policy.EarliestStart,
+		LatestEnd:           cfg.Policy.LatestEnd,
+		AllowedDays:         cfg.Policy.AllowedDays,
+		MaxActiveBookings:   cfg.Policy.MaxActiveBookings,
+		CapacityEnforcement: cfg.Policy.CapacityEnforcement,
+	}
+	if err := defaultPolicy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid global booking policy: %w", err)
+	}
+
+	defaultZone, err := models.LoadTimeZone(cfg.DefaultTimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid default booking time zone: %w", err)
+	}
+
+	return &BookingPolicyService{
+		policyRepo:    policyRepo,
+		roomRepo:      roomRepo,
+		meetingRepo:   meetingRepo,
+		defaultPolicy: defaultPolicy,
+		defaultZone:   defaultZone,
+	}, nil
+}
+
+func (s *BookingPolicyService) GetDefaultPolicy() models.BookingPolicy {
+	return s.defaultPolicy
+}
+
+func (s *BookingPolicyService) GetEffectivePolicy(roomID uint) (*models.EffectiveBookingPolicy, error) {
+	if _, err := s.roomRepo.GetByID(roomID); err != nil {
+		return nil, errors.New("room not found")
+	}
+
+	overrides, err := s.policyRepo.GetByRoomID(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.EffectiveBookingPolicy{
+		RoomID:    roomID,
+		Policy:    s.defaultPolicy.WithOverrides(overrides),
+		Overrides: overrides,
+	}, nil
+}
+
+// UpdateRoomPolicy replaces the room's overrides; fields left out of req fall
+// back to the global policy.
+func (s *BookingPolicyService) UpdateRoomPolicy(roomID uint, req *models.UpdateRoomBookingPolicyRequest) (*models.EffectiveBookingPolicy, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.New("validation failed")
+	}
+
+	if _, err := s.roomRepo.GetByID(roomID); err != nil {
+		return nil, errors.New("room not found")
+	}
+
+	overrides := &models.RoomBookingPolicy{
+		RoomID:              roomID,
+		MinDurationMinutes:  req.MinDurationMinutes,
+		MaxDurationMinutes:  req.MaxDurationMinutes,
+		MaxAdvanceDays:      req.MaxAdvanceDays,
+		MinLeadTimeMinutes:  req.MinLeadTimeMinutes,
+		EarliestStart:       req.EarliestStart,
+		LatestEnd:           req.LatestEnd,
+		AllowedDays:         req.AllowedDays,
+		MaxActiveBookings:   req.MaxActiveBookings,
+		CapacityEnforcement: req.CapacityEnforcement,
+	}
+
+	if err := s.defaultPolicy.WithOverrides(overrides).Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.policyRepo.Save(overrides); err != nil {
+		return nil, err
+	}
+
+	return s.GetEffectivePolicy(roomID)
+}
+
+func (s *BookingPolicyService) DeleteRoomPolicy(roomID uint) error {
+	if _, err := s.roomRepo.GetByID(roomID); err != nil {
+		return errors.New("room not found")
+	}
+
+	return s.policyRepo.Delete(roomID)
+}
+
+// CheckBooking validates a booking of roomID by organizerID against the
+// room's effective policy. excludeMeetingID leaves the booking being changed
+// out of the active booking count. Hours and days are evaluated in the time
+// zone of the room's site.
+func (s *BookingPolicyService) CheckBooking(roomID, organizerID uint, startTime, endTime time.Time, excludeMeetingID *uint) error {
+	room, err := s.roomRepo.GetByID(roomID)
+	if err != nil {
+		return errors.New("room not found")
+	}
+	loc := s.RoomLocation(room)
+
+	overrides, err := s.policyRepo.GetByRoomID(roomID)
+	if err != nil {
+		return err
+	}
+	policy := s.defaultPolicy.WithOverrides(overrides)
+
+	now := time.Now()
+	violations := &BookingPolicyError{}
+
+	duration := endTime.Sub(startTime)
+	if policy.MinDurationMinutes > 0 && duration < time.Duration(policy.MinDurationMinutes)*time.Minute {
+		violations.add("end_time", "min_duration",
+			fmt.Sprintf("meetings must last at least %d minutes", policy.MinDurationMinutes))
+	}
+	if policy.MaxDurationMinutes > 0 && duration > time.Duration(policy.MaxDurationMinutes)*time.Minute {
+		violations.add("end_time", "max_duration",
+			fmt.Sprintf("meetings must not last longer than %d minutes", policy.MaxDurationMinutes))
+	}
+
+	if policy.MaxAdvanceDays > 0 && startTime.After(now.AddDate(0, 0, policy.MaxAdvanceDays)) {
+		violations.add("start_time", "max_advance",
+			fmt.Sprintf("meetings cannot be booked more than %d days in advance", policy.MaxAdvanceDays))
+	}
+	if policy.MinLeadTimeMinutes > 0 && startTime.Before(now.Add(time.Duration(policy.MinLeadTimeMinutes)*time.Minute)) {
+		violations.add("start_time", "min_lead_time",
+			fmt.Sprintf("meetings must be booked at least %d minutes in advance", policy.MinLeadTimeMinutes))
+	}
+
+	localStart := startTime.In(loc)
+	localEnd := endTime.In(loc)
+
+	if policy.EarliestStart != "" || policy.LatestEnd != "" {
+		earliest, _ := models.ParseTimeOfDay(policy.EarliestStart)
+		latest, _ := models.ParseTimeOfDay(policy.LatestEnd)
+		if policy.LatestEnd == "" {
+			latest = 24 * time.Hour
+		}
+
+		dayStart := models.StartOfDay(localStart, loc)
+		if localStart.Before(atTimeOfDay(dayStart, earliest)) || localEnd.After(atTimeOfDay(dayStart, latest)) {
+			violations.add("start_time", "allowed_hours",
+				fmt.Sprintf("meetings must take place between %s and %s", timeOfDayLabel(policy.EarliestStart, "00:00"), timeOfDayLabel(policy.LatestEnd, "24:00")))
+		}
+	}
+
+	if len(policy.AllowedDays) > 0 {
+		days, _ := models.ParseWeekdays(policy.AllowedDays)
+		allowed := false
+		for _, day := range days {
+			if localStart.Weekday() == day {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			violations.add("start_time", "allowed_days",
+				fmt.Sprintf("meetings can only be booked on %s", strings.Join(policy.AllowedDays, ", ")))
+		}
+	}
+
+	if policy.MaxActiveBookings > 0 {
+		active, err := s.meetingRepo.CountActiveByOrganizer(organizerID, now, excludeMeetingID)
+		if err != nil {
+			return err
+		}
+		if active >= int64(policy.MaxActiveBookings) {
+			violations.add("organizer_id", "max_active_bookings",
+				fmt.Sprintf("organizers may hold at most %d active bookings", policy.MaxActiveBookings))
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return violations
+	}
+	return nil
+}
+
+// RoomLocation returns the time zone of the room's site, or the configured
+// default for rooms that are not attached to a site.
+func (s *BookingPolicyService) RoomLocation(room *models.Room) *time.Location {
+	if name := room.SiteTimeZone(); name != "" {
+		if loc, err := models.LoadTimeZone(name); err == nil {
+			return loc
+		}
+	}
+	return s.defaultZone
+}
+
+// atTimeOfDay adds a wall-clock offset to midnight. Adding a plain duration
+// would be off by an hour on days with a DST change.
+func atTimeOfDay(dayStart time.Time, offset time.Duration) time.Time {
+	hours := int(offset / time.Hour)
+	minutes := int((offset % time.Hour) / time.Minute)
+	return time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day(), hours, minutes, 0, 0, dayStart.Location())
+}
+
+func timeOfDayLabel(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
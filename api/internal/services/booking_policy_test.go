@@ -0,0 +1,95 @@
+package services
+
+import (
+	"api/internal/models"
+	"api/internal/repositories"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeRoomRepository serves rooms from memory. Rooms are available unless
+// listed in unavailable.
+type fakeRoomRepository struct {
+	repositories.RoomRepository
+	rooms       map[uint]*models.Room
+	unavailable map[uint]bool
+}
+
+func (r *fakeRoomRepository) GetByID(id uint) (*models.Room, error) {
+	room, ok := r.rooms[id]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	return room, nil
+}
+
+func (r *fakeRoomRepository) IsRoomAvailable(roomID uint, startTime, endTime time.Time, buffers models.BookingBuffers, excludeMeetingID *uint) (bool, error) {
+	return !r.unavailable[roomID], nil
+}
+
+// fakeBookingPolicyRepository has no room overrides.
+type fakeBookingPolicyRepository struct {
+	repositories.BookingPolicyRepository
+}
+
+func (r *fakeBookingPolicyRepository) GetByRoomID(roomID uint) (*models.RoomBookingPolicy, error) {
+	return nil, nil
+}
+
+func TestCheckBookingInSiteTimeZone(t *testing.T) {
+	const (
+		berlinRoom   = 1
+		siteLessRoom = 2
+	)
+	berlin := &models.Site{TimeZone: "Europe/Berlin"}
+	rooms := &fakeRoomRepository{rooms: map[uint]*models.Room{
+		berlinRoom:   {ID: berlinRoom, Floor: &models.Floor{Building: &models.Building{Site: berlin}}},
+		siteLessRoom: {ID: siteLessRoom},
+	}}
+	hours := models.BookingPolicy{EarliestStart: "08:00", LatestEnd: "18:00"}
+	weekdays := models.BookingPolicy{AllowedDays: []string{"mon", "tue", "wed", "thu", "fri"}}
+
+	tests := []struct {
+		name   string
+		policy models.BookingPolicy
+		room   uint
+		start  string
+		end    string
+		want   string
+	}{
+		{"within hours", hours, berlinRoom, "2025-06-10T06:00:00Z", "2025-06-10T07:00:00Z", ""},
+		{"before hours in the site zone", hours, berlinRoom, "2025-06-10T05:30:00Z", "2025-06-10T06:30:00Z", "allowed_hours"},
+		{"opening on the day DST starts", hours, berlinRoom, "2025-03-30T06:00:00Z", "2025-03-30T07:00:00Z", ""},
+		{"before opening on the day DST starts", hours, berlinRoom, "2025-03-30T05:30:00Z", "2025-03-30T06:30:00Z", "allowed_hours"},
+		{"closing on the day DST ends", hours, berlinRoom, "2025-10-26T16:00:00Z", "2025-10-26T17:00:00Z", ""},
+		{"after closing on the day DST ends", hours, berlinRoom, "2025-10-26T16:30:00Z", "2025-10-26T17:30:00Z", "allowed_hours"},
+		{"room without a site uses the default zone", hours, siteLessRoom, "2025-06-10T08:00:00Z", "2025-06-10T09:00:00Z", ""},
+		{"Monday in the site zone, Sunday in UTC", weekdays, berlinRoom, "2025-06-08T22:30:00Z", "2025-06-08T23:00:00Z", ""},
+		{"Saturday in the site zone, Friday in UTC", weekdays, berlinRoom, "2025-06-13T22:30:00Z", "2025-06-13T23:00:00Z", "allowed_days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policyService := &BookingPolicyService{
+				policyRepo:    &fakeBookingPolicyRepository{},
+				roomRepo:      rooms,
+				defaultPolicy: tt.policy,
+				defaultZone:   time.UTC,
+			}
+			start, _ := time.Parse(time.RFC3339, tt.start)
+			end, _ := time.Parse(time.RFC3339, tt.end)
+
+			err := policyService.CheckBooking(tt.room, 1, start, end, nil)
+			var violations *BookingPolicyError
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("CheckBooking() error = %v, want none", err)
+			case tt.want != "" && !errors.As(err, &violations):
+				t.Errorf("CheckBooking() error = %v, want %s violation", err, tt.want)
+			case tt.want != "" && violations.Violations[0].Tag != tt.want:
+				t.Errorf("CheckBooking() violations = %v, want %s", violations.Violations, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"api/internal/models"
+	"api/internal/repositories"
+	"api/internal/utils"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+type BumpService struct {
+	meetingService *MeetingService
+	roomService    *RoomService
+	meetingRepo    repositories.MeetingRepository
+	roomRepo       repositories.RoomRepository
+	bumpRepo       repositories.MeetingBumpRepository
+	userRepo       repositories.UserRepository
+	authzService   *AuthorizationService
+	notifier       Notifier
+}
+
+func NewBumpService(meetingService *MeetingService, roomService *RoomService, meetingRepo repositories.MeetingRepository, roomRepo repositories.RoomRepository, bumpRepo repositories.MeetingBumpRepository, userRepo repositories.UserRepository, authzService *AuthorizationService, notifier Notifier) *BumpService {
+	return &BumpService{
+		meetingService: meetingService,
+		roomService:    roomService,
+		meetingRepo:    meetingRepo,
+		roomRepo:       roomRepo,
+		bumpRepo:       bumpRepo,
+		userRepo:       userRepo,
+		authzService:   authzService,
+		notifier:       notifier,
+	}
+}
+
+// BumpMeeting books a room that is taken by moving the bookings in the way,
+// including those in rooms sharing its space, to equivalent rooms. Every one
+// of them must have a lower priority than the new booking and a free
+// equivalent room, and nothing else such as a blackout may block the room, or
+// nothing is moved. The moves and the new booking are saved together. Each
+// move is recorded and its organizer notified.
+func (s *BumpService) BumpMeeting(userID uint, req *models.BumpMeetingRequest) (*models.BumpResult, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.New("validation failed")
+	}
+
+	if !req.EndTime.After(req.StartTime) {
+		return nil, errors.New("end time must be after start time")
+	}
+
+	if req.OnBehalfOf != nil {
+		return nil, errors.New("bookings cannot be bumped on behalf of another user")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	if err := s.authzService.Authorize(models.SubjectFromUser(user), models.ActionMeetingBump, nil); err != nil {
+		return nil, err
+	}
+
+	priority, err := s.meetingService.bookingPriority(user, req.Priority)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := s.roomRepo.GetByID(req.RoomID)
+	if err != nil {
+		return nil, errors.New("room not found")
+	}
+
+	blocking, err := s.meetingRepo.GetBlockingByRoom(room.ID, req.StartTime, req.EndTime, req.BookingBuffers)
+	if err != nil {
+		return nil, err
+	}
+	if len(blocking) == 0 {
+		return nil, errors.New("no booking of the room is in the way; book it directly")
+	}
+
+	ids := make([]uint, len(blocking))
+	for i, meeting := range blocking {
+		ids[i] = meeting.ID
+	}
+	free, err := s.roomRepo.IsRoomAvailableWithout(room.ID, req.StartTime, req.EndTime, req.BookingBuffers, ids)
+	if err != nil {
+		return nil, err
+	}
+	if !free {
+		return nil, errors.New("the room is blocked by a blackout, a desk booking or an inactive component, which cannot be bumped")
+	}
+
+	relocations, err := s.planBump(room, blocking, priority)
+	if err != nil {
+		return nil, err
+	}
+
+	meeting, err := s.meetingService.createMeeting(userID, &req.CreateMeetingRequest, relocations)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.BumpResult{Meeting: meeting, Bumps: []*models.MeetingBump{}}
+	for i := range relocations {
+		relocation := &relocations[i]
+		relocation.Relocated = true
+		fromRoom := relocation.Meeting.Room
+
+		bump := &models.MeetingBump{
+			MeetingID:         relocation.Meeting.ID,
+			BumpedByMeetingID: meeting.ID,
+			BumpedByUserID:    userID,
+			FromRoomID:        fromRoom.ID,
+			ToRoomID:          relocation.NewRoom.ID,
+			MeetingPriority:   relocation.Meeting.Priority,
+			BumpPriority:      meeting.Priority,
+			Reason:            req.Reason,
+		}
+		if err := s.bumpRepo.Create(bump); err != nil {
+			log.Printf("Failed to record the bump of meeting %d: %v", relocation.Meeting.ID, err)
+		}
+		result.Bumps = append(result.Bumps, bump)
+
+		s.notifier.Notify(&relocation.Meeting.Organizer, "Meeting room changed",
+			fmt.Sprintf("%q on %s was moved from %s to %s to make way for a priority booking: %s",
+				relocation.Meeting.Title, relocation.Meeting.StartTime.Format(time.RFC3339), fromRoom.Name, relocation.NewRoom.Name, req.Reason))
+	}
+
+	return result, nil
+}
+
+// GetBumps lists the bump audit trail to holders of meeting.bump.
+func (s *BumpService) GetBumps(userID uint, filter models.MeetingBumpFilter, page, limit int) ([]*models.MeetingBump, utils.PaginationMeta, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, utils.PaginationMeta{}, errors.New("user not found")
+	}
+
+	if err := s.authzService.Authorize(models.SubjectFromUser(user), models.ActionMeetingBump, nil); err != nil {
+		return nil, utils.PaginationMeta{}, err
+	}
+
+	offset := utils.GetOffset(page, limit)
+	bumps, total, err := s.bumpRepo.GetByFilter(filter, offset, limit)
+	if err != nil {
+		return nil, utils.PaginationMeta{}, err
+	}
+
+	meta := utils.CreatePaginationMeta(page, limit, total)
+	return bumps, meta, nil
+}
+
+// planBump finds a room equivalent to its own for each blocking meeting,
+// failing if any of them cannot be bumped.
+func (s *BumpService) planBump(room *models.Room, blocking []*models.Meeting, priority int) ([]models.MeetingRelocation, error) {
+	relocations := make([]models.MeetingRelocation, 0, len(blocking))
+	claimed := make(map[uint][]*models.Meeting)
+
+	for _, meeting := range blocking {
+		if meeting.Status == models.StatusInProgress {
+			return nil, fmt.Errorf("%q is in progress and cannot be bumped", meeting.Title)
+		}
+		if meeting.Priority >= priority {
+			return nil, fmt.Errorf("%w: %q has priority %d, which a booking of priority %d cannot bump",
+				ErrPermissionDenied, meeting.Title, meeting.Priority, priority)
+		}
+
+		original := room
+		if meeting.RoomID != room.ID {
+			other, err := s.roomRepo.GetByID(meeting.RoomID)
+			if err != nil {
+				return nil, errors.New("room not found")
+			}
+			original = other
+		}
+
+		newRoom, err := s.roomService.findEquivalentRoom(original, meeting, claimed, room)
+		if err != nil {
+			return nil, err
+		}
+		if newRoom == nil {
+			return nil, fmt.Errorf("no equivalent room is free for %q, so nothing was bumped", meeting.Title)
+		}
+		claimed[newRoom.ID] = append(claimed[newRoom.ID], meeting)
+
+		relocations = append(relocations, models.MeetingRelocation{Meeting: meeting, NewRoom: newRoom})
+	}
+
+	return relocations, nil
+}
+
+// relocatedMeetingIDs lists the IDs of the meetings in relocations.
+func relocatedMeetingIDs(relocations []models.MeetingRelocation) []uint {
+	ids := make([]uint, len(relocations))
+	for i, relocation := range relocations {
+		ids[i] = relocation.Meeting.ID
+	}
+	return ids
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"api/internal/config"
+	"api/internal/models"
+	"api/internal/repositories"
+	"strings"
+	"testing"
+	"time"
+)
+
+func (r *fakeRoomRepository) IsRoomAvailableWithout(roomID uint, startTime, endTime time.Time, buffers models.BookingBuffers, meetingIDs []uint) (bool, error) {
+	return !r.unavailable[roomID], nil
+}
+
+func (r *fakeRoomRepository) GetAvailableRooms(query *models.RoomAvailabilityQuery) ([]*models.Room, error) {
+	var rooms []*models.Room
+	for _, room := range r.rooms {
+		if room.IsActive && !r.unavailable[room.ID] && (query.Capacity == nil || room.Capacity >= *query.Capacity) {
+			rooms = append(rooms, room)
+		}
+	}
+	return rooms, nil
+}
+
+// fakeBumpMeetingRepository serves the meetings in the way of a bump. With
+// conflict set, saving the bump fails as if a room was booked meanwhile.
+type fakeBumpMeetingRepository struct {
+	repositories.MeetingRepository
+	blocking []*models.Meeting
+	conflict bool
+	created  []*models.Meeting
+}
+
+func (r *fakeBumpMeetingRepository) GetBlockingByRoom(roomID uint, startTime, endTime time.Time, buffers models.BookingBuffers) ([]*models.Meeting, error) {
+	return r.blocking, nil
+}
+
+func (r *fakeBumpMeetingRepository) CreateWithRelocations(meeting *models.Meeting, relocations []models.MeetingRelocation, equipment []models.EquipmentRequest) (bool, []models.EquipmentAvailability, error) {
+	if r.conflict {
+		return false, nil, nil
+	}
+	for _, relocation := range relocations {
+		relocation.Meeting.RoomID = relocation.NewRoom.ID
+	}
+	meeting.ID = uint(100 + len(r.created))
+	r.created = append(r.created, meeting)
+	return true, nil, nil
+}
+
+func (r *fakeBumpMeetingRepository) GetByID(id uint) (*models.Meeting, error) {
+	for _, meeting := range r.created {
+		if meeting.ID == id {
+			return meeting, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeBumpMeetingRepository) GetAttendeeConflicts(userIDs []uint, startTime, endTime time.Time, excludeMeetingID *uint) ([]models.AttendeeConflict, error) {
+	return nil, nil
+}
+
+func (r *fakeBumpMeetingRepository) RecordActivity(activity *models.MeetingActivity) error {
+	return nil
+}
+
+type fakeGroupRepository struct {
+	repositories.GroupRepository
+}
+
+func (r *fakeGroupRepository) GetGroupIDsForUser(userID uint) ([]uint, error) {
+	return nil, nil
+}
+
+type fakeMeetingBumpRepository struct {
+	repositories.MeetingBumpRepository
+	bumps []*models.MeetingBump
+}
+
+func (r *fakeMeetingBumpRepository) Create(bump *models.MeetingBump) error {
+	r.bumps = append(r.bumps, bump)
+	return nil
+}
+
+// newTestBumpService lets manager 1 bump bookings out of the way.
+func newTestBumpService(t *testing.T, meetings *fakeBumpMeetingRepository, rooms *fakeRoomRepository, bumps *fakeMeetingBumpRepository, notifier *recordingNotifier) *BumpService {
+	t.Helper()
+	authzService := NewAuthorizationService(newFakeRolePermissionRepository())
+	if err := authzService.Load(); err != nil {
+		t.Fatal(err)
+	}
+	users := newFakeUserRepository(&models.User{ID: 1, Role: models.RoleManager, IsActive: true})
+	groups := &fakeGroupRepository{}
+	policyService := &BookingPolicyService{
+		policyRepo:  &fakeBookingPolicyRepository{},
+		roomRepo:    rooms,
+		meetingRepo: meetings,
+		defaultZone: time.UTC,
+	}
+	meetingService := &MeetingService{
+		meetingRepo:   meetings,
+		roomRepo:      rooms,
+		userRepo:      users,
+		groupRepo:     groups,
+		authzService:  authzService,
+		policyService: policyService,
+		notifier:      notifier,
+		bookingConfig: config.BookingConfig{RolePriorities: map[string]int{"employee": 0, "manager": 1}},
+	}
+	roomService := NewRoomService(rooms, nil, nil, meetings, users, groups, authzService, policyService, notifier)
+	return NewBumpService(meetingService, roomService, meetings, rooms, bumps, users, authzService, notifier)
+}
+
+// bumpRooms has combined room 4 made of rooms 1 and 2, which seat as many as
+// it does, and room 3, which seats more.
+func bumpRooms() *fakeRoomRepository {
+	room := func(id uint, capacity int) *models.Room {
+		return &models.Room{ID: id, Name: "Room", Capacity: capacity, IsActive: true, SpaceType: models.SpaceTypeRoom}
+	}
+	rooms := map[uint]*models.Room{1: room(1, 4), 2: room(2, 4), 3: room(3, 10), 4: room(4, 4)}
+	rooms[4].Components = []models.Room{*rooms[1], *rooms[2]}
+	return &fakeRoomRepository{rooms: rooms, unavailable: map[uint]bool{}}
+}
+
+func TestBumpMeeting(t *testing.T) {
+	start := time.Now().UTC().Truncate(time.Hour).Add(24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		priority int
+		conflict bool
+		blackout bool
+		wantErr  string
+		wantRoom uint
+	}{
+		{"moves the booking out of the way", 0, false, false, "", 3},
+		{"booking of the same priority", 1, false, false, "cannot bump", 2},
+		{"room blocked by a blackout", 0, false, true, "blocked by a blackout", 2},
+		{"room booked while planning", 0, true, false, "nothing was bumped", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocking := &models.Meeting{
+				ID:        1,
+				Title:     "Standup",
+				RoomID:    2,
+				Room:      models.Room{ID: 2, Name: "Room"},
+				Organizer: models.User{ID: 2, Role: models.RoleEmployee},
+				StartTime: start,
+				EndTime:   start.Add(time.Hour),
+				Status:    models.StatusScheduled,
+				Priority:  tt.priority,
+			}
+			meetings := &fakeBumpMeetingRepository{blocking: []*models.Meeting{blocking}, conflict: tt.conflict}
+			rooms := bumpRooms()
+			rooms.unavailable[4] = tt.blackout
+			bumps := &fakeMeetingBumpRepository{}
+			notifier := &recordingNotifier{}
+			bumpService := newTestBumpService(t, meetings, rooms, bumps, notifier)
+
+			result, err := bumpService.BumpMeeting(1, &models.BumpMeetingRequest{
+				CreateMeetingRequest: models.CreateMeetingRequest{Title: "Board", RoomID: 4, StartTime: start, EndTime: start.Add(time.Hour)},
+				Reason:               "board meeting",
+			})
+
+			if blocking.RoomID != tt.wantRoom {
+				t.Errorf("bumped meeting is in room %d, want %d", blocking.RoomID, tt.wantRoom)
+			}
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("BumpMeeting() error = %v", err)
+				}
+				if len(result.Bumps) != 1 || len(bumps.bumps) != 1 || len(notifier.subjects) != 1 {
+					t.Errorf("recorded %d bumps and sent %d notifications, want 1 each", len(bumps.bumps), len(notifier.subjects))
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("BumpMeeting() error = %v, want %q", err, tt.wantErr)
+			}
+			if len(meetings.created) != 0 || len(bumps.bumps) != 0 || len(notifier.subjects) != 0 {
+				t.Errorf("failed bump created %d meetings, recorded %d bumps and sent %d notifications",
+					len(meetings.created), len(bumps.bumps), len(notifier.subjects))
+			}
+		})
+	}
+}
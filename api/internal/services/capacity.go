@@ -0,0 +1,92 @@
+package services
+
+import (
+	"api/internal/models"
+	"fmt"
+	"time"
+)
+
+// checkCapacity compares a booking of room for headcount people with the
+// room's seats under the room's capacity enforcement. Hard enforcement
+// rejects an over-capacity booking; soft enforcement accepts it and returns
+// a warning. Either way a larger room with the same features that is free
+// from startTime to endTime is suggested when there is one.
+func (s *MeetingService) checkCapacity(room *models.Room, organizer *models.User, headcount int, startTime, endTime time.Time, buffers models.BookingBuffers) (*models.CapacityWarning, error) {
+	if headcount <= room.Capacity {
+		return nil, nil
+	}
+
+	effective, err := s.policyService.GetEffectivePolicy(room.ID)
+	if err != nil {
+		return nil, err
+	}
+	enforcement := effective.Policy.CapacityEnforcement
+	if enforcement == "" || enforcement == models.CapacityEnforcementOff {
+		return nil, nil
+	}
+
+	warning := &models.CapacityWarning{
+		Capacity:  room.Capacity,
+		Headcount: headcount,
+		Message:   fmt.Sprintf("%s seats %d but the meeting has %d people", room.Name, room.Capacity, headcount),
+	}
+
+	larger, err := s.findLargerRoom(room, organizer, headcount, startTime, endTime, buffers)
+	if err != nil {
+		return nil, err
+	}
+	if larger != nil {
+		warning.SuggestedRoom = larger
+		warning.Message += fmt.Sprintf("; %s is free at that time and seats %d", larger.Name, larger.Capacity)
+	}
+
+	if enforcement == models.CapacityEnforcementHard {
+		violations := &BookingPolicyError{}
+		violations.add("attendee_ids", "capacity", warning.Message)
+		return nil, violations
+	}
+	return warning, nil
+}
+
+// findLargerRoom returns the smallest free room other than room that seats
+// headcount, offers room's features and may be booked by organizer.
+func (s *MeetingService) findLargerRoom(room *models.Room, organizer *models.User, headcount int, startTime, endTime time.Time, buffers models.BookingBuffers) (*models.Room, error) {
+	booker, err := newRoomBooker(s.groupRepo, s.authzService, models.SubjectFromUser(organizer))
+	if err != nil {
+		return nil, err
+	}
+
+	featureIDs := make([]uint, len(room.Features))
+	for i, feature := range room.Features {
+		featureIDs[i] = feature.ID
+	}
+
+	candidates, err := s.roomRepo.GetAvailableRooms(&models.RoomAvailabilityQuery{
+		StartTime:  startTime,
+		EndTime:    endTime,
+		RoomFilter: models.RoomFilter{Capacity: &headcount, FeatureIDs: featureIDs},
+		Booker:     booker,
+		Buffers:    buffers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Candidates come smallest first.
+	for _, candidate := range candidates {
+		if candidate.ID != room.ID {
+			return candidate, nil
+		}
+	}
+	return nil, nil
+}
+
+// meetingHeadcount is the number of people in a meeting: the organizer plus every
+// distinct attendee other than the organizer.
+func meetingHeadcount(organizerID uint, attendeeIDs []uint) int {
+	seen := map[uint]bool{organizerID: true}
+	for _, id := range attendeeIDs {
+		seen[id] = true
+	}
+	return len(seen)
+}
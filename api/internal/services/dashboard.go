@@ -35,10 +35,10 @@ func (s *DashboardService) GetTopActiveUsers(filter models.DashboardFilter, limi
 	return s.dashboardRepo.GetTopActiveUsers(filter, limit)
 }
 
-func (s *DashboardService) GetRecentMeetings(limit int) ([]models.Meeting, error) {
-	return s.dashboardRepo.GetRecentMeetings(limit)
+func (s *DashboardService) GetRecentMeetings(filter models.DashboardFilter, limit int) ([]models.Meeting, error) {
+	return s.dashboardRepo.GetRecentMeetings(filter, limit)
 }
 
-func (s *DashboardService) GetUpcomingMeetings7d() ([]models.Meeting, error) {
-	return s.dashboardRepo.GetUpcomingMeetings7d()
+func (s *DashboardService) GetUpcomingMeetings7d(filter models.DashboardFilter) ([]models.Meeting, error) {
+	return s.dashboardRepo.GetUpcomingMeetings7d(filter)
 }
\ No newline at end of file
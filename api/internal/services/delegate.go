@@ -0,0 +1,80 @@
+package services
+
+import (
+	"api/internal/models"
+	"api/internal/repositories"
+	"api/internal/utils"
+	"errors"
+	"fmt"
+)
+
+type DelegateService struct {
+	delegateRepo repositories.DelegateRepository
+	userRepo     repositories.UserRepository
+}
+
+func NewDelegateService(delegateRepo repositories.DelegateRepository, userRepo repositories.UserRepository) *DelegateService {
+	return &DelegateService{
+		delegateRepo: delegateRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// GrantDelegate lets another user act for principalID within the requested
+// scope, replacing any earlier grant to the same user.
+func (s *DelegateService) GrantDelegate(principalID uint, req *models.GrantDelegateRequest) (*models.DelegateGrant, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.New("validation failed")
+	}
+
+	if req.DelegateID == principalID {
+		return nil, errors.New("you cannot delegate to yourself")
+	}
+
+	delegate, err := s.userRepo.GetByID(req.DelegateID)
+	if err != nil {
+		return nil, errors.New("delegate not found")
+	}
+	if !delegate.IsActive {
+		return nil, errors.New("delegate is not active")
+	}
+
+	return s.delegateRepo.Save(&models.DelegateGrant{
+		PrincipalID: principalID,
+		DelegateID:  req.DelegateID,
+		Scope:       req.Scope,
+	})
+}
+
+// GetDelegates lists the grants userID gave and received.
+func (s *DelegateService) GetDelegates(userID uint) ([]*models.DelegateGrant, error) {
+	return s.delegateRepo.GetForUser(userID)
+}
+
+// RevokeDelegate removes a grant; either of its users may revoke it.
+func (s *DelegateService) RevokeDelegate(id, userID uint) error {
+	grant, err := s.delegateRepo.GetByID(id)
+	if err != nil {
+		return errors.New("delegate grant not found")
+	}
+
+	if !grant.IsOwnedBy(userID) {
+		return fmt.Errorf("%w: only the users of a grant can revoke it", ErrPermissionDenied)
+	}
+
+	return s.delegateRepo.Delete(id)
+}
+
+// IsDelegate reports whether principalID allowed delegateID to act for them
+// within scope.
+func (s *DelegateService) IsDelegate(principalID, delegateID uint, scope models.DelegateScope) (bool, error) {
+	return isDelegate(s.delegateRepo, principalID, delegateID, scope)
+}
+
+func isDelegate(delegateRepo repositories.DelegateRepository, principalID, delegateID uint, scope models.DelegateScope) (bool, error) {
+	grant, err := delegateRepo.GetByPair(principalID, delegateID)
+	if err != nil {
+		return false, err
+	}
+	return grant != nil && grant.Scope.Covers(scope), nil
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"api/internal/models"
+	"api/internal/repositories"
+	"api/internal/utils"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const deskDateLayout = "2006-01-02"
+
+type DeskService struct {
+	deskBookingRepo repositories.DeskBookingRepository
+	roomRepo        repositories.RoomRepository
+	userRepo        repositories.UserRepository
+	groupRepo       repositories.GroupRepository
+	authzService    *AuthorizationService
+}
+
+func NewDeskService(deskBookingRepo repositories.DeskBookingRepository, roomRepo repositories.RoomRepository, userRepo repositories.UserRepository, groupRepo repositories.GroupRepository, authzService *AuthorizationService) *DeskService {
+	return &DeskService{
+		deskBookingRepo: deskBookingRepo,
+		roomRepo:        roomRepo,
+		userRepo:        userRepo,
+		groupRepo:       groupRepo,
+		authzService:    authzService,
+	}
+}
+
+// GetDesks lists the desks matching filter.
+func (s *DeskService) GetDesks(filter models.RoomFilter, page, limit int) ([]*models.Room, utils.PaginationMeta, error) {
+	filter.SpaceType = models.SpaceTypeDesk
+
+	offset := utils.GetOffset(page, limit)
+	desks, total, err := s.roomRepo.SearchRooms("", filter, offset, limit)
+	if err != nil {
+		return nil, utils.PaginationMeta{}, err
+	}
+
+	meta := utils.CreatePaginationMeta(page, limit, total)
+	return desks, meta, nil
+}
+
+// GetAvailableDesks lists the desks matching filter that subject may book
+// and that are free for period of day. day must be midnight in the caller's
+// time zone.
+func (s *DeskService) GetAvailableDesks(subject models.Subject, filter models.RoomFilter, day time.Time, period models.DeskPeriod) ([]*models.Room, error) {
+	if !models.IsValidDeskPeriod(period) {
+		return nil, errors.New("invalid period: use full_day, morning or afternoon")
+	}
+
+	booker, err := newRoomBooker(s.groupRepo, s.authzService, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.SpaceType = models.SpaceTypeDesk
+	startTime, endTime := period.Interval(day)
+
+	return s.roomRepo.GetAvailableRooms(&models.RoomAvailabilityQuery{
+		StartTime:  startTime,
+		EndTime:    endTime,
+		RoomFilter: filter,
+		Booker:     booker,
+	})
+}
+
+// BookDesk reserves a desk for userID. The date is read in loc, and a user
+// can hold only one desk per day.
+func (s *DeskService) BookDesk(userID uint, req *models.CreateDeskBookingRequest, loc *time.Location) (*models.DeskBooking, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.New("validation failed")
+	}
+
+	period := req.Period
+	if period == "" {
+		period = models.DeskPeriodFullDay
+	}
+
+	day, err := time.ParseInLocation(deskDateLayout, req.Date, loc)
+	if err != nil {
+		return nil, errors.New("invalid date format. Use YYYY-MM-DD")
+	}
+	startTime, endTime := period.Interval(day)
+	if !endTime.After(time.Now()) {
+		return nil, errors.New("cannot book a desk for a period that has ended")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if !user.IsActive {
+		return nil, errors.New("user is not active")
+	}
+
+	if err := s.authzService.Authorize(models.SubjectFromUser(user), models.ActionDeskBook, nil); err != nil {
+		return nil, err
+	}
+
+	desk, err := s.roomRepo.GetByID(req.DeskID)
+	if err != nil || !desk.IsDesk() {
+		return nil, errors.New("desk not found")
+	}
+	if !desk.IsActive {
+		return nil, errors.New("desk is not active")
+	}
+
+	if err := checkRoomAccess(s.groupRepo, s.authzService, desk, user); err != nil {
+		return nil, err
+	}
+
+	date := day.Format(deskDateLayout)
+	booking, conflict, err := s.deskBookingRepo.Book(&models.DeskBooking{
+		DeskID:    desk.ID,
+		UserID:    userID,
+		Date:      date,
+		Period:    period,
+		StartTime: startTime,
+		EndTime:   endTime,
+		TimeZone:  loc.String(),
+		Status:    models.DeskBookingBooked,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch conflict {
+	case models.DeskConflictUserBooked:
+		return nil, fmt.Errorf("you already have a desk booked on %s", date)
+	case models.DeskConflictDeskTaken:
+		return nil, errors.New("desk is not available for the selected period")
+	}
+	return booking, nil
+}
+
+// GetDeskBookingByID returns a desk booking userID may see: their own, or
+// any with desk.booking.any.
+func (s *DeskService) GetDeskBookingByID(id, userID uint) (*models.DeskBooking, error) {
+	return s.authorizedBooking(id, userID)
+}
+
+// GetDeskBookings lists the desk bookings matching filter. Unless userID may
+// see any desk booking, only their own are listed.
+func (s *DeskService) GetDeskBookings(userID uint, filter models.DeskBookingFilter, page, limit int) ([]*models.DeskBooking, utils.PaginationMeta, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, utils.PaginationMeta{}, errors.New("user not found")
+	}
+	if err := s.authzService.Authorize(models.SubjectFromUser(user), models.ActionDeskBooking, nil); err != nil {
+		filter.UserID = &userID
+	}
+
+	offset := utils.GetOffset(page, limit)
+	bookings, total, err := s.deskBookingRepo.GetByFilter(filter, offset, limit)
+	if err != nil {
+		return nil, utils.PaginationMeta{}, err
+	}
+
+	meta := utils.CreatePaginationMeta(page, limit, total)
+	return bookings, meta, nil
+}
+
+// CancelDeskBooking frees the desk for the rest of the booked period.
+func (s *DeskService) CancelDeskBooking(id, userID uint) error {
+	booking, err := s.authorizedBooking(id, userID)
+	if err != nil {
+		return err
+	}
+
+	if booking.Status == models.DeskBookingCancelled {
+		return errors.New("desk booking is already cancelled")
+	}
+	if !booking.EndTime.After(time.Now()) {
+		return errors.New("cannot cancel a desk booking that has ended")
+	}
+
+	booking.Status = models.DeskBookingCancelled
+	return s.deskBookingRepo.UpdateStatus(booking)
+}
+
+// CheckIn records that the user has arrived at the desk. It is possible
+// during the booked period only.
+func (s *DeskService) CheckIn(id, userID uint) (*models.DeskBooking, error) {
+	booking, err := s.authorizedBooking(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch booking.Status {
+	case models.DeskBookingCheckedIn:
+		return nil, errors.New("already checked in")
+	case models.DeskBookingCancelled:
+		return nil, errors.New("desk booking is cancelled")
+	}
+
+	now := time.Now()
+	if now.Before(booking.StartTime) {
+		return nil, fmt.Errorf("check-in opens at %s", booking.StartTime.Format(time.RFC3339))
+	}
+	if !now.Before(booking.EndTime) {
+		return nil, errors.New("desk booking has ended")
+	}
+
+	booking.Status = models.DeskBookingCheckedIn
+	booking.CheckedInAt = &now
+	if err := s.deskBookingRepo.UpdateStatus(booking); err != nil {
+		return nil, err
+	}
+
+	return s.deskBookingRepo.GetByID(booking.ID)
+}
+
+func (s *DeskService) authorizedBooking(id, userID uint) (*models.DeskBooking, error) {
+	booking, err := s.deskBookingRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("desk booking not found")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	if err := s.authzService.Authorize(models.SubjectFromUser(user), models.ActionDeskBooking, booking); err != nil {
+		return nil, err
+	}
+
+	return booking, nil
+}
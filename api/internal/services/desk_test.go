@@ -0,0 +1,185 @@
+package services
+
+import (
+	"api/internal/models"
+	"api/internal/repositories"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeDeskBookingRepository applies the one-desk-per-day and free-desk rules
+// of the real repository to bookings kept in memory.
+type fakeDeskBookingRepository struct {
+	repositories.DeskBookingRepository
+	bookings []*models.DeskBooking
+	filter   models.DeskBookingFilter
+}
+
+func (r *fakeDeskBookingRepository) Book(booking *models.DeskBooking) (*models.DeskBooking, models.DeskBookingConflict, error) {
+	for _, other := range r.bookings {
+		if other.Status == models.DeskBookingCancelled {
+			continue
+		}
+		if other.UserID == booking.UserID && other.Date == booking.Date {
+			return nil, models.DeskConflictUserBooked, nil
+		}
+		if other.DeskID == booking.DeskID && other.StartTime.Before(booking.EndTime) && other.EndTime.After(booking.StartTime) {
+			return nil, models.DeskConflictDeskTaken, nil
+		}
+	}
+	booking.ID = uint(len(r.bookings) + 1)
+	r.bookings = append(r.bookings, booking)
+	return booking, models.DeskConflictNone, nil
+}
+
+func (r *fakeDeskBookingRepository) GetByID(id uint) (*models.DeskBooking, error) {
+	for _, booking := range r.bookings {
+		if booking.ID == id {
+			return booking, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (r *fakeDeskBookingRepository) GetByFilter(filter models.DeskBookingFilter, offset, limit int) ([]*models.DeskBooking, int64, error) {
+	r.filter = filter
+	return nil, 0, nil
+}
+
+func newTestDeskService(t *testing.T, bookings *fakeDeskBookingRepository, users ...*models.User) *DeskService {
+	t.Helper()
+	authzService := NewAuthorizationService(newFakeRolePermissionRepository())
+	if err := authzService.Load(); err != nil {
+		t.Fatal(err)
+	}
+	rooms := &fakeRoomRepository{rooms: map[uint]*models.Room{
+		1: {ID: 1, SpaceType: models.SpaceTypeDesk, IsActive: true},
+		2: {ID: 2, SpaceType: models.SpaceTypeDesk, IsActive: true},
+	}}
+	return NewDeskService(bookings, rooms, newFakeUserRepository(users...), nil, authzService)
+}
+
+func TestBookDesk(t *testing.T) {
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format(deskDateLayout)
+	dayAfter := time.Now().UTC().AddDate(0, 0, 2).Format(deskDateLayout)
+	existing := func() []*models.DeskBooking {
+		day, _ := time.Parse(deskDateLayout, tomorrow)
+		start, end := models.DeskPeriodMorning.Interval(day)
+		return []*models.DeskBooking{{ID: 1, DeskID: 1, UserID: 1, Date: tomorrow, Period: models.DeskPeriodMorning, StartTime: start, EndTime: end, Status: models.DeskBookingBooked}}
+	}
+
+	tests := []struct {
+		name      string
+		userID    uint
+		cancelled bool
+		req       models.CreateDeskBookingRequest
+		wantErr   string
+	}{
+		{"free desk", 2, false, models.CreateDeskBookingRequest{DeskID: 2, Date: tomorrow}, ""},
+		{"second desk on the same day", 1, false, models.CreateDeskBookingRequest{DeskID: 2, Date: tomorrow, Period: models.DeskPeriodAfternoon}, "already have a desk booked"},
+		{"same desk on another day", 1, false, models.CreateDeskBookingRequest{DeskID: 1, Date: dayAfter}, ""},
+		{"desk taken in the morning", 2, false, models.CreateDeskBookingRequest{DeskID: 1, Date: tomorrow, Period: models.DeskPeriodFullDay}, "not available"},
+		{"other half of a taken desk", 2, false, models.CreateDeskBookingRequest{DeskID: 1, Date: tomorrow, Period: models.DeskPeriodAfternoon}, ""},
+		{"cancelled booking frees the day", 1, true, models.CreateDeskBookingRequest{DeskID: 2, Date: tomorrow}, ""},
+		{"not a desk", 2, false, models.CreateDeskBookingRequest{DeskID: 3, Date: tomorrow}, "desk not found"},
+		{"period that has ended", 2, false, models.CreateDeskBookingRequest{DeskID: 2, Date: "2020-01-01"}, "has ended"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookings := &fakeDeskBookingRepository{bookings: existing()}
+			if tt.cancelled {
+				bookings.bookings[0].Status = models.DeskBookingCancelled
+			}
+			deskService := newTestDeskService(t, bookings,
+				&models.User{ID: 1, Role: models.RoleEmployee, IsActive: true},
+				&models.User{ID: 2, Role: models.RoleEmployee, IsActive: true})
+
+			booking, err := deskService.BookDesk(tt.userID, &tt.req, time.UTC)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("BookDesk() error = %v", err)
+				}
+				if booking.Date != tt.req.Date || booking.UserID != tt.userID {
+					t.Errorf("BookDesk() = %+v", booking)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("BookDesk() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBookDeskReadsDateInTimeZone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	date := time.Now().In(tokyo).AddDate(0, 0, 1).Format(deskDateLayout)
+
+	bookings := &fakeDeskBookingRepository{}
+	deskService := newTestDeskService(t, bookings, &models.User{ID: 1, Role: models.RoleEmployee, IsActive: true})
+	booking, err := deskService.BookDesk(1, &models.CreateDeskBookingRequest{DeskID: 1, Date: date}, tokyo)
+	if err != nil {
+		t.Fatalf("BookDesk() error = %v", err)
+	}
+
+	wantStart, _ := time.ParseInLocation(deskDateLayout, date, tokyo)
+	if !booking.StartTime.Equal(wantStart) || booking.TimeZone != "Asia/Tokyo" {
+		t.Errorf("booking starts %s in %s, want %s in Asia/Tokyo", booking.StartTime, booking.TimeZone, wantStart)
+	}
+}
+
+func TestGetDeskBookingsOfOthers(t *testing.T) {
+	deskID := uint(1)
+	tests := []struct {
+		name       string
+		role       models.UserRole
+		wantUserID bool
+	}{
+		{"employee only sees own bookings", models.RoleEmployee, true},
+		{"manager sees everyone's", models.RoleManager, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookings := &fakeDeskBookingRepository{}
+			deskService := newTestDeskService(t, bookings, &models.User{ID: 7, Role: tt.role, IsActive: true})
+
+			if _, _, err := deskService.GetDeskBookings(7, models.DeskBookingFilter{DeskID: &deskID}, 1, 10); err != nil {
+				t.Fatalf("GetDeskBookings() error = %v", err)
+			}
+			if restricted := bookings.filter.UserID != nil && *bookings.filter.UserID == 7; restricted != tt.wantUserID {
+				t.Errorf("listing restricted to own bookings = %v, want %v", restricted, tt.wantUserID)
+			}
+		})
+	}
+}
+
+func TestGetDeskBookingByID(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  uint
+		role    models.UserRole
+		wantErr error
+	}{
+		{"own booking", 1, models.RoleEmployee, nil},
+		{"someone else's booking", 2, models.RoleEmployee, ErrPermissionDenied},
+		{"manager", 2, models.RoleManager, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookings := &fakeDeskBookingRepository{bookings: []*models.DeskBooking{{ID: 1, DeskID: 1, UserID: 1}}}
+			deskService := newTestDeskService(t, bookings, &models.User{ID: tt.userID, Role: tt.role, IsActive: true})
+
+			if _, err := deskService.GetDeskBookingByID(1, tt.userID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetDeskBookingByID() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
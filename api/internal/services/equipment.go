@@ -0,0 +1,272 @@
+package services
+
+import (
+	"api/internal/models"
+	"api/internal/repositories"
+	"api/internal/utils"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type EquipmentService struct {
+	equipmentRepo repositories.EquipmentRepository
+	siteRepo      repositories.SiteRepository
+}
+
+func NewEquipmentService(equipmentRepo repositories.EquipmentRepository, siteRepo repositories.SiteRepository) *EquipmentService {
+	return &EquipmentService{
+		equipmentRepo: equipmentRepo,
+		siteRepo:      siteRepo,
+	}
+}
+
+func (s *EquipmentService) CreateEquipment(req *models.CreateEquipmentRequest) (*models.Equipment, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.New("validation failed")
+	}
+
+	if req.SiteID != nil {
+		if _, err := s.siteRepo.GetByID(*req.SiteID); err != nil {
+			return nil, errors.New("site not found")
+		}
+	}
+
+	equipment := &models.Equipment{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Category:    strings.TrimSpace(req.Category),
+		Quantity:    req.Quantity,
+		SiteID:      req.SiteID,
+		IsActive:    true,
+	}
+
+	return s.equipmentRepo.Create(equipment)
+}
+
+func (s *EquipmentService) GetEquipmentByID(id uint) (*models.Equipment, error) {
+	return s.equipmentRepo.GetByID(id)
+}
+
+func (s *EquipmentService) GetAllEquipment(filter models.EquipmentFilter, page, limit int) ([]*models.Equipment, utils.PaginationMeta, error) {
+	offset := utils.GetOffset(page, limit)
+	equipment, total, err := s.equipmentRepo.GetAll(filter, offset, limit)
+	if err != nil {
+		return nil, utils.PaginationMeta{}, err
+	}
+
+	meta := utils.CreatePaginationMeta(page, limit, total)
+	return equipment, meta, nil
+}
+
+// UpdateEquipment changes a piece of equipment. Lowering the quantity does not
+// touch existing bookings; it only limits new ones. A site_id of 0 makes the
+// equipment bookable at every site.
+func (s *EquipmentService) UpdateEquipment(id uint, req *models.UpdateEquipmentRequest) (*models.Equipment, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.New("validation failed")
+	}
+
+	equipment, err := s.equipmentRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("equipment not found")
+	}
+
+	if req.Name != nil {
+		equipment.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		equipment.Description = *req.Description
+	}
+	if req.Category != nil {
+		equipment.Category = strings.TrimSpace(*req.Category)
+	}
+	if req.Quantity != nil {
+		equipment.Quantity = *req.Quantity
+	}
+	if req.SiteID != nil {
+		if *req.SiteID == 0 {
+			equipment.SiteID = nil
+		} else {
+			if _, err := s.siteRepo.GetByID(*req.SiteID); err != nil {
+				return nil, errors.New("site not found")
+			}
+			equipment.SiteID = req.SiteID
+		}
+	}
+	if req.IsActive != nil {
+		equipment.IsActive = *req.IsActive
+	}
+
+	return s.equipmentRepo.Update(equipment)
+}
+
+func (s *EquipmentService) DeleteEquipment(id uint) error {
+	if _, err := s.equipmentRepo.GetByID(id); err != nil {
+		return errors.New("equipment not found")
+	}
+
+	count, err := s.equipmentRepo.CountUpcomingBookings(id, time.Now())
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("equipment is booked for %d upcoming meetings; deactivate it instead", count)
+	}
+
+	return s.equipmentRepo.Delete(id)
+}
+
+// GetAvailability reports how many units of each active piece of equipment
+// matching filter are free from startTime to endTime.
+func (s *EquipmentService) GetAvailability(filter models.EquipmentFilter, startTime, endTime time.Time) ([]models.EquipmentAvailability, error) {
+	if !endTime.After(startTime) {
+		return nil, errors.New("end time must be after start time")
+	}
+
+	equipment, err := s.equipmentRepo.GetActive(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, len(equipment))
+	for i, item := range equipment {
+		ids[i] = item.ID
+	}
+	reserved, err := s.equipmentRepo.GetReservedQuantities(ids, startTime, endTime, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	availability := make([]models.EquipmentAvailability, len(equipment))
+	for i, item := range equipment {
+		availability[i] = models.EquipmentAvailability{
+			Equipment: item,
+			Reserved:  reserved[item.ID],
+			Available: max(item.Quantity-reserved[item.ID], 0),
+		}
+	}
+	return availability, nil
+}
+
+// checkEquipment validates the equipment requested for a meeting in room from
+// startTime to endTime and returns the requests with duplicates merged. Every
+// piece must be active, bookable at the room's site and have enough units
+// free; excludeMeetingID's own bookings count as free.
+func (s *MeetingService) checkEquipment(room *models.Room, requests []models.EquipmentRequest, startTime, endTime time.Time, excludeMeetingID *uint) ([]models.EquipmentRequest, error) {
+	merged := mergeEquipmentRequests(requests)
+	if len(merged) == 0 {
+		return merged, nil
+	}
+
+	ids := make([]uint, len(merged))
+	for i, request := range merged {
+		ids[i] = request.EquipmentID
+	}
+
+	equipment, err := s.equipmentRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	reserved, err := s.equipmentRepo.GetReservedQuantities(ids, startTime, endTime, excludeMeetingID)
+	if err != nil {
+		return nil, err
+	}
+
+	violations := &BookingPolicyError{}
+	for _, request := range merged {
+		item := findEquipment(equipment, request.EquipmentID)
+		if item == nil {
+			return nil, fmt.Errorf("equipment %d not found", request.EquipmentID)
+		}
+		if !item.IsActive {
+			return nil, fmt.Errorf("%s is not active", item.Name)
+		}
+		if item.SiteID != nil && !roomOnSite(room, *item.SiteID) {
+			return nil, fmt.Errorf("%s cannot be booked for rooms at this site", item.Name)
+		}
+		if available := item.Quantity - reserved[item.ID]; request.Quantity > available {
+			violations.add("equipment", "availability", equipmentShortage(item, request.Quantity, available))
+		}
+	}
+	if len(violations.Violations) > 0 {
+		return nil, violations
+	}
+
+	return merged, nil
+}
+
+// reserveEquipment books requests for the meeting, replacing what it held
+// before. It fails when another booking took the units after checkEquipment.
+func (s *MeetingService) reserveEquipment(meeting *models.Meeting, requests []models.EquipmentRequest) error {
+	shortages, err := s.equipmentRepo.Reserve(meeting.ID, requests, meeting.StartTime, meeting.EndTime)
+	if err != nil {
+		return err
+	}
+	return equipmentShortages(shortages, requests)
+}
+
+// equipmentShortages reports the equipment of requests that was short as
+// booking policy violations, or nil when none was.
+func equipmentShortages(shortages []models.EquipmentAvailability, requests []models.EquipmentRequest) error {
+	if len(shortages) == 0 {
+		return nil
+	}
+
+	violations := &BookingPolicyError{}
+	for _, shortage := range shortages {
+		requested := 0
+		for _, request := range requests {
+			if request.EquipmentID == shortage.Equipment.ID {
+				requested = request.Quantity
+			}
+		}
+		violations.add("equipment", "availability", equipmentShortage(shortage.Equipment, requested, shortage.Available))
+	}
+	return violations
+}
+
+// bookedEquipment turns the meeting's current equipment bookings back into
+// requests, so they can be checked again when the meeting moves.
+func bookedEquipment(meeting *models.Meeting) []models.EquipmentRequest {
+	requests := make([]models.EquipmentRequest, len(meeting.Equipment))
+	for i, booking := range meeting.Equipment {
+		requests[i] = models.EquipmentRequest{EquipmentID: booking.EquipmentID, Quantity: booking.Quantity}
+	}
+	return requests
+}
+
+func mergeEquipmentRequests(requests []models.EquipmentRequest) []models.EquipmentRequest {
+	merged := []models.EquipmentRequest{}
+	index := make(map[uint]int)
+	for _, request := range requests {
+		if i, ok := index[request.EquipmentID]; ok {
+			merged[i].Quantity += request.Quantity
+			continue
+		}
+		index[request.EquipmentID] = len(merged)
+		merged = append(merged, request)
+	}
+	return merged
+}
+
+func findEquipment(equipment []*models.Equipment, id uint) *models.Equipment {
+	for _, item := range equipment {
+		if item.ID == id {
+			return item
+		}
+	}
+	return nil
+}
+
+// roomOnSite reports whether room is on siteID. It needs the room's
+// Floor.Building to be loaded.
+func roomOnSite(room *models.Room, siteID uint) bool {
+	return room.Floor != nil && room.Floor.Building != nil && room.Floor.Building.SiteID == siteID
+}
+
+func equipmentShortage(item *models.Equipment, requested, available int) string {
+	return fmt.Sprintf("%d of %s requested but only %d available for the selected time", requested, item.Name, max(available, 0))
+}
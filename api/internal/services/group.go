@@ -0,0 +1,319 @@
+package services
+
+import (
+	"api/internal/models"
+	"api/internal/repositories"
+	"api/internal/utils"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+var ErrDirectoryGroup = errors.New("members of directory groups are synced from the directory")
+
+type GroupService struct {
+	groupRepo      repositories.GroupRepository
+	userRepo       repositories.UserRepository
+	meetingRepo    repositories.MeetingRepository
+	meetingService *MeetingService
+}
+
+func NewGroupService(groupRepo repositories.GroupRepository, userRepo repositories.UserRepository, meetingRepo repositories.MeetingRepository, meetingService *MeetingService) *GroupService {
+	return &GroupService{
+		groupRepo:      groupRepo,
+		userRepo:       userRepo,
+		meetingRepo:    meetingRepo,
+		meetingService: meetingService,
+	}
+}
+
+func (s *GroupService) CreateGroup(req *models.CreateUserGroupRequest) (*models.UserGroup, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.New("validation failed")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, errors.New("group name is required")
+	}
+
+	group := &models.UserGroup{
+		Name:        name,
+		Description: req.Description,
+		Source:      models.GroupSourceManual,
+	}
+
+	for _, memberID := range req.MemberIDs {
+		member, err := s.userRepo.GetByID(memberID)
+		if err != nil {
+			return nil, errors.New("member not found")
+		}
+		group.Members = append(group.Members, *member)
+	}
+
+	return s.groupRepo.Create(group)
+}
+
+func (s *GroupService) GetGroupByID(id uint) (*models.UserGroup, error) {
+	return s.groupRepo.GetByID(id)
+}
+
+func (s *GroupService) GetAllGroups(page, limit int) ([]*models.UserGroup, utils.PaginationMeta, error) {
+	offset := utils.GetOffset(page, limit)
+	groups, total, err := s.groupRepo.GetAll(offset, limit)
+	if err != nil {
+		return nil, utils.PaginationMeta{}, err
+	}
+
+	meta := utils.CreatePaginationMeta(page, limit, total)
+	return groups, meta, nil
+}
+
+func (s *GroupService) UpdateGroup(id uint, req *models.UpdateUserGroupRequest) (*models.UserGroup, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.New("validation failed")
+	}
+
+	group, err := s.groupRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("group not found")
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, errors.New("group name is required")
+		}
+		group.Name = name
+	}
+	if req.Description != nil {
+		group.Description = *req.Description
+	}
+
+	return s.groupRepo.Update(group)
+}
+
+func (s *GroupService) DeleteGroup(id uint) error {
+	if _, err := s.groupRepo.GetByID(id); err != nil {
+		return errors.New("group not found")
+	}
+
+	return s.groupRepo.Delete(id)
+}
+
+// AddMember adds a user to a manual group and invites them to the upcoming
+// meetings the group is invited to.
+func (s *GroupService) AddMember(groupID, userID uint) error {
+	group, err := s.groupRepo.GetByID(groupID)
+	if err != nil {
+		return errors.New("group not found")
+	}
+
+	if group.Source == models.GroupSourceDirectory {
+		return ErrDirectoryGroup
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+
+	if err := s.groupRepo.AddMember(groupID, userID); err != nil {
+		return err
+	}
+	s.joinGroupMeetings(groupID, user)
+	return nil
+}
+
+// RemoveMember removes a user from a manual group and from the upcoming
+// meetings they attend only through it.
+func (s *GroupService) RemoveMember(groupID, userID uint) error {
+	group, err := s.groupRepo.GetByID(groupID)
+	if err != nil {
+		return errors.New("group not found")
+	}
+
+	if group.Source == models.GroupSourceDirectory {
+		return ErrDirectoryGroup
+	}
+
+	if err := s.groupRepo.RemoveMember(groupID, userID); err != nil {
+		return err
+	}
+	s.leaveGroupMeetings(groupID, userID)
+	return nil
+}
+
+func (s *GroupService) GetGroupIDsForUser(userID uint) ([]uint, error) {
+	return s.groupRepo.GetGroupIDsForUser(userID)
+}
+
+// SyncDirectoryGroups makes user a member of exactly the directory groups
+// given, creating the ones not seen before. Manual groups are left alone.
+func (s *GroupService) SyncDirectoryGroups(user *models.User, directoryGroups []models.DirectoryGroup) error {
+	current, err := s.groupRepo.GetDirectoryGroupsForUser(user.ID)
+	if err != nil {
+		return err
+	}
+	isMember := make(map[uint]bool, len(current))
+	for _, group := range current {
+		isMember[group.ID] = true
+	}
+
+	synced := make(map[uint]bool, len(directoryGroups))
+	for _, directoryGroup := range directoryGroups {
+		group, err := s.directoryGroup(directoryGroup)
+		if err != nil {
+			log.Printf("Failed to sync directory group %s: %v", directoryGroup.ExternalID, err)
+			continue
+		}
+		synced[group.ID] = true
+
+		if isMember[group.ID] {
+			continue
+		}
+		if err := s.groupRepo.AddMember(group.ID, user.ID); err != nil {
+			return err
+		}
+		s.joinGroupMeetings(group.ID, user)
+	}
+
+	for _, group := range current {
+		if synced[group.ID] {
+			continue
+		}
+		if err := s.groupRepo.RemoveMember(group.ID, user.ID); err != nil {
+			return err
+		}
+		s.leaveGroupMeetings(group.ID, user.ID)
+	}
+
+	return nil
+}
+
+// directoryGroup returns the group mirroring a directory group, creating it
+// or following a rename. A name already taken by another group gets the
+// directory ID appended.
+func (s *GroupService) directoryGroup(directoryGroup models.DirectoryGroup) (*models.UserGroup, error) {
+	name := strings.TrimSpace(directoryGroup.Name)
+	if name == "" {
+		name = directoryGroup.ExternalID
+	}
+	fallbackName := fmt.Sprintf("%s (%s)", name, directoryGroup.ExternalID)
+
+	group, err := s.groupRepo.GetByExternalID(directoryGroup.ExternalID)
+	if err != nil {
+		externalID := directoryGroup.ExternalID
+		group = &models.UserGroup{
+			Name:       name,
+			Source:     models.GroupSourceDirectory,
+			ExternalID: &externalID,
+		}
+		created, err := s.groupRepo.Create(group)
+		if err != nil {
+			group.Name = fallbackName
+			created, err = s.groupRepo.Create(group)
+		}
+		return created, err
+	}
+
+	if group.Name != name && group.Name != fallbackName {
+		group.Name = name
+		if _, err := s.groupRepo.Update(group); err != nil {
+			log.Printf("Failed to rename directory group %d to %q: %v", group.ID, name, err)
+		}
+	}
+	return group, nil
+}
+
+// joinGroupMeetings invites a new member of a group to the upcoming meetings
+// the group is invited to, as far as their rooms' capacity allows. Meetings
+// they already attend on an individual invitation, or organize, are left
+// alone.
+func (s *GroupService) joinGroupMeetings(groupID uint, user *models.User) {
+	if !user.IsActive {
+		return
+	}
+
+	meetings, err := s.meetingRepo.GetActiveByAttendeeGroup(groupID, time.Now())
+	if err != nil {
+		log.Printf("Failed to load the meetings of group %d: %v", groupID, err)
+		return
+	}
+
+	for _, meeting := range meetings {
+		if meeting.OrganizerID == user.ID {
+			continue
+		}
+
+		attending := false
+		for _, attendee := range meeting.Attendees {
+			if attendee.ID == user.ID {
+				attending = true
+				break
+			}
+		}
+		if attending {
+			viaGroup, err := s.attendsViaGroup(meeting.ID, user.ID, nil)
+			if err != nil || !viaGroup {
+				continue
+			}
+		} else if !s.meetingService.admitGroupMember(meeting, user) {
+			continue
+		} else if err := s.meetingRepo.AddAttendee(meeting.ID, user.ID); err != nil {
+			log.Printf("Failed to add group member %d to meeting %d: %v", user.ID, meeting.ID, err)
+			continue
+		}
+
+		if err := s.meetingRepo.AddGroupAttendee(&models.MeetingGroupAttendee{MeetingID: meeting.ID, UserID: user.ID, GroupID: groupID}); err != nil {
+			log.Printf("Failed to record group member %d of meeting %d: %v", user.ID, meeting.ID, err)
+		}
+	}
+}
+
+// leaveGroupMeetings removes a former member of a group from the upcoming
+// meetings they attended only through it.
+func (s *GroupService) leaveGroupMeetings(groupID, userID uint) {
+	meetings, err := s.meetingRepo.GetActiveByAttendeeGroup(groupID, time.Now())
+	if err != nil {
+		log.Printf("Failed to load the meetings of group %d: %v", groupID, err)
+		return
+	}
+
+	for _, meeting := range meetings {
+		viaGroup, err := s.attendsViaGroup(meeting.ID, userID, &groupID)
+		if err != nil || !viaGroup {
+			continue
+		}
+
+		if err := s.meetingRepo.RemoveGroupAttendee(meeting.ID, userID, groupID); err != nil {
+			log.Printf("Failed to remove group member %d from meeting %d: %v", userID, meeting.ID, err)
+			continue
+		}
+
+		stillInvited, err := s.attendsViaGroup(meeting.ID, userID, nil)
+		if err != nil || stillInvited {
+			continue
+		}
+		if err := s.meetingRepo.RemoveAttendee(meeting.ID, userID); err != nil {
+			log.Printf("Failed to remove group member %d from meeting %d: %v", userID, meeting.ID, err)
+		}
+	}
+}
+
+// attendsViaGroup reports whether userID attends the meeting through
+// groupID, or through any group when groupID is nil.
+func (s *GroupService) attendsViaGroup(meetingID, userID uint, groupID *uint) (bool, error) {
+	records, err := s.meetingRepo.GetGroupAttendees(meetingID)
+	if err != nil {
+		return false, err
+	}
+	for _, record := range records {
+		if record.UserID == userID && (groupID == nil || record.GroupID == *groupID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"api/internal/models"
+	"api/internal/repositories"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeGroupMeetingRepository serves the meetings a group is invited to and
+// records who joins them.
+type fakeGroupMeetingRepository struct {
+	repositories.MeetingRepository
+	meetings []*models.Meeting
+	added    []uint
+}
+
+func (r *fakeGroupMeetingRepository) GetActiveByAttendeeGroup(groupID uint, from time.Time) ([]*models.Meeting, error) {
+	return r.meetings, nil
+}
+
+func (r *fakeGroupMeetingRepository) AddAttendee(meetingID, userID uint) error {
+	r.added = append(r.added, userID)
+	return nil
+}
+
+func (r *fakeGroupMeetingRepository) AddGroupAttendee(record *models.MeetingGroupAttendee) error {
+	return nil
+}
+
+func TestJoinGroupMeetingsChecksCapacity(t *testing.T) {
+	start := time.Now().UTC().Truncate(time.Hour).Add(24 * time.Hour)
+
+	tests := []struct {
+		enforcement string
+		wantAdded   bool
+		wantNotice  string
+	}{
+		{models.CapacityEnforcementOff, true, ""},
+		{models.CapacityEnforcementSoft, true, "Meeting over capacity"},
+		{models.CapacityEnforcementHard, false, "Group member not invited"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.enforcement, func(t *testing.T) {
+			authzService := NewAuthorizationService(newFakeRolePermissionRepository())
+			if err := authzService.Load(); err != nil {
+				t.Fatal(err)
+			}
+			room := &models.Room{ID: 1, Name: "Huddle", Capacity: 2, IsActive: true}
+			rooms := &fakeRoomRepository{rooms: map[uint]*models.Room{1: room}, unavailable: map[uint]bool{}}
+			meetings := &fakeGroupMeetingRepository{meetings: []*models.Meeting{{
+				ID:        1,
+				Title:     "Retro",
+				RoomID:    1,
+				Room:      *room,
+				Organizer: models.User{ID: 1, Role: models.RoleEmployee},
+				StartTime: start,
+				EndTime:   start.Add(time.Hour),
+				Status:    models.StatusScheduled,
+				Attendees: []models.User{{ID: 2}},
+			}}}
+			notifier := &recordingNotifier{}
+			meetingService := &MeetingService{
+				meetingRepo:  meetings,
+				roomRepo:     rooms,
+				groupRepo:    &fakeGroupRepository{},
+				authzService: authzService,
+				policyService: &BookingPolicyService{
+					policyRepo:    &fakeBookingPolicyRepository{},
+					roomRepo:      rooms,
+					defaultPolicy: models.BookingPolicy{CapacityEnforcement: tt.enforcement},
+					defaultZone:   time.UTC,
+				},
+				notifier: notifier,
+			}
+			groupService := NewGroupService(nil, nil, meetings, meetingService)
+
+			groupService.joinGroupMeetings(1, &models.User{ID: 3, IsActive: true})
+
+			if added := len(meetings.added) == 1; added != tt.wantAdded {
+				t.Errorf("member added = %v, want %v", added, tt.wantAdded)
+			}
+			if got := strings.Join(notifier.subjects, ", "); got != tt.wantNotice {
+				t.Errorf("notifications = %q, want %q", got, tt.wantNotice)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"api/internal/models"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// ConfirmMeeting turns a tentative hold into a booking. Rooms that need
+// sign-off send the confirmed booking for approval.
+func (s *MeetingService) ConfirmMeeting(id, userID uint) (*models.Meeting, error) {
+	meeting, err := s.meetingRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("meeting not found")
+	}
+
+	if err := s.authorize(userID, models.ActionMeetingStatus, meeting); err != nil {
+		return nil, err
+	}
+
+	if meeting.Status != models.StatusHeld {
+		return nil, errors.New("only held meetings can be confirmed")
+	}
+	if meeting.HoldExpiresAt != nil && !meeting.HoldExpiresAt.After(time.Now()) {
+		return nil, errors.New("the hold has expired")
+	}
+
+	meeting.HoldExpiresAt = nil
+	needsApproval := s.needsApproval(&meeting.Room, &meeting.Organizer)
+	if needsApproval {
+		s.requestApproval(meeting)
+	} else {
+		meeting.Status = models.StatusScheduled
+	}
+
+	updated, err := s.meetingRepo.UpdateHoldState(meeting, models.StatusHeld)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, errors.New("the hold has expired")
+	}
+
+	if needsApproval {
+		s.notifyApprovers(meeting)
+	}
+	return s.meetingRepo.GetByID(id)
+}
+
+// ExpireHolds cancels holds that were not confirmed in time, offering their
+// slots to the waitlist, and returns how many were released. Holds confirmed
+// meanwhile are left alone.
+func (s *MeetingService) ExpireHolds() (int, error) {
+	meetings, err := s.meetingRepo.GetExpiredHolds(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, meeting := range meetings {
+		startTime, endTime := meeting.BlockedInterval(&meeting.Room)
+
+		meeting.Status = models.StatusCancelled
+		updated, err := s.meetingRepo.UpdateHoldState(meeting, models.StatusHeld)
+		if err != nil {
+			return released, err
+		}
+		if !updated {
+			continue
+		}
+		released++
+
+		s.notifier.Notify(&meeting.Organizer, "Tentative booking released",
+			fmt.Sprintf("Your hold on %s for %q from %s to %s expired and the room was released",
+				meeting.Room.Name, meeting.Title, meeting.StartTime.Format(time.RFC3339), meeting.EndTime.Format(time.RFC3339)))
+		s.offerFreedSlot(meeting.RoomID, startTime, endTime)
+	}
+
+	return released, nil
+}
+
+// StartHoldSweeper periodically releases expired holds.
+func (s *MeetingService) StartHoldSweeper(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if _, err := s.ExpireHolds(); err != nil {
+				log.Printf("Failed to release expired holds: %v", err)
+			}
+		}
+	}()
+}
+
+// holdExpiry is when a hold placed now on a meeting starting at startTime
+// lapses: at holdUntil if given, else after the configured TTL, and never
+// after the meeting starts.
+func (s *MeetingService) holdExpiry(holdUntil *time.Time, startTime time.Time) (time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(s.bookingConfig.HoldTTL)
+	if holdUntil != nil {
+		if !holdUntil.After(now) {
+			return time.Time{}, errors.New("hold_until must be in the future")
+		}
+		expiresAt = *holdUntil
+	}
+
+	if startTime.Before(expiresAt) {
+		expiresAt = startTime
+	}
+	return expiresAt, nil
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"api/internal/models"
+	"api/internal/repositories"
+	"testing"
+	"time"
+)
+
+// fakeHoldMeetingRepository serves expired holds. Holds listed in confirmed
+// were confirmed after they were loaded, so releasing them changes nothing.
+type fakeHoldMeetingRepository struct {
+	repositories.MeetingRepository
+	holds     []*models.Meeting
+	confirmed map[uint]bool
+	released  []uint
+}
+
+func (r *fakeHoldMeetingRepository) GetExpiredHolds(now time.Time) ([]*models.Meeting, error) {
+	return r.holds, nil
+}
+
+func (r *fakeHoldMeetingRepository) UpdateHoldState(meeting *models.Meeting, from models.MeetingStatus) (bool, error) {
+	if r.confirmed[meeting.ID] {
+		return false, nil
+	}
+	r.released = append(r.released, meeting.ID)
+	return true, nil
+}
+
+func TestExpireHoldsSkipsConfirmedHolds(t *testing.T) {
+	start := time.Now().UTC().Truncate(time.Hour).Add(24 * time.Hour)
+	hold := func(id, roomID uint) *models.Meeting {
+		return &models.Meeting{
+			ID:        id,
+			RoomID:    roomID,
+			Room:      models.Room{ID: roomID},
+			StartTime: start,
+			EndTime:   start.Add(time.Hour),
+			Status:    models.StatusHeld,
+		}
+	}
+
+	meetings := &fakeHoldMeetingRepository{
+		holds:     []*models.Meeting{hold(1, 1), hold(2, 2)},
+		confirmed: map[uint]bool{2: true},
+	}
+	waitlist := &fakeWaitlistRepository{entries: []*models.WaitlistEntry{
+		waitlistEntry(1, 1, start, time.Hour),
+		waitlistEntry(2, 2, start, time.Hour),
+	}}
+	meetingService := newTestWaitlistService(waitlist)
+	meetingService.meetingRepo = meetings
+	notifier := &recordingNotifier{}
+	meetingService.notifier = notifier
+
+	released, err := meetingService.ExpireHolds()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if released != 1 || len(meetings.released) != 1 || meetings.released[0] != 1 {
+		t.Errorf("released %d holds (%v), want only hold 1", released, meetings.released)
+	}
+	if got := waitlist.status(1); got != models.WaitlistOffered {
+		t.Errorf("entry for the released slot is %s, want offered", got)
+	}
+	if got := waitlist.status(2); got != models.WaitlistWaiting {
+		t.Errorf("entry for the confirmed hold is %s, want waiting", got)
+	}
+	if len(notifier.subjects) != 2 {
+		t.Errorf("sent %v, want a release notice and a waitlist offer", notifier.subjects)
+	}
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"api/internal/models"
+	"api/internal/repositories"
+	"api/internal/utils"
+	"errors"
+	"strings"
+)
+
+type LocationService struct {
+	siteRepo     repositories.SiteRepository
+	buildingRepo repositories.BuildingRepository
+	floorRepo    repositories.FloorRepository
+}
+
+func NewLocationService(siteRepo repositories.SiteRepository, buildingRepo repositories.BuildingRepository, floorRepo repositories.FloorRepository) *LocationService {
+	return &LocationService{
+		siteRepo:     siteRepo,
+		buildingRepo: buildingRepo,
+		floorRepo:    floorRepo,
+	}
+}
+
+func (s *LocationService) CreateSite(req *models.CreateSiteRequest) (*models.Site, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.New("validation failed")
+	}
+
+	if _, err := models.LoadTimeZone(req.TimeZone); err != nil {
+		return nil, err
+	}
+
+	site := &models.Site{
+		Name:     strings.TrimSpace(req.Name),
+		TimeZone: req.TimeZone,
+		Address:  req.Address,
+		City:     req.City,
+		Country:  req.Country,
+	}
+
+	return s.siteRepo.Create(site)
+}
+
+func (s *LocationService) GetSiteByID(id uint) (*models.Site, error) {
+	return s.siteRepo.GetByID(id)
+}
+
+func (s *LocationService) GetAllSites(page, limit int) ([]*models.Site, utils.PaginationMeta, error) {
+	offset := utils.GetOffset(page, limit)
+	sites, total, err := s.siteRepo.GetAll(offset, limit)
+	if err != nil {
+		return nil, utils.PaginationMeta{}, err
+	}
+
+	return sites, utils.CreatePaginationMeta(page, limit, total), nil
+}
+
+func (s *LocationService) UpdateSite(id uint, req *models.UpdateSiteRequest) (*models.Site, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.New("validation failed")
+	}
+
+	site, err := s.siteRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("site not found")
+	}
+
+	if req.Name != nil {
+		site.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.TimeZone != nil {
+		if _, err := models.LoadTimeZone(*req.TimeZone); err != nil {
+			return nil, err
+		}
+		site.TimeZone = *req.TimeZone
+	}
+	if req.Address != nil {
+		site.Address = *req.Address
+	}
+	if req.City != nil {
+		site.City = *req.City
+	}
+	if req.Country != nil {
+		site.Country = *req.Country
+	}
+
+	return s.siteRepo.Update(site)
+}
+
+func (s *LocationService) DeleteSite(id uint) error {
+	if _, err := s.siteRepo.GetByID(id); err != nil {
+		return errors.New("site not found")
+	}
+
+	count, err := s.siteRepo.CountBuildings(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("site still has buildings")
+	}
+
+	return s.siteRepo.Delete(id)
+}
+
+func (s *LocationService) CreateBuilding(req *models.CreateBuildingRequest) (*models.Building, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.New("validation failed")
+	}
+
+	if _, err := s.siteRepo.GetByID(req.SiteID); err != nil {
+		return nil, errors.New("site not found")
+	}
+
+	building := &models.Building{
+		SiteID:  req.SiteID,
+		Name:    strings.TrimSpace(req.Name),
+		Address: req.Address,
+	}
+
+	return s.buildingRepo.Create(building)
+}
+
+func (s *LocationService) GetBuildingByID(id uint) (*models.Building, error) {
+	return s.buildingRepo.GetByID(id)
+}
+
+func (s *LocationService) GetAllBuildings(siteID *uint, page, limit int) ([]*models.Building, utils.PaginationMeta, error) {
+	offset := utils.GetOffset(page, limit)
+	buildings, total, err := s.buildingRepo.GetAll(siteID, offset, limit)
+	if err != nil {
+		return nil, utils.PaginationMeta{}, err
+	}
+
+	return buildings, utils.CreatePaginationMeta(page, limit, total), nil
+}
+
+func (s *LocationService) UpdateBuilding(id uint, req *models.UpdateBuildingRequest) (*models.Building, error) {
+	building, err := s.buildingRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("building not found")
+	}
+
+	if req.Name != nil {
+		building.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Address != nil {
+		building.Address = *req.Address
+	}
+
+	return s.buildingRepo.Update(building)
+}
+
+func (s *LocationService) DeleteBuilding(id uint) error {
+	if _, err := s.buildingRepo.GetByID(id); err != nil {
+		return errors.New("building not found")
+	}
+
+	count, err := s.buildingRepo.CountFloors(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("building still has floors")
+	}
+
+	return s.buildingRepo.Delete(id)
+}
+
+func (s *LocationService) CreateFloor(req *models.CreateFloorRequest) (*models.Floor, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.New("validation failed")
+	}
+
+	if _, err := s.buildingRepo.GetByID(req.BuildingID); err != nil {
+		return nil, errors.New("building not found")
+	}
+
+	floor := &models.Floor{
+		BuildingID: req.BuildingID,
+		Name:       strings.TrimSpace(req.Name),
+		Level:      req.Level,
+	}
+
+	return s.floorRepo.Create(floor)
+}
+
+func (s *LocationService) GetFloorByID(id uint) (*models.Floor, error) {
+	return s.floorRepo.GetByID(id)
+}
+
+func (s *LocationService) GetAllFloors(buildingID *uint, page, limit int) ([]*models.Floor, utils.PaginationMeta, error) {
+	offset := utils.GetOffset(page, limit)
+	floors, total, err := s.floorRepo.GetAll(buildingID, offset, limit)
+	if err != nil {
+		return nil, utils.PaginationMeta{}, err
+	}
+
+	return floors, utils.CreatePaginationMeta(page, limit, total), nil
+}
+
+func (s *LocationService) UpdateFloor(id uint, req *models.UpdateFloorRequest) (*models.Floor, error) {
+	floor, err := s.floorRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("floor not found")
+	}
+
+	if req.Name != nil {
+		floor.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Level != nil {
+		floor.Level = *req.Level
+	}
+
+	return s.floorRepo.Update(floor)
+}
+
+func (s *LocationService) DeleteFloor(id uint) error {
+	if _, err := s.floorRepo.GetByID(id); err != nil {
+		return errors.New("floor not found")
+	}
+
+	count, err := s.floorRepo.CountRooms(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errors.New("floor still has rooms")
+	}
+
+	return s.floorRepo.Delete(id)
+}
@@ -1,32 +1,75 @@
 package services
 
 import (
+	"api/internal/config"
 	"api/internal/models"
 	"api/internal/repositories"
 	"api/internal/utils"
 	"errors"
+	"fmt"
+	"log"
 	"time"
 )
 
+// ErrRoomRestricted is returned when a room's booking ACL does not admit the
+// user making the booking.
+var ErrRoomRestricted = fmt.Errorf("%w: room is restricted", ErrPermissionDenied)
+
+// ErrDeskNotMeetingRoom is returned when a meeting is booked into a desk.
+// Desks are reserved with desk bookings.
+var ErrDeskNotMeetingRoom = errors.New("desks cannot be booked for meetings; use a desk booking")
+
+const maxMeetingOccurrences = 500
+
 type MeetingService struct {
-	meetingRepo repositories.MeetingRepository
-	roomRepo    repositories.RoomRepository
-	userRepo    repositories.UserRepository
+	meetingRepo   repositories.MeetingRepository
+	roomRepo      repositories.RoomRepository
+	userRepo      repositories.UserRepository
+	groupRepo     repositories.GroupRepository
+	equipmentRepo repositories.EquipmentRepository
+	waitlistRepo  repositories.WaitlistRepository
+	delegateRepo  repositories.DelegateRepository
+	authzService  *AuthorizationService
+	policyService *BookingPolicyService
+	notifier      Notifier
+	bookingConfig config.BookingConfig
 }
 
-func NewMeetingService(meetingRepo repositories.MeetingRepository, roomRepo repositories.RoomRepository, userRepo repositories.UserRepository) *MeetingService {
+func NewMeetingService(meetingRepo repositories.MeetingRepository, roomRepo repositories.RoomRepository, userRepo repositories.UserRepository, groupRepo repositories.GroupRepository, equipmentRepo repositories.EquipmentRepository, waitlistRepo repositories.WaitlistRepository, delegateRepo repositories.DelegateRepository, authzService *AuthorizationService, policyService *BookingPolicyService, notifier Notifier, bookingConfig config.BookingConfig) *MeetingService {
 	return &MeetingService{
-		meetingRepo: meetingRepo,
-		roomRepo:    roomRepo,
-		userRepo:    userRepo,
+		meetingRepo:   meetingRepo,
+		roomRepo:      roomRepo,
+		userRepo:      userRepo,
+		groupRepo:     groupRepo,
+		equipmentRepo: equipmentRepo,
+		waitlistRepo:  waitlistRepo,
+		delegateRepo:  delegateRepo,
+		authzService:  authzService,
+		policyService: policyService,
+		notifier:      notifier,
+		bookingConfig: bookingConfig,
 	}
 }
 
-func (s *MeetingService) CreateMeeting(organizerID uint, req *models.CreateMeetingRequest) (*models.Meeting, error) {
+// CreateMeeting books a meeting organized by userID, or by the user named in
+// req.OnBehalfOf when userID is their delegate.
+func (s *MeetingService) CreateMeeting(userID uint, req *models.CreateMeetingRequest) (*models.Meeting, error) {
+	return s.createMeeting(userID, req, nil)
+}
+
+// createMeeting books req, first moving the meetings of relocations to their
+// new rooms when it bumps them. The moves, the booking and its equipment are
+// saved together or not at all.
+func (s *MeetingService) createMeeting(userID uint, req *models.CreateMeetingRequest, relocations []models.MeetingRelocation) (*models.Meeting, error) {
 	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
 		return nil, errors.New("validation failed")
 	}
 
+	organizerID, err := s.actingFor(userID, req.OnBehalfOf, models.DelegateScopeBook)
+	if err != nil {
+		return nil, err
+	}
+
 	if req.EndTime.Before(req.StartTime) {
 		return nil, errors.New("end time must be after start time")
 	}
@@ -35,6 +78,14 @@ func (s *MeetingService) CreateMeeting(organizerID uint, req *models.CreateMeeti
 		return nil, errors.New("meeting start time cannot be in the past")
 	}
 
+	if err := validateRecurrence(req.IsRecurring, req.RecurrencePattern); err != nil {
+		return nil, err
+	}
+
+	if req.HoldUntil != nil && !req.Hold {
+		return nil, errors.New("hold_until requires hold")
+	}
+
 	room, err := s.roomRepo.GetByID(req.RoomID)
 	if err != nil {
 		return nil, errors.New("room not found")
@@ -44,7 +95,16 @@ func (s *MeetingService) CreateMeeting(organizerID uint, req *models.CreateMeeti
 		return nil, errors.New("room is not active")
 	}
 
-	available, err := s.roomRepo.IsRoomAvailable(req.RoomID, req.StartTime, req.EndTime, nil)
+	if room.IsDesk() {
+		return nil, ErrDeskNotMeetingRoom
+	}
+
+	var available bool
+	if len(relocations) > 0 {
+		available, err = s.roomRepo.IsRoomAvailableWithout(req.RoomID, req.StartTime, req.EndTime, req.BookingBuffers, relocatedMeetingIDs(relocations))
+	} else {
+		available, err = s.roomRepo.IsRoomAvailable(req.RoomID, req.StartTime, req.EndTime, req.BookingBuffers, nil)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -61,6 +121,49 @@ func (s *MeetingService) CreateMeeting(organizerID uint, req *models.CreateMeeti
 		return nil, errors.New("organizer is not active")
 	}
 
+	if err := s.authzService.Authorize(models.SubjectFromUser(organizer), models.ActionMeetingCreate, nil); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkRoomAccess(room, organizer); err != nil {
+		return nil, err
+	}
+
+	priority, err := s.bookingPriority(organizer, req.Priority)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.policyService.CheckBooking(req.RoomID, organizerID, req.StartTime, req.EndTime, nil); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeGroupInvites(organizerID, req.AttendeeGroupIDs, nil); err != nil {
+		return nil, err
+	}
+
+	// Groups count with their current members.
+	groups, groupMembers, err := s.expandAttendeeGroups(organizerID, req.AttendeeGroupIDs)
+	if err != nil {
+		return nil, err
+	}
+	attendeeIDs := attendeeUnion(req.AttendeeIDs, groupMembers)
+
+	capacityWarning, err := s.checkCapacity(room, organizer, meetingHeadcount(organizerID, attendeeIDs), req.StartTime, req.EndTime, req.BookingBuffers)
+	if err != nil {
+		return nil, err
+	}
+
+	attendeeConflicts, err := s.meetingRepo.GetAttendeeConflicts(attendeeIDs, req.StartTime, req.EndTime, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	equipment, err := s.checkEquipment(room, req.Equipment, req.StartTime, req.EndTime, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	meeting := &models.Meeting{
 		Title:             req.Title,
 		Description:       req.Description,
@@ -71,31 +174,79 @@ func (s *MeetingService) CreateMeeting(organizerID uint, req *models.CreateMeeti
 		Status:            models.StatusScheduled,
 		IsRecurring:       req.IsRecurring,
 		RecurrencePattern: req.RecurrencePattern,
+		TimeZone:          s.policyService.RoomLocation(room).String(),
+		Priority:          priority,
+		BookingBuffers:    req.BookingBuffers,
 	}
 
-	createdMeeting, err := s.meetingRepo.Create(meeting)
-	if err != nil {
-		return nil, err
+	if req.TimeZone != "" {
+		if _, err := models.LoadTimeZone(req.TimeZone); err != nil {
+			return nil, err
+		}
+		meeting.TimeZone = req.TimeZone
 	}
 
-	if len(req.AttendeeIDs) > 0 {
-		for _, attendeeID := range req.AttendeeIDs {
-			if attendeeID == organizerID {
-				continue
-			}
+	// A hold asks for approval only once it is confirmed.
+	needsApproval := false
+	if req.Hold {
+		expiresAt, err := s.holdExpiry(req.HoldUntil, req.StartTime)
+		if err != nil {
+			return nil, err
+		}
+		meeting.Status = models.StatusHeld
+		meeting.HoldExpiresAt = &expiresAt
+	} else if needsApproval = s.needsApproval(room, organizer); needsApproval {
+		s.requestApproval(meeting)
+	}
 
-			attendee, err := s.userRepo.GetByID(attendeeID)
-			if err != nil || !attendee.IsActive {
-				continue
-			}
+	var createdMeeting *models.Meeting
+	if len(relocations) > 0 {
+		free, shortages, err := s.meetingRepo.CreateWithRelocations(meeting, relocations, equipment)
+		if err != nil {
+			return nil, err
+		}
+		if !free {
+			return nil, errors.New("a room was booked while the bump was planned, so nothing was bumped")
+		}
+		if err := equipmentShortages(shortages, equipment); err != nil {
+			return nil, err
+		}
+		if createdMeeting, err = s.meetingRepo.GetByID(meeting.ID); err != nil {
+			return nil, err
+		}
+	} else {
+		createdMeeting, err = s.meetingRepo.Create(meeting)
+		if err != nil {
+			return nil, err
+		}
 
-			if err := s.meetingRepo.AddAttendee(createdMeeting.ID, attendeeID); err != nil {
-				continue
+		if len(equipment) > 0 {
+			if err := s.reserveEquipment(createdMeeting, equipment); err != nil {
+				if deleteErr := s.meetingRepo.Delete(createdMeeting.ID); deleteErr != nil {
+					return nil, fmt.Errorf("%w; removing the meeting failed: %v", err, deleteErr)
+				}
+				return nil, err
 			}
 		}
 	}
 
-	return s.meetingRepo.GetByID(createdMeeting.ID)
+	if needsApproval {
+		s.notifyApprovers(createdMeeting)
+	}
+
+	if len(req.AttendeeIDs) > 0 || len(groups) > 0 {
+		s.setAttendees(createdMeeting, req.AttendeeIDs, groups, groupMembers)
+	}
+
+	s.recordActivity(createdMeeting.ID, models.MeetingActivityCreated, userID, organizerID)
+
+	createdMeeting, err = s.meetingRepo.GetByID(createdMeeting.ID)
+	if err != nil {
+		return nil, err
+	}
+	createdMeeting.CapacityWarning = capacityWarning
+	createdMeeting.AttendeeConflicts = attendeeConflicts
+	return createdMeeting, nil
 }
 
 func (s *MeetingService) GetMeetingByID(id uint) (*models.Meeting, error) {
@@ -113,23 +264,39 @@ func (s *MeetingService) GetAllMeetings(page, limit int) ([]*models.Meeting, uti
 	return meetings, meta, nil
 }
 
-func (s *MeetingService) UpdateMeeting(id uint, userID uint, req *models.UpdateMeetingRequest) (*models.Meeting, error) {
+// UpdateMeeting changes a meeting as userID, or as the user named in
+// req.OnBehalfOf when userID manages their meetings.
+func (s *MeetingService) UpdateMeeting(id uint, actorID uint, req *models.UpdateMeetingRequest) (*models.Meeting, error) {
 	meeting, err := s.meetingRepo.GetByID(id)
 	if err != nil {
 		return nil, errors.New("meeting not found")
 	}
 
-	if meeting.OrganizerID != userID {
-		user, err := s.userRepo.GetByID(userID)
-		if err != nil || !user.IsManager() {
-			return nil, errors.New("only organizer or manager can update meeting")
-		}
+	userID, err := s.actingFor(actorID, req.OnBehalfOf, models.DelegateScopeManage)
+	if err != nil {
+		return nil, err
 	}
 
-	if meeting.Status == models.StatusCompleted || meeting.Status == models.StatusCancelled {
-		return nil, errors.New("cannot update completed or cancelled meeting")
+	if err := s.authorize(userID, models.ActionMeetingUpdate, meeting); err != nil {
+		return nil, err
+	}
+
+	if meeting.Status == models.StatusCompleted || meeting.Status == models.StatusCancelled || meeting.Status == models.StatusRejected {
+		return nil, errors.New("cannot update completed, cancelled or rejected meeting")
 	}
 
+	if req.Status != nil && (meeting.Status == models.StatusPendingApproval ||
+		*req.Status == models.StatusPendingApproval || *req.Status == models.StatusRejected) {
+		return nil, errors.New("approval status can only be changed by approving or rejecting the meeting")
+	}
+
+	if req.Status != nil && (*req.Status == models.StatusHeld ||
+		meeting.Status == models.StatusHeld && *req.Status != models.StatusCancelled) {
+		return nil, errors.New("a hold can only be confirmed or cancelled")
+	}
+
+	previous := *meeting
+
 	if req.Title != nil {
 		meeting.Title = *req.Title
 	}
@@ -137,7 +304,17 @@ func (s *MeetingService) UpdateMeeting(id uint, userID uint, req *models.UpdateM
 		meeting.Description = *req.Description
 	}
 
-	if req.StartTime != nil || req.EndTime != nil {
+	buffers := meeting.BookingBuffers
+	if req.SetupMinutes != nil {
+		buffers.SetupMinutes = req.SetupMinutes
+	}
+	if req.CleanupMinutes != nil {
+		buffers.CleanupMinutes = req.CleanupMinutes
+	}
+
+	// Changing only the buffers needs a free room but no new approval.
+	rebooked := req.StartTime != nil || req.EndTime != nil || req.RoomID != nil
+	if rebooked || req.SetupMinutes != nil || req.CleanupMinutes != nil {
 		startTime := meeting.StartTime
 		endTime := meeting.EndTime
 
@@ -160,7 +337,7 @@ func (s *MeetingService) UpdateMeeting(id uint, userID uint, req *models.UpdateM
 			roomID = *req.RoomID
 		}
 
-		available, err := s.roomRepo.IsRoomAvailable(roomID, startTime, endTime, &meeting.ID)
+		available, err := s.roomRepo.IsRoomAvailable(roomID, startTime, endTime, buffers, &meeting.ID)
 		if err != nil {
 			return nil, err
 		}
@@ -170,6 +347,7 @@ func (s *MeetingService) UpdateMeeting(id uint, userID uint, req *models.UpdateM
 
 		meeting.StartTime = startTime
 		meeting.EndTime = endTime
+		meeting.BookingBuffers = buffers
 	}
 
 	if req.RoomID != nil {
@@ -180,13 +358,104 @@ func (s *MeetingService) UpdateMeeting(id uint, userID uint, req *models.UpdateM
 		if !room.IsActive {
 			return nil, errors.New("room is not active")
 		}
+		if room.IsDesk() {
+			return nil, ErrDeskNotMeetingRoom
+		}
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil {
+			return nil, errors.New("user not found")
+		}
+		if err := s.checkRoomAccess(room, user); err != nil {
+			return nil, err
+		}
 		meeting.RoomID = *req.RoomID
+		meeting.Room = *room
 	}
 
 	if req.Status != nil {
 		meeting.Status = *req.Status
 	}
 
+	// Moving a booking into or within a room that needs sign-off sends it
+	// back for approval; moving a pending booking elsewhere schedules it.
+	needsApproval := false
+	if rebooked {
+		if err := s.policyService.CheckBooking(meeting.RoomID, meeting.OrganizerID, meeting.StartTime, meeting.EndTime, &meeting.ID); err != nil {
+			return nil, err
+		}
+
+		user, err := s.userRepo.GetByID(userID)
+		if err != nil {
+			return nil, errors.New("user not found")
+		}
+		switch {
+		case meeting.Status == models.StatusHeld:
+			if meeting.HoldExpiresAt != nil && meeting.StartTime.Before(*meeting.HoldExpiresAt) {
+				expiresAt := meeting.StartTime
+				meeting.HoldExpiresAt = &expiresAt
+			}
+		case s.needsApproval(&meeting.Room, user):
+			needsApproval = true
+			s.requestApproval(meeting)
+		case meeting.Status == models.StatusPendingApproval:
+			meeting.Status = models.StatusScheduled
+			meeting.ApprovalExpiresAt = nil
+		}
+	}
+
+	// Changing the invitees or the groups keeps the other as it is.
+	attendeesChanged := req.AttendeeIDs != nil || req.AttendeeGroupIDs != nil
+	var individualIDs []uint
+	var groups []*models.UserGroup
+	var groupMembers map[uint][]uint
+	if attendeesChanged {
+		individualIDs = req.AttendeeIDs
+		if individualIDs == nil {
+			if individualIDs, err = s.individualAttendeeIDs(meeting); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := s.authorizeGroupInvites(userID, req.AttendeeGroupIDs, meeting.AttendeeGroups); err != nil {
+			return nil, err
+		}
+
+		groupIDs := req.AttendeeGroupIDs
+		if groupIDs == nil {
+			for _, group := range meeting.AttendeeGroups {
+				groupIDs = append(groupIDs, group.ID)
+			}
+		}
+		if groups, groupMembers, err = s.expandAttendeeGroups(meeting.OrganizerID, groupIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	var attendeeIDs []uint
+	if attendeesChanged {
+		attendeeIDs = attendeeUnion(individualIDs, groupMembers)
+	} else {
+		for _, attendee := range meeting.Attendees {
+			attendeeIDs = append(attendeeIDs, attendee.ID)
+		}
+	}
+
+	var capacityWarning *models.CapacityWarning
+	if attendeesChanged || req.RoomID != nil {
+		capacityWarning, err = s.checkCapacity(&meeting.Room, &meeting.Organizer, meetingHeadcount(meeting.OrganizerID, attendeeIDs),
+			meeting.StartTime, meeting.EndTime, meeting.BookingBuffers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var attendeeConflicts []models.AttendeeConflict
+	if attendeesChanged || rebooked {
+		if attendeeConflicts, err = s.meetingRepo.GetAttendeeConflicts(attendeeIDs, meeting.StartTime, meeting.EndTime, &meeting.ID); err != nil {
+			return nil, err
+		}
+	}
+
 	if req.IsRecurring != nil {
 		meeting.IsRecurring = *req.IsRecurring
 	}
@@ -195,42 +464,93 @@ func (s *MeetingService) UpdateMeeting(id uint, userID uint, req *models.UpdateM
 		meeting.RecurrencePattern = *req.RecurrencePattern
 	}
 
-	updatedMeeting, err := s.meetingRepo.Update(meeting)
-	if err != nil {
+	if err := validateRecurrence(meeting.IsRecurring, meeting.RecurrencePattern); err != nil {
 		return nil, err
 	}
 
-	if req.AttendeeIDs != nil {
-		currentAttendees, _ := s.meetingRepo.GetMeetingAttendees(meeting.ID)
-		currentAttendeeMap := make(map[uint]bool)
-		for _, attendee := range currentAttendees {
-			currentAttendeeMap[attendee.ID] = true
+	if req.TimeZone != nil {
+		if _, err := models.LoadTimeZone(*req.TimeZone); err != nil {
+			return nil, err
 		}
+		meeting.TimeZone = *req.TimeZone
+	}
 
-		newAttendeeMap := make(map[uint]bool)
-		for _, attendeeID := range req.AttendeeIDs {
-			if attendeeID != meeting.OrganizerID {
-				newAttendeeMap[attendeeID] = true
-			}
+	// Equipment moves with the meeting, so a new time or room needs the
+	// booked units free again.
+	var equipment []models.EquipmentRequest
+	rebookEquipment := false
+	if req.Equipment != nil || rebooked {
+		requests := req.Equipment
+		if requests == nil {
+			requests = bookedEquipment(meeting)
 		}
 
-		for attendeeID := range currentAttendeeMap {
-			if !newAttendeeMap[attendeeID] {
-				s.meetingRepo.RemoveAttendee(meeting.ID, attendeeID)
-			}
+		room, err := s.roomRepo.GetByID(meeting.RoomID)
+		if err != nil {
+			return nil, errors.New("room not found")
+		}
+		equipment, err = s.checkEquipment(room, requests, meeting.StartTime, meeting.EndTime, &meeting.ID)
+		if err != nil {
+			return nil, err
 		}
+		rebookEquipment = len(equipment) > 0 || len(meeting.Equipment) > 0
+	}
 
-		for attendeeID := range newAttendeeMap {
-			if !currentAttendeeMap[attendeeID] {
-				attendee, err := s.userRepo.GetByID(attendeeID)
-				if err == nil && attendee.IsActive {
-					s.meetingRepo.AddAttendee(meeting.ID, attendeeID)
-				}
+	updatedMeeting, err := s.meetingRepo.Update(meeting)
+	if err != nil {
+		return nil, err
+	}
+
+	// The units are reserved for the new slot only once the meeting holds
+	// it. If they were taken in the meantime the meeting goes back to its
+	// old slot, where its earlier equipment bookings still apply.
+	if rebookEquipment {
+		if err := s.reserveEquipment(updatedMeeting, equipment); err != nil {
+			if _, restoreErr := s.meetingRepo.Update(&previous); restoreErr != nil {
+				return nil, fmt.Errorf("%w; restoring the meeting failed: %v", err, restoreErr)
 			}
+			return nil, err
 		}
 	}
 
-	return s.meetingRepo.GetByID(updatedMeeting.ID)
+	if needsApproval {
+		s.notifyApprovers(updatedMeeting)
+	}
+
+	// Moving, shortening or cancelling the meeting may free time someone
+	// is waiting for.
+	if rebooked || req.SetupMinutes != nil || req.CleanupMinutes != nil || !updatedMeeting.IsActive() {
+		s.releaseSlot(&previous)
+	}
+
+	if attendeesChanged {
+		s.setAttendees(updatedMeeting, individualIDs, groups, groupMembers)
+	}
+
+	s.recordActivity(meeting.ID, models.MeetingActivityUpdated, actorID, userID)
+
+	updatedMeeting, err = s.meetingRepo.GetByID(updatedMeeting.ID)
+	if err != nil {
+		return nil, err
+	}
+	updatedMeeting.CapacityWarning = capacityWarning
+	updatedMeeting.AttendeeConflicts = attendeeConflicts
+	return updatedMeeting, nil
+}
+
+// GetMeetingOccurrences expands a meeting into its instances between from and
+// to, at most maxMeetingOccurrences of them.
+func (s *MeetingService) GetMeetingOccurrences(id uint, from, to time.Time) ([]models.Occurrence, error) {
+	meeting, err := s.meetingRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("meeting not found")
+	}
+
+	if !to.After(from) {
+		return nil, errors.New("end date must be after start date")
+	}
+
+	return meeting.Occurrences(from, to, maxMeetingOccurrences), nil
 }
 
 func (s *MeetingService) DeleteMeeting(id uint, userID uint) error {
@@ -239,18 +559,21 @@ func (s *MeetingService) DeleteMeeting(id uint, userID uint) error {
 		return errors.New("meeting not found")
 	}
 
-	if meeting.OrganizerID != userID {
-		user, err := s.userRepo.GetByID(userID)
-		if err != nil || !user.IsManager() {
-			return errors.New("only organizer or manager can delete meeting")
-		}
+	if err := s.authorize(userID, models.ActionMeetingDelete, meeting); err != nil {
+		return err
 	}
 
 	if meeting.Status == models.StatusCompleted {
 		return errors.New("cannot delete completed meeting")
 	}
 
-	return s.meetingRepo.UpdateMeetingStatus(id, models.StatusCancelled)
+	if err := s.meetingRepo.UpdateMeetingStatus(id, models.StatusCancelled); err != nil {
+		return err
+	}
+
+	s.recordActivity(id, models.MeetingActivityDeleted, userID, userID)
+	s.releaseSlot(meeting)
+	return nil
 }
 
 func (s *MeetingService) GetMeetingsByFilter(filter models.MeetingFilter, page, limit int) ([]*models.Meeting, utils.PaginationMeta, error) {
@@ -274,4 +597,428 @@ func (s *MeetingService) GetMeetingsByDateRange(startDate, endDate time.Time, us
 	}
 
 	return s.meetingRepo.GetMeetingsByDateRange(startDate, endDate, userID)
-}
\ No newline at end of file
+}
+
+func (s *MeetingService) StartMeeting(id, userID uint) error {
+	meeting, err := s.meetingRepo.GetByID(id)
+	if err != nil {
+		return errors.New("meeting not found")
+	}
+
+	if err := s.authorize(userID, models.ActionMeetingStatus, meeting); err != nil {
+		return err
+	}
+
+	if meeting.Status != models.StatusScheduled {
+		return errors.New("only scheduled meetings can be started")
+	}
+
+	return s.meetingRepo.UpdateMeetingStatus(id, models.StatusInProgress)
+}
+
+func (s *MeetingService) CompleteMeeting(id, userID uint) error {
+	meeting, err := s.meetingRepo.GetByID(id)
+	if err != nil {
+		return errors.New("meeting not found")
+	}
+
+	if err := s.authorize(userID, models.ActionMeetingStatus, meeting); err != nil {
+		return err
+	}
+
+	if meeting.Status != models.StatusScheduled && meeting.Status != models.StatusInProgress {
+		return errors.New("only scheduled or in-progress meetings can be completed")
+	}
+
+	return s.meetingRepo.UpdateMeetingStatus(id, models.StatusCompleted)
+}
+
+// CancelMeeting cancels a meeting as userID, or as the user named in
+// onBehalfOf when userID manages their meetings.
+func (s *MeetingService) CancelMeeting(id, actorID uint, onBehalfOf *uint) error {
+	meeting, err := s.meetingRepo.GetByID(id)
+	if err != nil {
+		return errors.New("meeting not found")
+	}
+
+	userID, err := s.actingFor(actorID, onBehalfOf, models.DelegateScopeManage)
+	if err != nil {
+		return err
+	}
+
+	if err := s.authorize(userID, models.ActionMeetingStatus, meeting); err != nil {
+		return err
+	}
+
+	if !meeting.IsActive() {
+		return errors.New("only scheduled, pending or in-progress meetings can be cancelled")
+	}
+
+	if err := s.meetingRepo.UpdateMeetingStatus(id, models.StatusCancelled); err != nil {
+		return err
+	}
+
+	s.recordActivity(id, models.MeetingActivityCancelled, actorID, userID)
+	s.releaseSlot(meeting)
+	return nil
+}
+
+// GetMeetingActivity lists who created, changed and cancelled a meeting.
+func (s *MeetingService) GetMeetingActivity(id uint) ([]*models.MeetingActivity, error) {
+	if _, err := s.meetingRepo.GetByID(id); err != nil {
+		return nil, errors.New("meeting not found")
+	}
+
+	return s.meetingRepo.GetActivity(id)
+}
+
+func (s *MeetingService) GetMeetingAttendees(meetingID uint) ([]*models.User, error) {
+	if _, err := s.meetingRepo.GetByID(meetingID); err != nil {
+		return nil, errors.New("meeting not found")
+	}
+
+	return s.meetingRepo.GetMeetingAttendees(meetingID)
+}
+
+// AddAttendee adds attendeeID to the meeting. If that takes the meeting over
+// its room's capacity it fails under hard enforcement and returns a warning
+// under soft enforcement.
+func (s *MeetingService) AddAttendee(meetingID, attendeeID, userID uint) (*models.CapacityWarning, error) {
+	meeting, err := s.meetingRepo.GetByID(meetingID)
+	if err != nil {
+		return nil, errors.New("meeting not found")
+	}
+
+	if err := s.authorize(userID, models.ActionMeetingAttendees, meeting); err != nil {
+		return nil, err
+	}
+
+	if !meeting.IsActive() {
+		return nil, errors.New("cannot change attendees of completed or cancelled meeting")
+	}
+
+	if attendeeID == meeting.OrganizerID {
+		return nil, errors.New("organizer is already part of the meeting")
+	}
+
+	attendee, err := s.userRepo.GetByID(attendeeID)
+	if err != nil {
+		return nil, errors.New("attendee not found")
+	}
+
+	if !attendee.IsActive {
+		return nil, errors.New("attendee is not active")
+	}
+
+	attendeeIDs := []uint{attendeeID}
+	for _, current := range meeting.Attendees {
+		attendeeIDs = append(attendeeIDs, current.ID)
+	}
+
+	capacityWarning, err := s.checkCapacity(&meeting.Room, &meeting.Organizer, meetingHeadcount(meeting.OrganizerID, attendeeIDs),
+		meeting.StartTime, meeting.EndTime, meeting.BookingBuffers)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.meetingRepo.AddAttendee(meetingID, attendeeID); err != nil {
+		return nil, err
+	}
+	// An individual invitation outlasts the attendee leaving their groups.
+	if err := s.meetingRepo.RemoveGroupAttendees(meetingID, attendeeID); err != nil {
+		log.Printf("Failed to record attendee %d of meeting %d as invited individually: %v", attendeeID, meetingID, err)
+	}
+	return capacityWarning, nil
+}
+
+// RemoveAttendee lets attendees decline on their own; removing anybody else
+// needs the attendee management permission.
+func (s *MeetingService) RemoveAttendee(meetingID, attendeeID, userID uint) error {
+	meeting, err := s.meetingRepo.GetByID(meetingID)
+	if err != nil {
+		return errors.New("meeting not found")
+	}
+
+	if attendeeID != userID {
+		if err := s.authorize(userID, models.ActionMeetingAttendees, meeting); err != nil {
+			return err
+		}
+	}
+
+	if !meeting.IsActive() {
+		return errors.New("cannot change attendees of completed or cancelled meeting")
+	}
+
+	if err := s.meetingRepo.RemoveAttendee(meetingID, attendeeID); err != nil {
+		return err
+	}
+	return s.meetingRepo.RemoveGroupAttendees(meetingID, attendeeID)
+}
+
+// ApproveMeeting signs off a pending booking so it becomes scheduled.
+func (s *MeetingService) ApproveMeeting(id, userID uint, note string) (*models.Meeting, error) {
+	return s.decideApproval(id, userID, models.StatusScheduled, note)
+}
+
+// RejectMeeting declines a pending booking and offers its slot to the
+// waitlist.
+func (s *MeetingService) RejectMeeting(id, userID uint, note string) (*models.Meeting, error) {
+	return s.decideApproval(id, userID, models.StatusRejected, note)
+}
+
+func (s *MeetingService) decideApproval(id, userID uint, status models.MeetingStatus, note string) (*models.Meeting, error) {
+	meeting, err := s.meetingRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("meeting not found")
+	}
+
+	if err := s.authorize(userID, models.ActionMeetingApprove, models.NewMeetingApproval(&meeting.Room)); err != nil {
+		return nil, err
+	}
+
+	if meeting.Status != models.StatusPendingApproval {
+		return nil, errors.New("meeting is not pending approval")
+	}
+
+	previous := *meeting
+	now := time.Now()
+	meeting.Status = status
+	meeting.ApprovalExpiresAt = nil
+	meeting.ApprovalDecidedBy = &userID
+	meeting.ApprovalDecidedAt = &now
+	meeting.ApprovalNote = note
+
+	updated, err := s.meetingRepo.UpdateApprovalState(meeting, models.StatusPendingApproval)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, errors.New("meeting is not pending approval")
+	}
+
+	s.notifyApprovalDecision(meeting)
+	if status == models.StatusRejected {
+		s.releaseSlot(&previous)
+	}
+	return s.meetingRepo.GetByID(id)
+}
+
+// GetApprovalQueue lists the pending bookings userID may decide on: all of
+// them with meeting.approve.any, otherwise those of rooms the user approves.
+func (s *MeetingService) GetApprovalQueue(userID uint, page, limit int) ([]*models.Meeting, utils.PaginationMeta, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, utils.PaginationMeta{}, errors.New("user not found")
+	}
+
+	var approverID *uint
+	switch {
+	case s.authzService.HasPermission(user.Role, models.PermMeetingApproveAny):
+	case s.authzService.HasPermission(user.Role, models.PermMeetingApproveOwn):
+		approverID = &user.ID
+	default:
+		return nil, utils.PaginationMeta{}, fmt.Errorf("%w: %s", ErrPermissionDenied, models.ActionMeetingApprove)
+	}
+
+	offset := utils.GetOffset(page, limit)
+	meetings, total, err := s.meetingRepo.GetPendingApprovals(approverID, offset, limit)
+	if err != nil {
+		return nil, utils.PaginationMeta{}, err
+	}
+
+	meta := utils.CreatePaginationMeta(page, limit, total)
+	return meetings, meta, nil
+}
+
+// ExpirePendingApprovals rejects bookings nobody decided on in time, offering
+// their slots to the waitlist, and returns how many were expired. Bookings
+// decided on meanwhile are left alone.
+func (s *MeetingService) ExpirePendingApprovals() (int, error) {
+	now := time.Now()
+	meetings, err := s.meetingRepo.GetExpiredPendingApprovals(now)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, meeting := range meetings {
+		previous := *meeting
+		meeting.Status = models.StatusRejected
+		meeting.ApprovalExpiresAt = nil
+		meeting.ApprovalDecidedAt = &now
+		meeting.ApprovalNote = "approval request expired"
+
+		updated, err := s.meetingRepo.UpdateApprovalState(meeting, models.StatusPendingApproval)
+		if err != nil {
+			return expired, err
+		}
+		if !updated {
+			continue
+		}
+		s.notifyApprovalDecision(meeting)
+		s.releaseSlot(&previous)
+		expired++
+	}
+
+	return expired, nil
+}
+
+// StartApprovalSweeper periodically expires pending approval requests.
+func (s *MeetingService) StartApprovalSweeper(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if _, err := s.ExpirePendingApprovals(); err != nil {
+				log.Printf("Failed to expire pending approvals: %v", err)
+			}
+		}
+	}()
+}
+
+// needsApproval reports whether a booking of room made by user must be
+// signed off. Approvers of the room do not need their own sign-off.
+func (s *MeetingService) needsApproval(room *models.Room, user *models.User) bool {
+	if !room.RequiresApproval {
+		return false
+	}
+
+	err := s.authzService.Authorize(models.SubjectFromUser(user), models.ActionMeetingApprove, models.NewMeetingApproval(room))
+	return err != nil
+}
+
+// requestApproval puts meeting on hold until an approver acts or the request
+// expires, which happens no later than the meeting's start.
+func (s *MeetingService) requestApproval(meeting *models.Meeting) {
+	expiresAt := time.Now().Add(s.bookingConfig.ApprovalTTL)
+	if meeting.StartTime.Before(expiresAt) {
+		expiresAt = meeting.StartTime
+	}
+
+	meeting.Status = models.StatusPendingApproval
+	meeting.ApprovalExpiresAt = &expiresAt
+	meeting.ApprovalDecidedBy = nil
+	meeting.ApprovalDecidedAt = nil
+	meeting.ApprovalNote = ""
+}
+
+func (s *MeetingService) notifyApprovers(meeting *models.Meeting) {
+	if len(meeting.Room.Approvers) == 0 {
+		log.Printf("Meeting %d in room %q is pending approval but the room has no approvers", meeting.ID, meeting.Room.Name)
+		return
+	}
+
+	message := fmt.Sprintf("%q in %s from %s to %s needs your approval",
+		meeting.Title, meeting.Room.Name, meeting.StartTime.Format(time.RFC3339), meeting.EndTime.Format(time.RFC3339))
+	for i := range meeting.Room.Approvers {
+		s.notifier.Notify(&meeting.Room.Approvers[i], "Booking approval requested", message)
+	}
+}
+
+func (s *MeetingService) notifyApprovalDecision(meeting *models.Meeting) {
+	subject := "Booking approved"
+	if meeting.Status == models.StatusRejected {
+		subject = "Booking rejected"
+	}
+
+	message := fmt.Sprintf("%q in %s from %s", meeting.Title, meeting.Room.Name, meeting.StartTime.Format(time.RFC3339))
+	if meeting.ApprovalNote != "" {
+		message += ": " + meeting.ApprovalNote
+	}
+	s.notifier.Notify(&meeting.Organizer, subject, message)
+}
+
+// actingFor returns the user actorID acts as: the user named in onBehalfOf,
+// who must have made actorID a delegate within scope, or else actorID.
+func (s *MeetingService) actingFor(actorID uint, onBehalfOf *uint, scope models.DelegateScope) (uint, error) {
+	if onBehalfOf == nil || *onBehalfOf == actorID {
+		return actorID, nil
+	}
+
+	delegated, err := isDelegate(s.delegateRepo, *onBehalfOf, actorID, scope)
+	if err != nil {
+		return 0, err
+	}
+	if !delegated {
+		return 0, fmt.Errorf("%w: you are not a delegate of user %d with %s scope", ErrPermissionDenied, *onBehalfOf, scope)
+	}
+	return *onBehalfOf, nil
+}
+
+// recordActivity logs that actorID performed action on a meeting for
+// principalID.
+func (s *MeetingService) recordActivity(meetingID uint, action models.MeetingActivityAction, actorID, principalID uint) {
+	activity := &models.MeetingActivity{
+		MeetingID: meetingID,
+		Action:    action,
+		ActorID:   actorID,
+	}
+	if principalID != actorID {
+		activity.OnBehalfOfID = &principalID
+	}
+	s.saveActivity(activity)
+}
+
+// saveActivity stores activity, logging rather than failing the action it
+// records.
+func (s *MeetingService) saveActivity(activity *models.MeetingActivity) {
+	if err := s.meetingRepo.RecordActivity(activity); err != nil {
+		log.Printf("Failed to record %s activity of meeting %d: %v", activity.Action, activity.MeetingID, err)
+	}
+}
+
+// bookingPriority is the priority user books with: the priority of their
+// role, or a lower one if requested.
+func (s *MeetingService) bookingPriority(user *models.User, requested *int) (int, error) {
+	priority := s.bookingConfig.RolePriorities[string(user.Role)]
+	if requested == nil {
+		return priority, nil
+	}
+	if *requested > priority {
+		return 0, fmt.Errorf("%w: your role books with priority %d at most", ErrPermissionDenied, priority)
+	}
+	return *requested, nil
+}
+
+// checkRoomAccess enforces the booking ACL of restricted rooms. Holders of
+// room.book.restricted may book any room.
+func (s *MeetingService) checkRoomAccess(room *models.Room, user *models.User) error {
+	return checkRoomAccess(s.groupRepo, s.authzService, room, user)
+}
+
+func checkRoomAccess(groupRepo repositories.GroupRepository, authzService *AuthorizationService, room *models.Room, user *models.User) error {
+	if !room.IsRestricted || authzService.HasPermission(user.Role, models.PermRoomBookRestricted) {
+		return nil
+	}
+
+	groupIDs, err := groupRepo.GetGroupIDsForUser(user.ID)
+	if err != nil {
+		return err
+	}
+
+	if !room.CanBeBookedBy(user, groupIDs) {
+		return ErrRoomRestricted
+	}
+	return nil
+}
+
+func (s *MeetingService) authorize(userID uint, action models.Action, resource models.Resource) error {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return errors.New("user not found")
+	}
+
+	return s.authzService.Authorize(models.SubjectFromUser(user), action, resource)
+}
+
+func validateRecurrence(isRecurring bool, pattern string) error {
+	if isRecurring && !models.IsValidRecurrencePattern(pattern) {
+		return fmt.Errorf("invalid recurrence pattern %q: use daily, weekdays, weekly, biweekly or monthly", pattern)
+	}
+	return nil
+}
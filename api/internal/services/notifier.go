@@ -0,0 +1,25 @@
+package services
+
+import (
+	"api/internal/models"
+	"log"
+)
+
+// Notifier delivers user-facing notifications about bookings. Delivery is
+// best effort: failures are logged and never fail the operation that caused
+// the notification.
+type Notifier interface {
+	Notify(recipient *models.User, subject, message string)
+}
+
+// LogNotifier writes notifications to the server log. It is used until a
+// mail or chat integration is configured.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(recipient *models.User, subject, message string) {
+	log.Printf("Notification to %s: %s - %s", recipient.Email, subject, message)
+}
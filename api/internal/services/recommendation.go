@@ -0,0 +1,161 @@
+package services
+
+import (
+	"api/internal/models"
+	"api/internal/utils"
+	"errors"
+	"sort"
+	"time"
+)
+
+const (
+	defaultRecommendationLimit = 5
+
+	// recommendationHistory is how far back past bookings count towards a
+	// room's history score.
+	recommendationHistory = 180 * 24 * time.Hour
+
+	capacityFitWeight       = 0.4
+	proximityWeight         = 0.3
+	historyWeight           = 0.2
+	preferredBuildingWeight = 0.1
+)
+
+// RecommendRooms ranks the rooms that are free for a new booking and seat
+// its attendees. Rooms score higher the more snugly they fit the group, the
+// closer they are to the attendees' home floors, the more often the
+// attendees booked them before and when they are in the preferred building.
+func (s *RoomService) RecommendRooms(query *models.RoomRecommendationQuery) ([]models.RoomRecommendation, error) {
+	if validationErrors := utils.ValidateStruct(query); len(validationErrors) > 0 {
+		return nil, errors.New("validation failed")
+	}
+
+	people := uniqueIDs(append([]uint{query.OrganizerID}, query.AttendeeIDs...))
+	headcount := query.AttendeeCount
+	if headcount == 0 {
+		headcount = len(people)
+	}
+
+	filter := query.RoomFilter
+	filter.Capacity = &headcount
+
+	rooms, err := s.GetAvailableRooms(&models.RoomAvailabilityQuery{
+		StartTime:  query.StartTime,
+		EndTime:    query.EndTime,
+		RoomFilter: filter,
+		Booker:     query.Booker,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	homes, err := s.homeFloors(people)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	bookings, err := s.meetingRepo.CountBookingsByRoom(people, now.Add(-recommendationHistory), now)
+	if err != nil {
+		return nil, err
+	}
+	var mostBookings int64
+	for _, count := range bookings {
+		if count > mostBookings {
+			mostBookings = count
+		}
+	}
+
+	recommendations := make([]models.RoomRecommendation, 0, len(rooms))
+	for _, room := range rooms {
+		recommendation := models.RoomRecommendation{
+			Room:        room,
+			CapacityFit: float64(headcount) / float64(room.Capacity),
+			Proximity:   proximity(room, homes),
+		}
+		if mostBookings > 0 {
+			recommendation.History = float64(bookings[room.ID]) / float64(mostBookings)
+		}
+		if query.PreferredBuildingID != nil && room.Floor != nil {
+			recommendation.PreferredBuilding = room.Floor.BuildingID == *query.PreferredBuildingID
+		}
+
+		recommendation.Score = capacityFitWeight*recommendation.CapacityFit +
+			proximityWeight*recommendation.Proximity +
+			historyWeight*recommendation.History
+		if recommendation.PreferredBuilding {
+			recommendation.Score += preferredBuildingWeight
+		}
+
+		recommendations = append(recommendations, recommendation)
+	}
+
+	// rooms come ranked by fit, which breaks ties between equal scores.
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		return recommendations[i].Score > recommendations[j].Score
+	})
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultRecommendationLimit
+	}
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+
+	return recommendations, nil
+}
+
+// homeFloors returns the home floor of each of userIDs that has one.
+func (s *RoomService) homeFloors(userIDs []uint) ([]*models.Floor, error) {
+	users, err := s.userRepo.GetByIDs(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	floors := make(map[uint]*models.Floor)
+	var homes []*models.Floor
+	for _, user := range users {
+		if user.HomeFloorID == nil {
+			continue
+		}
+
+		floor, ok := floors[*user.HomeFloorID]
+		if !ok {
+			floor, err = s.floorRepo.GetByID(*user.HomeFloorID)
+			if err != nil {
+				return nil, err
+			}
+			floors[floor.ID] = floor
+		}
+		homes = append(homes, floor)
+	}
+
+	return homes, nil
+}
+
+// proximity averages how close room is to each of homes: 1 on the same
+// floor, down to 0 on another site. It is 0 when no home floor is known.
+func proximity(room *models.Room, homes []*models.Floor) float64 {
+	if len(homes) == 0 {
+		return 0
+	}
+
+	var total float64
+	for _, home := range homes {
+		total += float64(maxFloorDistance-floorDistance(room.Floor, home)) / maxFloorDistance
+	}
+	return total / float64(len(homes))
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := ids[:0]
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
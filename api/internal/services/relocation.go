@@ -0,0 +1,243 @@
+package services
+
+import (
+	"api/internal/models"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// GetRelocationPlan previews where each upcoming booking of a room would be
+// moved if the room were deactivated now.
+func (s *RoomService) GetRelocationPlan(id uint) (*models.RoomDeactivationReport, error) {
+	room, err := s.roomRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("room not found")
+	}
+
+	return s.planRelocation(room)
+}
+
+func (s *RoomService) planRelocation(room *models.Room) (*models.RoomDeactivationReport, error) {
+	meetings, err := s.meetingRepo.GetActiveByRoom(room.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.RoomDeactivationReport{
+		Room:        room,
+		Relocations: []models.MeetingRelocation{},
+	}
+
+	// Rooms proposed earlier in this plan are not booked yet, so their new
+	// slots are tracked here to avoid proposing the same room twice.
+	claimed := make(map[uint][]*models.Meeting)
+	for _, meeting := range meetings {
+		// Bookings of combined rooms the room is part of lose their room
+		// too, and are replaced by a room like the combined one.
+		original := room
+		if meeting.RoomID != room.ID {
+			original = &meeting.Room
+		}
+
+		newRoom, err := s.findEquivalentRoom(original, meeting, claimed, room)
+		if err != nil {
+			return nil, err
+		}
+		if newRoom != nil {
+			claimed[newRoom.ID] = append(claimed[newRoom.ID], meeting)
+		}
+
+		report.Relocations = append(report.Relocations, models.MeetingRelocation{
+			Meeting: meeting,
+			NewRoom: newRoom,
+		})
+	}
+
+	return report, nil
+}
+
+// findEquivalentRoom picks a free replacement for original: it must seat the
+// meeting's attendees, offer every feature of original, admit the organizer,
+// not need approval and allow the booking under its booking policy. Rooms
+// sharing space with avoid, if given, are passed over. Rooms nearer in the
+// site hierarchy win, then rooms closer in capacity.
+func (s *RoomService) findEquivalentRoom(original *models.Room, meeting *models.Meeting, claimed map[uint][]*models.Meeting, avoid *models.Room) (*models.Room, error) {
+	booker, err := s.BookerFor(models.SubjectFromUser(&meeting.Organizer))
+	if err != nil {
+		return nil, err
+	}
+
+	capacity := len(meeting.Attendees) + 1
+	candidates, err := s.roomRepo.GetAvailableRooms(&models.RoomAvailabilityQuery{
+		StartTime:  meeting.StartTime,
+		EndTime:    meeting.EndTime,
+		RoomFilter: models.RoomFilter{Capacity: &capacity},
+		Booker:     booker,
+		Buffers:    meeting.BookingBuffers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var best *models.Room
+	for _, candidate := range candidates {
+		if candidate.ID == original.ID || candidate.RequiresApproval || !hasAllFeatures(candidate, original) {
+			continue
+		}
+		if avoid != nil && candidate.SharesSpaceWith(avoid) {
+			continue
+		}
+		if overlapsAny(claimed[candidate.ID], meeting, candidate) {
+			continue
+		}
+		if allowed, err := s.policyAllows(candidate, meeting); err != nil {
+			return nil, err
+		} else if !allowed {
+			continue
+		}
+		if best == nil || isCloserReplacement(original, candidate, best) {
+			best = candidate
+		}
+	}
+
+	return best, nil
+}
+
+// relocate moves every booking in report that has a replacement room. Pending
+// bookings are scheduled, since replacement rooms never need approval.
+func (s *RoomService) relocate(report *models.RoomDeactivationReport) {
+	for i := range report.Relocations {
+		relocation := &report.Relocations[i]
+		if relocation.NewRoom == nil {
+			continue
+		}
+
+		meeting := relocation.Meeting
+		available, err := s.roomRepo.IsRoomAvailable(relocation.NewRoom.ID, meeting.StartTime, meeting.EndTime, meeting.BookingBuffers, &meeting.ID)
+		if err != nil || !available {
+			log.Printf("Could not relocate meeting %d to room %d: room is no longer available", meeting.ID, relocation.NewRoom.ID)
+			continue
+		}
+		if allowed, err := s.policyAllows(relocation.NewRoom, meeting); err != nil || !allowed {
+			log.Printf("Could not relocate meeting %d to room %d: the room's booking policy does not allow it", meeting.ID, relocation.NewRoom.ID)
+			continue
+		}
+
+		meeting.RoomID = relocation.NewRoom.ID
+		meeting.Room = *relocation.NewRoom
+		if meeting.Status == models.StatusPendingApproval {
+			meeting.Status = models.StatusScheduled
+			meeting.ApprovalExpiresAt = nil
+		}
+
+		if err := s.meetingRepo.UpdateRoomAssignment(meeting); err != nil {
+			log.Printf("Failed to relocate meeting %d: %v", meeting.ID, err)
+			continue
+		}
+		relocation.Relocated = true
+	}
+}
+
+func (s *RoomService) notifyRelocations(report *models.RoomDeactivationReport) {
+	for _, relocation := range report.Relocations {
+		meeting := relocation.Meeting
+		start := meeting.StartTime.Format(time.RFC3339)
+
+		switch {
+		case relocation.Relocated:
+			s.notifier.Notify(&meeting.Organizer, "Meeting room changed",
+				fmt.Sprintf("%q on %s has been moved from %s to %s because the room was deactivated",
+					meeting.Title, start, report.Room.Name, relocation.NewRoom.Name))
+		case relocation.NewRoom != nil:
+			s.notifier.Notify(&meeting.Organizer, "Meeting room deactivated",
+				fmt.Sprintf("%s has been deactivated; %q on %s needs a new room. %s is free at that time",
+					report.Room.Name, meeting.Title, start, relocation.NewRoom.Name))
+		default:
+			s.notifier.Notify(&meeting.Organizer, "Meeting room deactivated",
+				fmt.Sprintf("%s has been deactivated and no equivalent room is free for %q on %s; please rebook",
+					report.Room.Name, meeting.Title, start))
+		}
+	}
+}
+
+// policyAllows reports whether the booking policy of room admits meeting.
+func (s *RoomService) policyAllows(room *models.Room, meeting *models.Meeting) (bool, error) {
+	err := s.policyService.CheckBooking(room.ID, meeting.OrganizerID, meeting.StartTime, meeting.EndTime, &meeting.ID)
+	var violations *BookingPolicyError
+	if errors.As(err, &violations) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func hasAllFeatures(room, original *models.Room) bool {
+	for _, wanted := range original.Features {
+		found := false
+		for _, feature := range room.Features {
+			if feature.ID == wanted.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// overlapsAny reports whether meeting, moved to room, would collide with any
+// of meetings planned for the same room, buffers included.
+func overlapsAny(meetings []*models.Meeting, meeting *models.Meeting, room *models.Room) bool {
+	start, end := meeting.BlockedInterval(room)
+	for _, other := range meetings {
+		otherStart, otherEnd := other.BlockedInterval(room)
+		if otherStart.Before(end) && otherEnd.After(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCloserReplacement reports whether candidate is a better stand-in for
+// original than current.
+func isCloserReplacement(original, candidate, current *models.Room) bool {
+	if a, b := locationDistance(original, candidate), locationDistance(original, current); a != b {
+		return a < b
+	}
+	return capacityDistance(original, candidate) < capacityDistance(original, current)
+}
+
+// maxFloorDistance is the distance between floors on different sites.
+const maxFloorDistance = 3
+
+// locationDistance is 0 for rooms on the same floor, 1 in the same building,
+// 2 on the same site and 3 otherwise.
+func locationDistance(a, b *models.Room) int {
+	return floorDistance(a.Floor, b.Floor)
+}
+
+func floorDistance(a, b *models.Floor) int {
+	if a == nil || b == nil {
+		return maxFloorDistance
+	}
+	if a.ID == b.ID {
+		return 0
+	}
+	if a.BuildingID == b.BuildingID {
+		return 1
+	}
+	if a.Building != nil && b.Building != nil && a.Building.SiteID == b.Building.SiteID {
+		return 2
+	}
+	return maxFloorDistance
+}
+
+func capacityDistance(a, b *models.Room) int {
+	if a.Capacity > b.Capacity {
+		return a.Capacity - b.Capacity
+	}
+	return b.Capacity - a.Capacity
+}
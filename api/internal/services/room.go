@@ -5,18 +5,34 @@ import (
 	"api/internal/repositories"
 	"api/internal/utils"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
 type RoomService struct {
-	roomRepo    repositories.RoomRepository
-	featureRepo repositories.RoomFeatureRepository
+	roomRepo     repositories.RoomRepository
+	featureRepo  repositories.RoomFeatureRepository
+	floorRepo    repositories.FloorRepository
+	meetingRepo  repositories.MeetingRepository
+	userRepo     repositories.UserRepository
+	groupRepo    repositories.GroupRepository
+	authzService  *AuthorizationService
+	policyService *BookingPolicyService
+	notifier      Notifier
 }
 
-func NewRoomService(roomRepo repositories.RoomRepository, featureRepo repositories.RoomFeatureRepository) *RoomService {
+func NewRoomService(roomRepo repositories.RoomRepository, featureRepo repositories.RoomFeatureRepository, floorRepo repositories.FloorRepository, meetingRepo repositories.MeetingRepository, userRepo repositories.UserRepository, groupRepo repositories.GroupRepository, authzService *AuthorizationService, policyService *BookingPolicyService, notifier Notifier) *RoomService {
 	return &RoomService{
-		roomRepo:    roomRepo,
-		featureRepo: featureRepo,
+		roomRepo:      roomRepo,
+		featureRepo:   featureRepo,
+		floorRepo:     floorRepo,
+		meetingRepo:   meetingRepo,
+		userRepo:      userRepo,
+		groupRepo:     groupRepo,
+		authzService:  authzService,
+		policyService: policyService,
+		notifier:      notifier,
 	}
 }
 
@@ -25,12 +41,59 @@ func (s *RoomService) CreateRoom(req *models.CreateRoomRequest) (*models.Room, e
 		return nil, errors.New("validation failed")
 	}
 
+	spaceType := req.SpaceType
+	if spaceType == "" {
+		spaceType = models.SpaceTypeRoom
+	}
+
+	// A desk seats one person unless stated otherwise.
+	capacity := req.Capacity
+	if spaceType == models.SpaceTypeDesk && capacity == 0 {
+		capacity = 1
+	}
+
+	if len(req.ComponentIDs) > 0 {
+		if spaceType == models.SpaceTypeDesk {
+			return nil, errors.New("a desk cannot be a combined room")
+		}
+		if err := s.validateComponents(0, req.ComponentIDs); err != nil {
+			return nil, err
+		}
+	} else if capacity == 0 {
+		return nil, errors.New("capacity is required")
+	}
+
 	room := &models.Room{
-		Name:        req.Name,
-		Description: req.Description,
-		Capacity:    req.Capacity,
-		Location:    req.Location,
-		IsActive:    true,
+		Name:                 req.Name,
+		Description:          req.Description,
+		SpaceType:            spaceType,
+		Capacity:             capacity,
+		Location:             req.Location,
+		FloorID:              req.FloorID,
+		Zone:                 req.Zone,
+		WheelchairAccessible: req.WheelchairAccessible,
+		HearingLoop:          req.HearingLoop,
+		SetupMinutes:         req.SetupMinutes,
+		CleanupMinutes:       req.CleanupMinutes,
+		IsActive:             true,
+	}
+
+	if req.FloorID != nil {
+		if _, err := s.floorRepo.GetByID(*req.FloorID); err != nil {
+			return nil, errors.New("floor not found")
+		}
+	}
+
+	if req.AccessControl != nil {
+		if err := s.validateAccessControl(req.AccessControl); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Approval != nil {
+		if err := s.validateApproval(req.Approval); err != nil {
+			return nil, err
+		}
 	}
 
 	createdRoom, err := s.roomRepo.Create(room)
@@ -54,6 +117,31 @@ func (s *RoomService) CreateRoom(req *models.CreateRoomRequest) (*models.Room, e
 		}
 	}
 
+	if req.AccessControl != nil {
+		if err := s.roomRepo.UpdateAccessControl(createdRoom.ID, req.AccessControl); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Approval != nil {
+		if err := s.roomRepo.UpdateApproval(createdRoom.ID, req.Approval); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(req.ComponentIDs) > 0 {
+		if err := s.roomRepo.UpdateComponents(createdRoom.ID, uniqueIDs(req.ComponentIDs)); err != nil {
+			return nil, err
+		}
+		if err := s.roomRepo.RefreshCombinedRoom(createdRoom.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.AccessControl != nil || req.Approval != nil || len(req.ComponentIDs) > 0 {
+		return s.roomRepo.GetByID(createdRoom.ID)
+	}
+
 	return createdRoom, nil
 }
 
@@ -61,9 +149,9 @@ func (s *RoomService) GetRoomByID(id uint) (*models.Room, error) {
 	return s.roomRepo.GetByID(id)
 }
 
-func (s *RoomService) GetAllRooms(page, limit int) ([]*models.Room, utils.PaginationMeta, error) {
+func (s *RoomService) GetAllRooms(location models.LocationFilter, page, limit int) ([]*models.Room, utils.PaginationMeta, error) {
 	offset := utils.GetOffset(page, limit)
-	rooms, total, err := s.roomRepo.GetAll(offset, limit)
+	rooms, total, err := s.roomRepo.GetAll(location, offset, limit)
 	if err != nil {
 		return nil, utils.PaginationMeta{}, err
 	}
@@ -93,7 +181,38 @@ func (s *RoomService) UpdateRoom(id uint, req *models.UpdateRoomRequest) (*model
 	if req.Location != nil {
 		room.Location = *req.Location
 	}
+	if req.FloorID != nil {
+		if _, err := s.floorRepo.GetByID(*req.FloorID); err != nil {
+			return nil, errors.New("floor not found")
+		}
+		room.FloorID = req.FloorID
+	}
+	if req.Zone != nil {
+		room.Zone = *req.Zone
+	}
+	if req.WheelchairAccessible != nil {
+		room.WheelchairAccessible = *req.WheelchairAccessible
+	}
+	if req.HearingLoop != nil {
+		room.HearingLoop = *req.HearingLoop
+	}
+	if req.SetupMinutes != nil || req.CleanupMinutes != nil {
+		if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+			return nil, errors.New("validation failed")
+		}
+		if req.SetupMinutes != nil {
+			room.SetupMinutes = *req.SetupMinutes
+		}
+		if req.CleanupMinutes != nil {
+			room.CleanupMinutes = *req.CleanupMinutes
+		}
+	}
 	if req.IsActive != nil {
+		// Deactivation has to go through DeleteRoom so the room's upcoming
+		// bookings are relocated or their organizers told.
+		if room.IsActive && !*req.IsActive {
+			return nil, errors.New("rooms are deactivated by deleting them, so their bookings can be relocated")
+		}
 		room.IsActive = *req.IsActive
 	}
 
@@ -109,31 +228,213 @@ func (s *RoomService) UpdateRoom(id uint, req *models.UpdateRoomRequest) (*model
 		room.Features = features
 	}
 
-	return s.roomRepo.Update(room)
+	if req.AccessControl != nil {
+		if err := s.validateAccessControl(req.AccessControl); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Approval != nil {
+		if err := s.validateApproval(req.Approval); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(req.ComponentIDs) > 0 {
+		if room.IsDesk() {
+			return nil, errors.New("a desk cannot be a combined room")
+		}
+		if err := s.validateComponents(id, req.ComponentIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	updatedRoom, err := s.roomRepo.Update(room)
+	if err != nil {
+		return nil, err
+	}
+
+	// Capacity and features of combined rooms follow their components, so
+	// changes to them are overridden and changes to a component spread to
+	// the combined rooms it is part of.
+	if req.ComponentIDs != nil {
+		if err := s.roomRepo.UpdateComponents(id, uniqueIDs(req.ComponentIDs)); err != nil {
+			return nil, err
+		}
+	}
+	combined := len(req.ComponentIDs) > 0 || (req.ComponentIDs == nil && room.IsCombined())
+	if combined {
+		if err := s.roomRepo.RefreshCombinedRoom(id); err != nil {
+			return nil, err
+		}
+	}
+	if req.Capacity != nil || req.FeatureIDs != nil {
+		combinedIDs, err := s.roomRepo.GetCombinedRoomIDs(id)
+		if err != nil {
+			return nil, err
+		}
+		for _, combinedID := range combinedIDs {
+			if err := s.roomRepo.RefreshCombinedRoom(combinedID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if req.AccessControl != nil {
+		if err := s.roomRepo.UpdateAccessControl(id, req.AccessControl); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Approval != nil {
+		if err := s.roomRepo.UpdateApproval(id, req.Approval); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.AccessControl != nil || req.Approval != nil || req.ComponentIDs != nil || combined {
+		return s.roomRepo.GetByID(id)
+	}
+
+	return updatedRoom, nil
+}
+
+// validateComponents checks that componentIDs can make up roomID: at least
+// two existing rooms that are not combined rooms themselves. A room that is
+// already part of a combined room cannot be combined.
+func (s *RoomService) validateComponents(roomID uint, componentIDs []uint) error {
+	ids := uniqueIDs(append([]uint(nil), componentIDs...))
+	if len(ids) < 2 {
+		return errors.New("a combined room needs at least two component rooms")
+	}
+
+	for _, id := range ids {
+		if id == roomID {
+			return errors.New("a room cannot be a component of itself")
+		}
+		component, err := s.roomRepo.GetByID(id)
+		if err != nil {
+			return fmt.Errorf("component room %d not found", id)
+		}
+		if component.IsCombined() {
+			return fmt.Errorf("%s is a combined room and cannot be a component", component.Name)
+		}
+		if component.IsDesk() {
+			return fmt.Errorf("%s is a desk and cannot be a component", component.Name)
+		}
+	}
+
+	if roomID != 0 {
+		combinedIDs, err := s.roomRepo.GetCombinedRoomIDs(roomID)
+		if err != nil {
+			return err
+		}
+		if len(combinedIDs) > 0 {
+			return errors.New("a component of a combined room cannot be combined itself")
+		}
+	}
+
+	return nil
+}
+
+func (s *RoomService) validateApproval(settings *models.RoomApprovalSettings) error {
+	for _, approverID := range settings.ApproverIDs {
+		approver, err := s.userRepo.GetByID(approverID)
+		if err != nil {
+			return fmt.Errorf("approver %d not found", approverID)
+		}
+		if !approver.IsActive {
+			return fmt.Errorf("approver %d is not active", approverID)
+		}
+	}
+
+	return nil
 }
 
-func (s *RoomService) DeleteRoom(id uint) error {
+func (s *RoomService) validateAccessControl(acl *models.RoomAccessControl) error {
+	for _, role := range acl.AllowedRoles {
+		if !models.IsValidRole(role) {
+			return fmt.Errorf("unknown role %q", role)
+		}
+	}
+
+	for _, userID := range acl.AllowedUserIDs {
+		if _, err := s.userRepo.GetByID(userID); err != nil {
+			return fmt.Errorf("allowed user %d not found", userID)
+		}
+	}
+
+	for _, groupID := range acl.AllowedGroupIDs {
+		if _, err := s.groupRepo.GetByID(groupID); err != nil {
+			return fmt.Errorf("allowed group %d not found", groupID)
+		}
+	}
+
+	return nil
+}
+
+// BookerFor describes subject for availability queries, including the groups
+// it belongs to and whether it may ignore room restrictions altogether.
+func (s *RoomService) BookerFor(subject models.Subject) (*models.RoomBooker, error) {
+	return newRoomBooker(s.groupRepo, s.authzService, subject)
+}
+
+func newRoomBooker(groupRepo repositories.GroupRepository, authzService *AuthorizationService, subject models.Subject) (*models.RoomBooker, error) {
+	groupIDs, err := groupRepo.GetGroupIDsForUser(subject.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RoomBooker{
+		UserID:             subject.UserID,
+		Role:               subject.Role,
+		GroupIDs:           groupIDs,
+		BypassRestrictions: authzService.HasPermission(subject.Role, models.PermRoomBookRestricted),
+	}, nil
+}
+
+// DeleteRoom deactivates a room. With relocate set its upcoming bookings are
+// moved to an equivalent free room; otherwise the report only suggests one.
+// Organizers are notified either way.
+func (s *RoomService) DeleteRoom(id uint, relocate bool) (*models.RoomDeactivationReport, error) {
 	room, err := s.roomRepo.GetByID(id)
 	if err != nil {
-		return errors.New("room not found")
+		return nil, errors.New("room not found")
 	}
 
 	if !room.IsActive {
-		return errors.New("room is already inactive")
+		return nil, errors.New("room is already inactive")
 	}
 
 	room.IsActive = false
-	_, err = s.roomRepo.Update(room)
-	return err
+	if _, err := s.roomRepo.Update(room); err != nil {
+		return nil, err
+	}
+
+	report, err := s.planRelocation(room)
+	if err != nil {
+		return nil, err
+	}
+
+	if relocate {
+		s.relocate(report)
+	}
+	s.notifyRelocations(report)
+
+	return report, nil
 }
 
 func (s *RoomService) GetActiveRooms() ([]*models.Room, error) {
 	return s.roomRepo.GetActiveRooms()
 }
 
-func (s *RoomService) SearchRooms(query string, page, limit int) ([]*models.Room, utils.PaginationMeta, error) {
+func (s *RoomService) SearchRooms(query string, filter models.RoomFilter, page, limit int) ([]*models.Room, utils.PaginationMeta, error) {
+	if err := s.resolveFeatures(&filter); err != nil {
+		return nil, utils.PaginationMeta{}, err
+	}
+
 	offset := utils.GetOffset(page, limit)
-	rooms, total, err := s.roomRepo.SearchRooms(query, offset, limit)
+	rooms, total, err := s.roomRepo.SearchRooms(strings.TrimSpace(query), filter, offset, limit)
 	if err != nil {
 		return nil, utils.PaginationMeta{}, err
 	}
@@ -151,7 +452,40 @@ func (s *RoomService) GetAvailableRooms(req *models.RoomAvailabilityQuery) ([]*m
 		return nil, errors.New("end time must be after start time")
 	}
 
-	return s.roomRepo.GetAvailableRooms(req.StartTime, req.EndTime, req.Capacity)
+	if err := s.resolveFeatures(&req.RoomFilter); err != nil {
+		return nil, err
+	}
+
+	return s.roomRepo.GetAvailableRooms(req)
+}
+
+// resolveFeatures turns the feature names of filter into IDs, so the
+// repository only has to match IDs. Unknown names are an error.
+func (s *RoomService) resolveFeatures(filter *models.RoomFilter) error {
+	if len(filter.FeatureNames) > 0 {
+		features, err := s.featureRepo.GetByNames(filter.FeatureNames)
+		if err != nil {
+			return err
+		}
+
+		for _, name := range filter.FeatureNames {
+			found := false
+			for _, feature := range features {
+				if strings.EqualFold(feature.Name, name) {
+					filter.FeatureIDs = append(filter.FeatureIDs, feature.ID)
+					found = true
+				}
+			}
+			if !found {
+				return fmt.Errorf("room feature %q not found", name)
+			}
+		}
+		filter.FeatureNames = nil
+	}
+
+	filter.FeatureIDs = uniqueIDs(filter.FeatureIDs)
+
+	return nil
 }
 
 func (s *RoomService) IsRoomAvailable(roomID uint, startTime, endTime time.Time, excludeMeetingID *uint) (bool, error) {
@@ -159,7 +493,7 @@ func (s *RoomService) IsRoomAvailable(roomID uint, startTime, endTime time.Time,
 		return false, errors.New("end time must be after start time")
 	}
 
-	return s.roomRepo.IsRoomAvailable(roomID, startTime, endTime, excludeMeetingID)
+	return s.roomRepo.IsRoomAvailable(roomID, startTime, endTime, models.BookingBuffers{}, excludeMeetingID)
 }
 
 func (s *RoomService) CreateFeature(req *models.CreateRoomFeatureRequest) (*models.RoomFeature, error) {
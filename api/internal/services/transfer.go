@@ -0,0 +1,158 @@
+package services
+
+import (
+	"api/internal/models"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// TransferMeeting hands a meeting to a new organizer.
+func (s *MeetingService) TransferMeeting(id, userID uint, req *models.TransferMeetingRequest) (*models.Meeting, error) {
+	meeting, err := s.meetingRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("meeting not found")
+	}
+
+	if err := s.authorize(userID, models.ActionMeetingUpdate, meeting); err != nil {
+		return nil, err
+	}
+
+	if !meeting.IsActive() {
+		return nil, errors.New("only scheduled, pending, held or in-progress meetings can be transferred")
+	}
+
+	newOrganizer, err := s.newOrganizer(req.NewOrganizerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.transferMeeting(meeting, newOrganizer, userID); err != nil {
+		return nil, err
+	}
+
+	return s.meetingRepo.GetByID(id)
+}
+
+// TransferUserMeetings hands every upcoming meeting a user organizes to a
+// new organizer. Users may transfer their own meetings; transferring those
+// of others needs meeting.update.any.
+func (s *MeetingService) TransferUserMeetings(userID uint, req *models.TransferMeetingsRequest) (*models.MeetingTransferReport, error) {
+	if err := s.authorize(userID, models.ActionMeetingUpdate, &models.User{ID: req.FromUserID}); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userRepo.GetByID(req.FromUserID); err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	newOrganizer, err := s.newOrganizer(req.NewOrganizerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.TransferAllMeetings(req.FromUserID, newOrganizer, userID)
+}
+
+// GetUpcomingOrganized lists the meetings organizerID still has ahead.
+func (s *MeetingService) GetUpcomingOrganized(organizerID uint) ([]*models.Meeting, error) {
+	return s.meetingRepo.GetActiveByOrganizer(organizerID, time.Now())
+}
+
+// TransferAllMeetings hands the upcoming meetings of fromUserID to
+// newOrganizer without authorizing actorID, who is recorded as having
+// performed the transfer. Meetings newOrganizer may not take over are
+// reported as failed and left unchanged.
+func (s *MeetingService) TransferAllMeetings(fromUserID uint, newOrganizer *models.User, actorID uint) (*models.MeetingTransferReport, error) {
+	if newOrganizer.ID == fromUserID {
+		return nil, errors.New("the new organizer must be a different user")
+	}
+
+	meetings, err := s.GetUpcomingOrganized(fromUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.MeetingTransferReport{
+		FromUserID:   fromUserID,
+		NewOrganizer: newOrganizer,
+		Transferred:  []*models.Meeting{},
+		Failed:       []models.MeetingTransferFailure{},
+	}
+	for _, meeting := range meetings {
+		if err := s.transferMeeting(meeting, newOrganizer, actorID); err != nil {
+			report.Failed = append(report.Failed, models.MeetingTransferFailure{Meeting: meeting, Reason: err.Error()})
+			continue
+		}
+		report.Transferred = append(report.Transferred, meeting)
+	}
+
+	return report, nil
+}
+
+// newOrganizer loads the user meetings are transferred to.
+func (s *MeetingService) newOrganizer(id uint) (*models.User, error) {
+	user, err := s.userRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("new organizer not found")
+	}
+	if !user.IsActive {
+		return nil, errors.New("new organizer is not active")
+	}
+	return user, nil
+}
+
+// transferMeeting makes newOrganizer the organizer of meeting, provided
+// they could have booked it themselves. A new organizer who attended the
+// meeting is no longer listed as an attendee.
+func (s *MeetingService) transferMeeting(meeting *models.Meeting, newOrganizer *models.User, actorID uint) error {
+	if meeting.OrganizerID == newOrganizer.ID {
+		return errors.New("the user already organizes this meeting")
+	}
+
+	if err := s.authzService.Authorize(models.SubjectFromUser(newOrganizer), models.ActionMeetingCreate, nil); err != nil {
+		return err
+	}
+	room, err := s.roomRepo.GetByID(meeting.RoomID)
+	if err != nil {
+		return errors.New("room not found")
+	}
+	if err := s.checkRoomAccess(room, newOrganizer); err != nil {
+		return err
+	}
+
+	if err := s.meetingRepo.UpdateOrganizer(meeting.ID, newOrganizer.ID); err != nil {
+		return err
+	}
+
+	for i, attendee := range meeting.Attendees {
+		if attendee.ID == newOrganizer.ID {
+			if err := s.meetingRepo.RemoveAttendee(meeting.ID, attendee.ID); err != nil {
+				log.Printf("Failed to remove new organizer %d from the attendees of meeting %d: %v", attendee.ID, meeting.ID, err)
+			}
+			if err := s.meetingRepo.RemoveGroupAttendees(meeting.ID, attendee.ID); err != nil {
+				log.Printf("Failed to remove new organizer %d from the group attendees of meeting %d: %v", attendee.ID, meeting.ID, err)
+			}
+			meeting.Attendees = append(meeting.Attendees[:i], meeting.Attendees[i+1:]...)
+			break
+		}
+	}
+
+	previousOrganizerID := meeting.OrganizerID
+	previousOrganizer := meeting.Organizer.FullName()
+	meeting.OrganizerID = newOrganizer.ID
+	meeting.Organizer = *newOrganizer
+	s.saveActivity(&models.MeetingActivity{
+		MeetingID:           meeting.ID,
+		Action:              models.MeetingActivityTransferred,
+		ActorID:             actorID,
+		PreviousOrganizerID: &previousOrganizerID,
+		NewOrganizerID:      &newOrganizer.ID,
+	})
+
+	s.notifier.Notify(newOrganizer, "Meeting transferred to you",
+		fmt.Sprintf("You now organize %q in %s from %s to %s, previously organized by %s",
+			meeting.Title, meeting.Room.Name, meeting.StartTime.Format(time.RFC3339), meeting.EndTime.Format(time.RFC3339), previousOrganizer))
+	return nil
+}
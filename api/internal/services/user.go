@@ -5,15 +5,21 @@ import (
 	"api/internal/repositories"
 	"api/internal/utils"
 	"errors"
+	"regexp"
+	"strings"
 )
 
 type UserService struct {
-	userRepo repositories.UserRepository
+	userRepo       repositories.UserRepository
+	floorRepo      repositories.FloorRepository
+	meetingService *MeetingService
 }
 
-func NewUserService(userRepo repositories.UserRepository) *UserService {
+func NewUserService(userRepo repositories.UserRepository, floorRepo repositories.FloorRepository, meetingService *MeetingService) *UserService {
 	return &UserService{
-		userRepo: userRepo,
+		userRepo:       userRepo,
+		floorRepo:      floorRepo,
+		meetingService: meetingService,
 	}
 }
 
@@ -27,13 +33,17 @@ func (s *UserService) CreateUser(req *models.CreateUserRequest) (*models.User, e
 		return nil, errors.New("user with this email already exists")
 	}
 
-	existingUserByMSID, err := s.userRepo.GetByMicrosoftID(req.MicrosoftID)
-	if err == nil && existingUserByMSID != nil {
-		return nil, errors.New("user with this Microsoft ID already exists")
+	var microsoftID *string
+	if req.MicrosoftID != "" {
+		existingUserByMSID, err := s.userRepo.GetByMicrosoftID(req.MicrosoftID)
+		if err == nil && existingUserByMSID != nil {
+			return nil, errors.New("user with this Microsoft ID already exists")
+		}
+		microsoftID = &req.MicrosoftID
 	}
 
 	user := &models.User{
-		MicrosoftID:    req.MicrosoftID,
+		MicrosoftID:    microsoftID,
 		Email:          req.Email,
 		FirstName:      req.FirstName,
 		LastName:       req.LastName,
@@ -46,6 +56,49 @@ func (s *UserService) CreateUser(req *models.CreateUserRequest) (*models.User, e
 	return s.userRepo.Create(user)
 }
 
+var serviceAccountSlugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// CreateServiceAccount creates a non-human user that can only authenticate
+// with personal access tokens issued by an admin.
+func (s *UserService) CreateServiceAccount(req *models.CreateServiceAccountRequest) (*models.User, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.New("validation failed")
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if email == "" {
+		slug := strings.Trim(serviceAccountSlugPattern.ReplaceAllString(strings.ToLower(req.Name), "-"), "-")
+		if slug == "" {
+			return nil, errors.New("service account name must contain letters or digits")
+		}
+		email = "svc-" + slug + "@service-accounts.local"
+	}
+
+	if existingUser, err := s.userRepo.GetByEmail(email); err == nil && existingUser != nil {
+		return nil, errors.New("user with this email already exists")
+	}
+
+	role := req.Role
+	if role == "" {
+		role = models.RoleEmployee
+	}
+
+	user := &models.User{
+		Email:            email,
+		FirstName:        req.Name,
+		DisplayName:      req.Name,
+		Role:             role,
+		IsActive:         true,
+		IsServiceAccount: true,
+	}
+
+	return s.userRepo.Create(user)
+}
+
+func (s *UserService) GetServiceAccounts() ([]*models.User, error) {
+	return s.userRepo.GetServiceAccounts()
+}
+
 func (s *UserService) GetUserByID(id uint) (*models.User, error) {
 	return s.userRepo.GetByID(id)
 }
@@ -91,25 +144,31 @@ func (s *UserService) UpdateUser(id uint, req *models.UpdateUserRequest) (*model
 		user.Role = *req.Role
 	}
 	if req.IsActive != nil {
+		// Deactivation has to go through DeactivateUser so the user's
+		// upcoming meetings are transferred or reported.
+		if user.IsActive && !*req.IsActive {
+			return nil, errors.New("users are deactivated by deleting them, so their meetings can be transferred")
+		}
 		user.IsActive = *req.IsActive
 	}
-
-	return s.userRepo.Update(user)
-}
-
-func (s *UserService) DeleteUser(id uint) error {
-	user, err := s.userRepo.GetByID(id)
-	if err != nil {
-		return errors.New("user not found")
+	if req.TimeZone != nil {
+		if _, err := models.LoadTimeZone(*req.TimeZone); err != nil {
+			return nil, err
+		}
+		user.TimeZone = *req.TimeZone
 	}
-
-	if !user.IsActive {
-		return errors.New("user is already inactive")
+	if req.HomeFloorID != nil {
+		if *req.HomeFloorID == 0 {
+			user.HomeFloorID = nil
+		} else {
+			if _, err := s.floorRepo.GetByID(*req.HomeFloorID); err != nil {
+				return nil, errors.New("floor not found")
+			}
+			user.HomeFloorID = req.HomeFloorID
+		}
 	}
 
-	user.IsActive = false
-	_, err = s.userRepo.Update(user)
-	return err
+	return s.userRepo.Update(user)
 }
 
 func (s *UserService) GetActiveUsers() ([]*models.User, error) {
@@ -153,17 +212,47 @@ func (s *UserService) ActivateUser(id uint) error {
 	return err
 }
 
-func (s *UserService) DeactivateUser(id uint) error {
+// DeactivateUser deactivates a user and reports the upcoming meetings they
+// still organize. With transferTo those meetings are first handed to that
+// user, recorded as done by actorID; otherwise all of them are reported so
+// they can be transferred later. The user is only deactivated once
+// everything else succeeded.
+func (s *UserService) DeactivateUser(id, actorID uint, transferTo *uint) (*models.UserDeactivationReport, error) {
 	user, err := s.userRepo.GetByID(id)
 	if err != nil {
-		return errors.New("user not found")
+		return nil, errors.New("user not found")
 	}
 
 	if !user.IsActive {
-		return errors.New("user is already inactive")
+		return nil, errors.New("user is already inactive")
+	}
+
+	var newOrganizer *models.User
+	if transferTo != nil {
+		if *transferTo == id {
+			return nil, errors.New("meetings cannot be transferred to the user being deactivated")
+		}
+		if newOrganizer, err = s.meetingService.newOrganizer(*transferTo); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &models.UserDeactivationReport{User: user}
+	if newOrganizer != nil {
+		if report.Transfer, err = s.meetingService.TransferAllMeetings(id, newOrganizer, actorID); err != nil {
+			return nil, err
+		}
+		report.UpcomingMeetings = []*models.Meeting{}
+		for _, failure := range report.Transfer.Failed {
+			report.UpcomingMeetings = append(report.UpcomingMeetings, failure.Meeting)
+		}
+	} else if report.UpcomingMeetings, err = s.meetingService.GetUpcomingOrganized(id); err != nil {
+		return nil, err
 	}
 
 	user.IsActive = false
-	_, err = s.userRepo.Update(user)
-	return err
+	if _, err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+	return report, nil
 }
\ No newline at end of file
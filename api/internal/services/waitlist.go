@@ -0,0 +1,311 @@
+package services
+
+import (
+	"api/internal/models"
+	"api/internal/utils"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// JoinWaitlist queues a booking request for a room that is taken at the
+// requested time. Free rooms must be booked directly.
+func (s *MeetingService) JoinWaitlist(userID uint, req *models.JoinWaitlistRequest) (*models.WaitlistEntry, error) {
+	if validationErrors := utils.ValidateStruct(req); len(validationErrors) > 0 {
+		return nil, errors.New("validation failed")
+	}
+
+	if !req.EndTime.After(req.StartTime) {
+		return nil, errors.New("end time must be after start time")
+	}
+
+	if req.StartTime.Before(time.Now()) {
+		return nil, errors.New("meeting start time cannot be in the past")
+	}
+
+	room, err := s.roomRepo.GetByID(req.RoomID)
+	if err != nil {
+		return nil, errors.New("room not found")
+	}
+	if !room.IsActive {
+		return nil, errors.New("room is not active")
+	}
+	if room.IsDesk() {
+		return nil, ErrDeskNotMeetingRoom
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	if err := s.authzService.Authorize(models.SubjectFromUser(user), models.ActionMeetingCreate, nil); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkRoomAccess(room, user); err != nil {
+		return nil, err
+	}
+
+	available, err := s.roomRepo.IsRoomAvailable(req.RoomID, req.StartTime, req.EndTime, req.BookingBuffers, nil)
+	if err != nil {
+		return nil, err
+	}
+	if available {
+		return nil, errors.New("room is available for the selected time; book it directly")
+	}
+
+	entry, err := s.waitlistRepo.Create(&models.WaitlistEntry{
+		RoomID:         req.RoomID,
+		UserID:         userID,
+		Title:          req.Title,
+		Description:    req.Description,
+		StartTime:      req.StartTime,
+		EndTime:        req.EndTime,
+		AttendeeIDs:    req.AttendeeIDs,
+		AutoBook:       req.AutoBook,
+		Status:         models.WaitlistWaiting,
+		BookingBuffers: req.BookingBuffers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.setWaitlistPosition(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *MeetingService) GetWaitlist(userID uint, page, limit int) ([]*models.WaitlistEntry, utils.PaginationMeta, error) {
+	offset := utils.GetOffset(page, limit)
+	entries, total, err := s.waitlistRepo.GetByUser(userID, offset, limit)
+	if err != nil {
+		return nil, utils.PaginationMeta{}, err
+	}
+
+	for _, entry := range entries {
+		if err := s.setWaitlistPosition(entry); err != nil {
+			return nil, utils.PaginationMeta{}, err
+		}
+	}
+
+	meta := utils.CreatePaginationMeta(page, limit, total)
+	return entries, meta, nil
+}
+
+// LeaveWaitlist withdraws a pending entry.
+func (s *MeetingService) LeaveWaitlist(id, userID uint) error {
+	entry, err := s.waitlistRepo.GetByID(id)
+	if err != nil {
+		return errors.New("waitlist entry not found")
+	}
+
+	if err := s.authorize(userID, models.ActionMeetingDelete, entry); err != nil {
+		return err
+	}
+
+	if !isPendingWaitlistEntry(entry) {
+		return errors.New("only waiting or offered entries can be withdrawn")
+	}
+
+	wasOffered := entry.Status == models.WaitlistOffered
+	entry.Status = models.WaitlistCancelled
+	entry.OfferExpiresAt = nil
+	if err := s.waitlistRepo.Update(entry); err != nil {
+		return err
+	}
+
+	if wasOffered {
+		s.offerFreedSlot(entry.RoomID, entry.StartTime, entry.EndTime)
+	}
+	return nil
+}
+
+// AcceptWaitlistOffer books the slot offered to the entry's user. The slot
+// is not held while offered, so this fails if someone booked it meanwhile.
+func (s *MeetingService) AcceptWaitlistOffer(id, userID uint) (*models.Meeting, error) {
+	entry, err := s.waitlistRepo.GetByID(id)
+	if err != nil {
+		return nil, errors.New("waitlist entry not found")
+	}
+
+	if !entry.IsOwnedBy(userID) {
+		return nil, fmt.Errorf("%w: only the user on the waitlist can accept its offer", ErrPermissionDenied)
+	}
+
+	if entry.Status != models.WaitlistOffered {
+		return nil, errors.New("waitlist entry has no open offer")
+	}
+	if entry.OfferExpiresAt != nil && !entry.OfferExpiresAt.After(time.Now()) {
+		return nil, errors.New("the offer has expired")
+	}
+
+	meeting, err := s.CreateMeeting(entry.UserID, entry.MeetingRequest())
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Status = models.WaitlistBooked
+	entry.OfferExpiresAt = nil
+	entry.MeetingID = &meeting.ID
+	if err := s.waitlistRepo.Update(entry); err != nil {
+		return nil, err
+	}
+
+	return meeting, nil
+}
+
+// ExpireWaitlist closes offers that were not accepted in time, passing their
+// slot on to the next in line, and entries whose slot has started.
+func (s *MeetingService) ExpireWaitlist() (int, error) {
+	now := time.Now()
+	entries, err := s.waitlistRepo.GetExpired(now)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, entry := range entries {
+		wasOffered := entry.Status == models.WaitlistOffered
+		entry.Status = models.WaitlistExpired
+		entry.OfferExpiresAt = nil
+		if err := s.waitlistRepo.Update(entry); err != nil {
+			return expired, err
+		}
+		expired++
+
+		if wasOffered && entry.StartTime.After(now) {
+			s.offerFreedSlot(entry.RoomID, entry.StartTime, entry.EndTime)
+		}
+	}
+
+	return expired, nil
+}
+
+// StartWaitlistSweeper runs ExpireWaitlist every interval until the process
+// exits. A non-positive interval disables it.
+func (s *MeetingService) StartWaitlistSweeper(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if _, err := s.ExpireWaitlist(); err != nil {
+				log.Printf("Failed to expire waitlist entries: %v", err)
+			}
+		}
+	}()
+}
+
+// releaseSlot hands the time meeting held in its room, buffers included, to
+// the waitlist: each upcoming instance of a recurring meeting, up to the last
+// slot anyone waits for in the room. It must be called with the meeting as it
+// was before it was cancelled or moved.
+func (s *MeetingService) releaseSlot(meeting *models.Meeting) {
+	if !meeting.IsActive() {
+		return
+	}
+
+	now := time.Now()
+	horizon, err := s.waitlistRepo.GetLatestWaitingEnd(meeting.RoomID, now)
+	if err != nil {
+		log.Printf("Failed to load the waitlist of room %d: %v", meeting.RoomID, err)
+		return
+	}
+	if horizon == nil {
+		return
+	}
+
+	setup, cleanup := meeting.BookingBuffers.Resolve(&meeting.Room)
+	for _, occurrence := range meeting.Occurrences(now.Add(-cleanup), horizon.Add(setup), maxMeetingOccurrences) {
+		s.offerFreedSlot(meeting.RoomID, occurrence.StartTime.Add(-setup), occurrence.EndTime.Add(cleanup))
+	}
+}
+
+// offerFreedSlot gives the first waiting entry for roomID that overlaps
+// [startTime, endTime) and now fits its room a go: entries with AutoBook are
+// booked on the spot, others are offered. Entries that still do not fit, or
+// whose automatic booking fails, keep waiting.
+func (s *MeetingService) offerFreedSlot(roomID uint, startTime, endTime time.Time) {
+	now := time.Now()
+	entries, err := s.waitlistRepo.GetWaitingForSlot(roomID, startTime, endTime, now)
+	if err != nil {
+		log.Printf("Failed to load the waitlist of room %d: %v", roomID, err)
+		return
+	}
+
+	for _, entry := range entries {
+		available, err := s.roomRepo.IsRoomAvailable(entry.RoomID, entry.StartTime, entry.EndTime, entry.BookingBuffers, nil)
+		if err != nil {
+			log.Printf("Failed to check room %d for waitlist entry %d: %v", entry.RoomID, entry.ID, err)
+			return
+		}
+		if !available {
+			continue
+		}
+
+		if entry.AutoBook {
+			meeting, err := s.CreateMeeting(entry.UserID, entry.MeetingRequest())
+			if err != nil {
+				log.Printf("Could not book waitlist entry %d: %v", entry.ID, err)
+				continue
+			}
+
+			entry.Status = models.WaitlistBooked
+			entry.MeetingID = &meeting.ID
+			if err := s.waitlistRepo.Update(entry); err != nil {
+				log.Printf("Failed to update waitlist entry %d: %v", entry.ID, err)
+			}
+			s.notifyWaitlist(entry, "Waitlisted booking confirmed",
+				fmt.Sprintf("%s is now booked for %q from %s to %s", entry.Room.Name, entry.Title,
+					entry.StartTime.Format(time.RFC3339), entry.EndTime.Format(time.RFC3339)))
+			return
+		}
+
+		expiresAt := now.Add(s.bookingConfig.WaitlistOfferTTL)
+		if entry.StartTime.Before(expiresAt) {
+			expiresAt = entry.StartTime
+		}
+		entry.Status = models.WaitlistOffered
+		entry.OfferExpiresAt = &expiresAt
+		if err := s.waitlistRepo.Update(entry); err != nil {
+			log.Printf("Failed to update waitlist entry %d: %v", entry.ID, err)
+			return
+		}
+		s.notifyWaitlist(entry, "Room available",
+			fmt.Sprintf("%s is free for %q from %s to %s. Accept the offer before %s to book it", entry.Room.Name, entry.Title,
+				entry.StartTime.Format(time.RFC3339), entry.EndTime.Format(time.RFC3339), expiresAt.Format(time.RFC3339)))
+		return
+	}
+}
+
+func (s *MeetingService) notifyWaitlist(entry *models.WaitlistEntry, subject, message string) {
+	if entry.User == nil {
+		return
+	}
+	s.notifier.Notify(entry.User, subject, message)
+}
+
+func (s *MeetingService) setWaitlistPosition(entry *models.WaitlistEntry) error {
+	entry.Position = 0
+	if !isPendingWaitlistEntry(entry) {
+		return nil
+	}
+
+	ahead, err := s.waitlistRepo.CountAhead(entry)
+	if err != nil {
+		return err
+	}
+	entry.Position = int(ahead) + 1
+	return nil
+}
+
+func isPendingWaitlistEntry(entry *models.WaitlistEntry) bool {
+	return entry.Status == models.WaitlistWaiting || entry.Status == models.WaitlistOffered
+}
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"api/internal/config"
+	"api/internal/models"
+	"api/internal/repositories"
+	"testing"
+	"time"
+)
+
+// fakeWaitlistRepository keeps entries in memory, in the order they joined.
+type fakeWaitlistRepository struct {
+	repositories.WaitlistRepository
+	entries []*models.WaitlistEntry
+}
+
+func (r *fakeWaitlistRepository) Update(entry *models.WaitlistEntry) error {
+	for i, stored := range r.entries {
+		if stored.ID == entry.ID {
+			copied := *entry
+			r.entries[i] = &copied
+		}
+	}
+	return nil
+}
+
+func (r *fakeWaitlistRepository) GetWaitingForSlot(roomID uint, startTime, endTime, now time.Time) ([]*models.WaitlistEntry, error) {
+	var entries []*models.WaitlistEntry
+	for _, entry := range r.entries {
+		if entry.RoomID == roomID && entry.Status == models.WaitlistWaiting && entry.StartTime.After(now) &&
+			entry.StartTime.Before(endTime) && entry.EndTime.After(startTime) {
+			copied := *entry
+			entries = append(entries, &copied)
+		}
+	}
+	return entries, nil
+}
+
+func (r *fakeWaitlistRepository) GetLatestWaitingEnd(roomID uint, now time.Time) (*time.Time, error) {
+	var latest *time.Time
+	for _, entry := range r.entries {
+		if entry.RoomID == roomID && entry.Status == models.WaitlistWaiting && entry.StartTime.After(now) &&
+			(latest == nil || entry.EndTime.After(*latest)) {
+			end := entry.EndTime
+			latest = &end
+		}
+	}
+	return latest, nil
+}
+
+func (r *fakeWaitlistRepository) GetExpired(now time.Time) ([]*models.WaitlistEntry, error) {
+	var entries []*models.WaitlistEntry
+	for _, entry := range r.entries {
+		offerLapsed := entry.Status == models.WaitlistOffered && !entry.OfferExpiresAt.After(now)
+		slotStarted := isPendingWaitlistEntry(entry) && !entry.StartTime.After(now)
+		if offerLapsed || slotStarted {
+			copied := *entry
+			entries = append(entries, &copied)
+		}
+	}
+	return entries, nil
+}
+
+func (r *fakeWaitlistRepository) status(id uint) models.WaitlistStatus {
+	for _, entry := range r.entries {
+		if entry.ID == id {
+			return entry.Status
+		}
+	}
+	return ""
+}
+
+type recordingNotifier struct {
+	subjects []string
+}
+
+func (n *recordingNotifier) Notify(recipient *models.User, subject, message string) {
+	n.subjects = append(n.subjects, subject)
+}
+
+func newTestWaitlistService(waitlist *fakeWaitlistRepository) *MeetingService {
+	return &MeetingService{
+		roomRepo:      &fakeRoomRepository{rooms: map[uint]*models.Room{}},
+		waitlistRepo:  waitlist,
+		notifier:      &recordingNotifier{},
+		bookingConfig: config.BookingConfig{WaitlistOfferTTL: time.Hour},
+	}
+}
+
+func waitlistEntry(id, roomID uint, start time.Time, length time.Duration) *models.WaitlistEntry {
+	return &models.WaitlistEntry{
+		ID:        id,
+		RoomID:    roomID,
+		UserID:    id,
+		StartTime: start,
+		EndTime:   start.Add(length),
+		Status:    models.WaitlistWaiting,
+		Room:      &models.Room{ID: roomID},
+		User:      &models.User{ID: id},
+	}
+}
+
+func TestReleaseSlot(t *testing.T) {
+	start := time.Now().UTC().Truncate(time.Hour).Add(24 * time.Hour)
+	week := 7 * 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		recurring bool
+		want      map[uint]models.WaitlistStatus
+	}{
+		{"single meeting", false, map[uint]models.WaitlistStatus{
+			1: models.WaitlistOffered, 2: models.WaitlistWaiting, 3: models.WaitlistWaiting, 4: models.WaitlistWaiting, 5: models.WaitlistWaiting,
+		}},
+		{"recurring meeting frees every instance", true, map[uint]models.WaitlistStatus{
+			1: models.WaitlistOffered, 2: models.WaitlistOffered, 3: models.WaitlistWaiting, 4: models.WaitlistWaiting, 5: models.WaitlistWaiting,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			waitlist := &fakeWaitlistRepository{entries: []*models.WaitlistEntry{
+				waitlistEntry(1, 1, start, time.Hour),
+				waitlistEntry(2, 1, start.Add(2*week), time.Hour),
+				waitlistEntry(3, 1, start.Add(24*time.Hour), time.Hour),
+				waitlistEntry(4, 1, start.Add(2*week), time.Hour),
+				waitlistEntry(5, 2, start, time.Hour),
+			}}
+			meetingService := newTestWaitlistService(waitlist)
+
+			meetingService.releaseSlot(&models.Meeting{
+				RoomID:            1,
+				Room:              models.Room{ID: 1},
+				StartTime:         start,
+				EndTime:           start.Add(time.Hour),
+				Status:            models.StatusScheduled,
+				IsRecurring:       tt.recurring,
+				RecurrencePattern: models.RecurrenceWeekly,
+				TimeZone:          "UTC",
+			})
+
+			for id, want := range tt.want {
+				if got := waitlist.status(id); got != want {
+					t.Errorf("entry %d is %s, want %s", id, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWaitlistOfferExpiry(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		startsIn   time.Duration
+		wantExpiry time.Duration
+	}{
+		{"offer lasts the offer TTL", 24 * time.Hour, time.Hour},
+		{"offer ends when the slot starts", 30 * time.Minute, 30 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := now.Add(tt.startsIn)
+			waitlist := &fakeWaitlistRepository{entries: []*models.WaitlistEntry{waitlistEntry(1, 1, start, time.Hour)}}
+			meetingService := newTestWaitlistService(waitlist)
+
+			meetingService.offerFreedSlot(1, start, start.Add(time.Hour))
+
+			entry := waitlist.entries[0]
+			if entry.Status != models.WaitlistOffered || entry.OfferExpiresAt == nil {
+				t.Fatalf("entry is %s with expiry %v, want an offer", entry.Status, entry.OfferExpiresAt)
+			}
+			if got := entry.OfferExpiresAt.Sub(now); got < tt.wantExpiry-time.Minute || got > tt.wantExpiry+time.Minute {
+				t.Errorf("offer expires in %v, want %v", got, tt.wantExpiry)
+			}
+		})
+	}
+}
+
+func TestExpireWaitlist(t *testing.T) {
+	now := time.Now()
+	tomorrow := now.Add(24 * time.Hour)
+	lapsed := now.Add(-time.Minute)
+	open := now.Add(time.Hour)
+
+	lapsedOffer := waitlistEntry(1, 1, tomorrow, time.Hour)
+	lapsedOffer.Status = models.WaitlistOffered
+	lapsedOffer.OfferExpiresAt = &lapsed
+	openOffer := waitlistEntry(4, 2, tomorrow, time.Hour)
+	openOffer.Status = models.WaitlistOffered
+	openOffer.OfferExpiresAt = &open
+
+	waitlist := &fakeWaitlistRepository{entries: []*models.WaitlistEntry{
+		lapsedOffer,
+		waitlistEntry(2, 1, tomorrow, time.Hour),
+		waitlistEntry(3, 1, now.Add(-time.Hour), time.Hour),
+		openOffer,
+	}}
+	meetingService := newTestWaitlistService(waitlist)
+
+	expired, err := meetingService.ExpireWaitlist()
+	if err != nil {
+		t.Fatalf("ExpireWaitlist() error = %v", err)
+	}
+	if expired != 2 {
+		t.Errorf("ExpireWaitlist() = %d, want 2", expired)
+	}
+
+	want := map[uint]models.WaitlistStatus{
+		1: models.WaitlistExpired,
+		2: models.WaitlistOffered,
+		3: models.WaitlistExpired,
+		4: models.WaitlistOffered,
+	}
+	for id, status := range want {
+		if got := waitlist.status(id); got != status {
+			t.Errorf("entry %d is %s, want %s", id, got, status)
+		}
+	}
+}
+
+// fakeApprovalMeetingRepository serves expired approval requests. Those in
+// decided were approved or rejected after they were loaded.
+type fakeApprovalMeetingRepository struct {
+	repositories.MeetingRepository
+	pending []*models.Meeting
+	decided map[uint]bool
+}
+
+func (r *fakeApprovalMeetingRepository) GetExpiredPendingApprovals(now time.Time) ([]*models.Meeting, error) {
+	return r.pending, nil
+}
+
+func (r *fakeApprovalMeetingRepository) UpdateApprovalState(meeting *models.Meeting, from models.MeetingStatus) (bool, error) {
+	return !r.decided[meeting.ID], nil
+}
+
+func TestExpirePendingApprovalsOffersSlots(t *testing.T) {
+	start := time.Now().UTC().Truncate(time.Hour).Add(24 * time.Hour)
+	pending := func(id, roomID uint) *models.Meeting {
+		return &models.Meeting{
+			ID:        id,
+			RoomID:    roomID,
+			Room:      models.Room{ID: roomID},
+			StartTime: start,
+			EndTime:   start.Add(time.Hour),
+			Status:    models.StatusPendingApproval,
+			TimeZone:  "UTC",
+		}
+	}
+
+	waitlist := &fakeWaitlistRepository{entries: []*models.WaitlistEntry{
+		waitlistEntry(1, 1, start, time.Hour),
+		waitlistEntry(2, 2, start, time.Hour),
+	}}
+	meetingService := newTestWaitlistService(waitlist)
+	meetingService.meetingRepo = &fakeApprovalMeetingRepository{
+		pending: []*models.Meeting{pending(1, 1), pending(2, 2)},
+		decided: map[uint]bool{2: true},
+	}
+
+	expired, err := meetingService.ExpirePendingApprovals()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expired != 1 {
+		t.Errorf("expired %d requests, want 1", expired)
+	}
+	if got := waitlist.status(1); got != models.WaitlistOffered {
+		t.Errorf("entry for the expired request is %s, want offered", got)
+	}
+	if got := waitlist.status(2); got != models.WaitlistWaiting {
+		t.Errorf("entry for the decided request is %s, want waiting", got)
+	}
+}
@@ -2,11 +2,14 @@ package utils
 
 import (
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+const TokenTTL = 24 * time.Hour
+
 type JWTClaims struct {
 	UserID      uint   `json:"user_id"`
 	Email       string `json:"email"`
@@ -15,38 +18,100 @@ type JWTClaims struct {
 	jwt.RegisteredClaims
 }
 
+// JWTManager signs tokens either with a shared HS256 secret or, when a key
+// set is configured, with the currently active asymmetric key. With a key set
+// the secret, if any, only verifies HS256 tokens issued before the switch.
 type JWTManager struct {
 	secretKey string
+	mu        sync.RWMutex
+	keySet    *KeySet
 }
 
 func NewJWTManager(secretKey string) *JWTManager {
 	return &JWTManager{secretKey: secretKey}
 }
 
+// NewJWTManagerWithKeySet signs with keySet. Tokens signed with
+// fallbackSecret keep verifying so switching to a key set does not log
+// everybody out; pass "" to accept key set tokens only.
+func NewJWTManagerWithKeySet(keySet *KeySet, fallbackSecret string) *JWTManager {
+	return &JWTManager{secretKey: fallbackSecret, keySet: keySet}
+}
+
+// SetKeySet swaps in a reloaded key set. Tokens signed by keys that are still
+// present keep validating.
+func (j *JWTManager) SetKeySet(keySet *KeySet) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.keySet = keySet
+}
+
+func (j *JWTManager) currentKeySet() *KeySet {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.keySet
+}
+
 func (j *JWTManager) GenerateToken(userID uint, email, role, microsoftID string) (string, error) {
+	now := time.Now()
 	claims := &JWTClaims{
 		UserID:      userID,
 		Email:       email,
 		Role:        role,
 		MicrosoftID: microsoftID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(now.Add(TokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    "meeting-salt-api",
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(j.secretKey))
+	keySet := j.currentKeySet()
+	if keySet == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString([]byte(j.secretKey))
+	}
+
+	key, err := keySet.SigningKey(now)
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(signingMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.PrivateKey)
 }
 
 func (j *JWTManager) ValidateToken(tokenString string) (*JWTClaims, error) {
+	keySet := j.currentKeySet()
+
 	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+			if j.secretKey == "" {
+				return nil, errors.New("unexpected signing method")
+			}
+			return []byte(j.secretKey), nil
+		}
+		if keySet == nil {
 			return nil, errors.New("unexpected signing method")
 		}
-		return []byte(j.secretKey), nil
+
+		kid, ok := token.Header["kid"].(string)
+		if !ok || kid == "" {
+			return nil, errors.New("token is missing kid header")
+		}
+
+		key, err := keySet.VerificationKey(kid, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		if token.Method.Alg() != signingMethod(key.Algorithm).Alg() {
+			return nil, errors.New("unexpected signing method")
+		}
+
+		return key.PublicKey(), nil
 	})
 
 	if err != nil {
@@ -67,4 +132,21 @@ func (j *JWTManager) RefreshToken(tokenString string) (string, error) {
 	}
 
 	return j.GenerateToken(claims.UserID, claims.Email, claims.Role, claims.MicrosoftID)
-}
\ No newline at end of file
+}
+
+// JWKS returns the public verification keys. With an HS256 secret there is
+// nothing that can be published, so the set is empty.
+func (j *JWTManager) JWKS() JWKS {
+	keySet := j.currentKeySet()
+	if keySet == nil {
+		return JWKS{Keys: []JWK{}}
+	}
+	return keySet.JWKS(time.Now())
+}
+
+func signingMethod(alg string) jwt.SigningMethod {
+	if alg == AlgEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
@@ -0,0 +1,249 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+// SigningKey is one entry of the JWT key set. A key signs new tokens from
+// ActiveFrom until a newer key becomes active, and keeps verifying tokens
+// until RetireAt so rotation never invalidates tokens that are still live.
+type SigningKey struct {
+	ID         string
+	Algorithm  string
+	PrivateKey crypto.Signer
+	ActiveFrom time.Time
+	RetireAt   *time.Time
+}
+
+type KeySet struct {
+	keys []*SigningKey
+}
+
+type keyManifestEntry struct {
+	KeyID          string     `json:"kid"`
+	Algorithm      string     `json:"alg"`
+	PrivateKeyFile string     `json:"private_key_file"`
+	ActiveFrom     time.Time  `json:"active_from"`
+	RetireAt       *time.Time `json:"retire_at"`
+}
+
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// LoadKeySetFromFile reads a JSON manifest listing the signing keys. Private
+// key paths are resolved relative to the manifest, e.g.
+//
+//	[{"kid": "2026-10", "alg": "EdDSA", "private_key_file": "2026-10.pem",
+//	  "active_from": "2026-10-01T00:00:00Z", "retire_at": null}]
+func LoadKeySetFromFile(path string) (*KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key manifest: %v", err)
+	}
+
+	var entries []keyManifestEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse key manifest: %v", err)
+	}
+
+	if len(entries) == 0 {
+		return nil, errors.New("key manifest contains no keys")
+	}
+
+	seen := make(map[string]bool)
+	keys := make([]*SigningKey, 0, len(entries))
+	for _, entry := range entries {
+		if entry.KeyID == "" {
+			return nil, errors.New("key manifest entry is missing kid")
+		}
+		if seen[entry.KeyID] {
+			return nil, fmt.Errorf("duplicate kid %q in key manifest", entry.KeyID)
+		}
+		seen[entry.KeyID] = true
+
+		keyPath := entry.PrivateKeyFile
+		if !filepath.IsAbs(keyPath) {
+			keyPath = filepath.Join(filepath.Dir(path), keyPath)
+		}
+
+		signer, err := loadPrivateKey(keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %v", entry.KeyID, err)
+		}
+
+		if err := checkAlgorithm(entry.Algorithm, signer); err != nil {
+			return nil, fmt.Errorf("key %q: %v", entry.KeyID, err)
+		}
+
+		keys = append(keys, &SigningKey{
+			ID:         entry.KeyID,
+			Algorithm:  entry.Algorithm,
+			PrivateKey: signer,
+			ActiveFrom: entry.ActiveFrom,
+			RetireAt:   entry.RetireAt,
+		})
+	}
+
+	keySet := NewKeySet(keys)
+	for i := 0; i+1 < len(keySet.keys); i++ {
+		key, successor := keySet.keys[i], keySet.keys[i+1]
+		if key.RetireAt != nil && key.RetireAt.Before(successor.ActiveFrom.Add(TokenTTL)) {
+			return nil, fmt.Errorf("key %q retires before tokens it signed expire; retire_at must be at least %s after %q activates",
+				key.ID, TokenTTL, successor.ID)
+		}
+	}
+
+	return keySet, nil
+}
+
+func NewKeySet(keys []*SigningKey) *KeySet {
+	sorted := append([]*SigningKey(nil), keys...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ActiveFrom.Before(sorted[j].ActiveFrom)
+	})
+	return &KeySet{keys: sorted}
+}
+
+// SigningKey returns the most recently activated key that is not retired.
+func (k *KeySet) SigningKey(now time.Time) (*SigningKey, error) {
+	for i := len(k.keys) - 1; i >= 0; i-- {
+		key := k.keys[i]
+		if !key.ActiveFrom.After(now) && !key.isRetired(now) {
+			return key, nil
+		}
+	}
+	return nil, errors.New("no active JWT signing key")
+}
+
+func (k *KeySet) VerificationKey(kid string, now time.Time) (*SigningKey, error) {
+	for _, key := range k.keys {
+		if key.ID == kid {
+			if key.isRetired(now) {
+				return nil, fmt.Errorf("signing key %q is retired", kid)
+			}
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// JWKS publishes every key that is not yet retired, including keys scheduled
+// to activate later, so verifiers can cache them ahead of a rotation.
+func (k *KeySet) JWKS(now time.Time) JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	for _, key := range k.keys {
+		if key.isRetired(now) {
+			continue
+		}
+		jwks.Keys = append(jwks.Keys, key.jwk())
+	}
+	return jwks
+}
+
+func (key *SigningKey) isRetired(now time.Time) bool {
+	return key.RetireAt != nil && !now.Before(*key.RetireAt)
+}
+
+func (key *SigningKey) PublicKey() crypto.PublicKey {
+	return key.PrivateKey.Public()
+}
+
+func (key *SigningKey) jwk() JWK {
+	jwk := JWK{
+		KeyID:     key.ID,
+		Use:       "sig",
+		Algorithm: key.Algorithm,
+	}
+
+	switch pub := key.PublicKey().(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	}
+
+	return jwk
+}
+
+func loadPrivateKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, errors.New("unsupported private key type")
+		}
+		return signer, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+}
+
+func checkAlgorithm(alg string, signer crypto.Signer) error {
+	switch alg {
+	case AlgRS256:
+		pub, ok := signer.Public().(*rsa.PublicKey)
+		if !ok {
+			return errors.New("RS256 requires an RSA key")
+		}
+		if pub.N.BitLen() < 2048 {
+			return errors.New("RSA keys must be at least 2048 bits")
+		}
+	case AlgEdDSA:
+		if _, ok := signer.Public().(ed25519.PublicKey); !ok {
+			return errors.New("EdDSA requires an Ed25519 key")
+		}
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	return nil
+}
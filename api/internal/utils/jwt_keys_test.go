@@ -0,0 +1,263 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newEd25519Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func newRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// writeKey stores key as PEM in dir: RSA keys as PKCS #1, others as PKCS #8.
+func writeKey(t *testing.T, dir, name string, key crypto.Signer) {
+	t.Helper()
+	block := &pem.Block{Type: "PRIVATE KEY"}
+	if rsaKey, ok := key.(*rsa.PrivateKey); ok {
+		block = &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}
+	} else {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block.Bytes = der
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeManifest(t *testing.T, dir string, entries []keyManifestEntry) string {
+	t.Helper()
+	data, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "keys.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// signToken signs claims for user 1 with method and key, setting kid unless
+// it is empty.
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, kid string) string {
+	t.Helper()
+	now := time.Now()
+	token := jwt.NewWithClaims(method, &JWTClaims{
+		UserID: 1,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(TokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestLoadKeySetFromFile(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "ed.pem", newEd25519Key(t))
+	writeKey(t, dir, "rsa.pem", newRSAKey(t))
+
+	activeFrom := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	retireAt := activeFrom.Add(31 * 24 * time.Hour)
+	early := activeFrom.Add(30*24*time.Hour + time.Hour)
+	older := keyManifestEntry{KeyID: "2026-10", Algorithm: AlgEdDSA, PrivateKeyFile: "ed.pem", ActiveFrom: activeFrom, RetireAt: &retireAt}
+	newer := keyManifestEntry{KeyID: "2026-11", Algorithm: AlgRS256, PrivateKeyFile: filepath.Join(dir, "rsa.pem"), ActiveFrom: activeFrom.Add(30 * 24 * time.Hour)}
+
+	tests := []struct {
+		name    string
+		entries []keyManifestEntry
+		wantErr string
+	}{
+		{"relative and absolute key paths", []keyManifestEntry{newer, older}, ""},
+		{"no keys", []keyManifestEntry{}, "contains no keys"},
+		{"missing kid", []keyManifestEntry{{Algorithm: AlgEdDSA, PrivateKeyFile: "ed.pem"}}, "missing kid"},
+		{"duplicate kid", []keyManifestEntry{older, older}, "duplicate kid"},
+		{"algorithm does not match the key", []keyManifestEntry{{KeyID: "a", Algorithm: AlgRS256, PrivateKeyFile: "ed.pem"}}, "RS256 requires an RSA key"},
+		{"unsupported algorithm", []keyManifestEntry{{KeyID: "a", Algorithm: "HS256", PrivateKeyFile: "ed.pem"}}, "unsupported algorithm"},
+		{"missing key file", []keyManifestEntry{{KeyID: "a", Algorithm: AlgEdDSA, PrivateKeyFile: "missing.pem"}}, `key "a"`},
+		{"retired before its tokens expire", []keyManifestEntry{{KeyID: "2026-10", Algorithm: AlgEdDSA, PrivateKeyFile: "ed.pem", ActiveFrom: activeFrom, RetireAt: &early}, newer}, "retires before tokens it signed expire"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keySet, err := LoadKeySetFromFile(writeManifest(t, dir, tt.entries))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadKeySetFromFile() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadKeySetFromFile() error = %v", err)
+			}
+
+			for now, wantKid := range map[time.Time]string{activeFrom: "2026-10", newer.ActiveFrom: "2026-11"} {
+				key, err := keySet.SigningKey(now)
+				if err != nil || key.ID != wantKid {
+					t.Errorf("SigningKey(%s) = %v, %v, want %s", now, key, err, wantKid)
+				}
+			}
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	now := time.Now()
+	retireAt := now.Add(TokenTTL + time.Hour)
+	oldKey := &SigningKey{ID: "old", Algorithm: AlgEdDSA, PrivateKey: newEd25519Key(t), ActiveFrom: now.Add(-48 * time.Hour), RetireAt: &retireAt}
+	newKey := &SigningKey{ID: "new", Algorithm: AlgEdDSA, PrivateKey: newEd25519Key(t), ActiveFrom: now.Add(-time.Minute)}
+
+	oldToken, err := NewJWTManagerWithKeySet(NewKeySet([]*SigningKey{oldKey}), "").GenerateToken(1, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manager := NewJWTManagerWithKeySet(NewKeySet([]*SigningKey{oldKey, newKey}), "")
+	newToken, err := manager.GenerateToken(1, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &JWTClaims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kid := parsed.Header["kid"]; kid != "new" {
+		t.Errorf("new tokens are signed with kid %v, want new", kid)
+	}
+
+	for name, token := range map[string]string{"old": oldToken, "new": newToken} {
+		if _, err := manager.ValidateToken(token); err != nil {
+			t.Errorf("token signed with the %s key: %v", name, err)
+		}
+	}
+
+	retired := now.Add(-time.Minute)
+	oldKey.RetireAt = &retired
+	if _, err := manager.ValidateToken(oldToken); err == nil {
+		t.Error("token signed with a retired key still verifies")
+	}
+}
+
+func TestValidateTokenRejectsUnexpectedKeys(t *testing.T) {
+	edKey := newEd25519Key(t)
+	keySet := NewKeySet([]*SigningKey{{ID: "ed", Algorithm: AlgEdDSA, PrivateKey: edKey, ActiveFrom: time.Now().Add(-time.Hour)}})
+
+	tests := []struct {
+		name           string
+		fallbackSecret string
+		token          string
+		wantErr        bool
+	}{
+		{"known kid", "", signToken(t, jwt.SigningMethodEdDSA, edKey, "ed"), false},
+		{"unknown kid", "", signToken(t, jwt.SigningMethodEdDSA, edKey, "other"), true},
+		{"missing kid", "", signToken(t, jwt.SigningMethodEdDSA, edKey, ""), true},
+		{"algorithm does not match the kid", "", signToken(t, jwt.SigningMethodRS256, newRSAKey(t), "ed"), true},
+		{"HS256 without a fallback secret", "", signToken(t, jwt.SigningMethodHS256, []byte("secret"), ""), true},
+		{"HS256 signed with the fallback secret", "secret", signToken(t, jwt.SigningMethodHS256, []byte("secret"), ""), false},
+		{"HS256 signed with another secret", "secret", signToken(t, jwt.SigningMethodHS256, []byte("guess"), ""), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewJWTManagerWithKeySet(keySet, tt.fallbackSecret).ValidateToken(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateToken() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	now := time.Now()
+	retired := now.Add(-time.Hour)
+	edKey := newEd25519Key(t)
+	rsaKey := newRSAKey(t)
+	keySet := NewKeySet([]*SigningKey{
+		{ID: "retired", Algorithm: AlgEdDSA, PrivateKey: newEd25519Key(t), ActiveFrom: now.Add(-72 * time.Hour), RetireAt: &retired},
+		{ID: "ed", Algorithm: AlgEdDSA, PrivateKey: edKey, ActiveFrom: now.Add(-48 * time.Hour)},
+		{ID: "rsa", Algorithm: AlgRS256, PrivateKey: rsaKey, ActiveFrom: now.Add(time.Hour)},
+	})
+
+	data, err := json.Marshal(keySet.JWKS(now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var jwks struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(jwks.Keys) != 2 {
+		t.Fatalf("published %d keys, want the Ed25519 key and the upcoming RSA key: %s", len(jwks.Keys), data)
+	}
+	// An empty value only asks for the field to be set.
+	want := []map[string]string{
+		{"kty": "OKP", "kid": "ed", "use": "sig", "alg": "EdDSA", "crv": "Ed25519", "x": ""},
+		{"kty": "RSA", "kid": "rsa", "use": "sig", "alg": "RS256", "n": "", "e": "AQAB"},
+	}
+	for i, key := range jwks.Keys {
+		if len(key) != len(want[i]) {
+			t.Errorf("key %d has fields %v, want %v", i, key, want[i])
+		}
+		for field, value := range want[i] {
+			if key[field] == "" || (value != "" && key[field] != value) {
+				t.Errorf("key %d field %q = %q, want %q", i, field, key[field], value)
+			}
+		}
+	}
+
+	parsed, err := jwt.Parse(signToken(t, jwt.SigningMethodEdDSA, edKey, "ed"), func(token *jwt.Token) (interface{}, error) {
+		return ed25519.PublicKey(mustDecode(t, jwks.Keys[0]["x"])), nil
+	})
+	if err != nil || !parsed.Valid {
+		t.Errorf("published Ed25519 key does not verify its tokens: %v", err)
+	}
+}
+
+func mustDecode(t *testing.T, value string) []byte {
+	t.Helper()
+	data, err := jwt.NewParser().DecodeSegment(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}